@@ -37,7 +37,44 @@ let package = Package(
                 "qtypes.go",
                 "qtypes.pb.go",
                 "qtypes_test.go",
+                "granularity.go",
+                "granularity_test.go",
+                "condition.go",
+                "normalize.go",
+                "normalize_test.go",
+                "intersect.go",
+                "intersect_test.go",
+                "set.go",
+                "conflict.go",
+                "conflict_test.go",
+                "positive.go",
+                "positive_test.go",
+                "equivalent.go",
+                "equivalent_test.go",
+                "explain.go",
+                "explain_test.go",
+                "log.go",
+                "log_test.go",
+                "policy.go",
+                "policy_test.go",
+                "limits.go",
+                "limits_test.go",
+                "pattern.go",
+                "pattern_test.go",
+                "wildcard.go",
+                "wildcard_test.go",
+                "order.go",
+                "order_test.go",
+                "folding.go",
+                "folding_test.go",
                 "qtypeshttp",
+                "qtypeselastic",
+                "qtypesprom",
+                "qtypeslabels",
+                "qtypesodata",
+                "qtypesaip",
+                "qtypesrsql",
+                "qtypesmongo",
                 "qtypes",
                 "Makefile",
                 "setup.py",
@@ -66,7 +103,44 @@ let package = Package(
                 "qtypes.go",
                 "qtypes.pb.go",
                 "qtypes_test.go",
+                "granularity.go",
+                "granularity_test.go",
+                "condition.go",
+                "normalize.go",
+                "normalize_test.go",
+                "intersect.go",
+                "intersect_test.go",
+                "set.go",
+                "conflict.go",
+                "conflict_test.go",
+                "positive.go",
+                "positive_test.go",
+                "equivalent.go",
+                "equivalent_test.go",
+                "explain.go",
+                "explain_test.go",
+                "log.go",
+                "log_test.go",
+                "policy.go",
+                "policy_test.go",
+                "limits.go",
+                "limits_test.go",
+                "pattern.go",
+                "pattern_test.go",
+                "wildcard.go",
+                "wildcard_test.go",
+                "order.go",
+                "order_test.go",
+                "folding.go",
+                "folding_test.go",
                 "qtypeshttp",
+                "qtypeselastic",
+                "qtypesprom",
+                "qtypeslabels",
+                "qtypesodata",
+                "qtypesaip",
+                "qtypesrsql",
+                "qtypesmongo",
                 "qtypes",
                 "Makefile",
                 "setup.py",
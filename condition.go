@@ -0,0 +1,182 @@
+package qtypes
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// condition is type agnostic representation of Int64, Uint64, Float64 and Timestamp objects.
+type condition[T any] struct {
+	values         []T
+	valid          bool
+	negation       bool
+	t              QueryType
+	lowerExclusive bool
+	upperExclusive bool
+}
+
+// domain describes ordering of values of given type.
+type domain[T any] struct {
+	compare func(a, b T) int
+	// next and prev return adjacent value if there is any. They are nil for dense domains.
+	next, prev func(v T) (T, bool)
+	// min and max report whether value is the smallest or the greatest value of the domain.
+	min, max func(v T) bool
+}
+
+func (d domain[T]) discrete() bool {
+	return d.next != nil && d.prev != nil
+}
+
+var (
+	int64Domain = domain[int64]{
+		compare: cmp.Compare[int64],
+		next: func(v int64) (int64, bool) {
+			return v + 1, v < math.MaxInt64
+		},
+		prev: func(v int64) (int64, bool) {
+			return v - 1, v > math.MinInt64
+		},
+		min: func(v int64) bool { return v == math.MinInt64 },
+		max: func(v int64) bool { return v == math.MaxInt64 },
+	}
+	uint64Domain = domain[uint64]{
+		compare: cmp.Compare[uint64],
+		next: func(v uint64) (uint64, bool) {
+			return v + 1, v < math.MaxUint64
+		},
+		prev: func(v uint64) (uint64, bool) {
+			return v - 1, v > 0
+		},
+		min: func(v uint64) bool { return v == 0 },
+		max: func(v uint64) bool { return v == math.MaxUint64 },
+	}
+	float64Domain = domain[float64]{
+		compare: cmp.Compare[float64],
+		min:     func(v float64) bool { return math.IsInf(v, -1) },
+		max:     func(v float64) bool { return math.IsInf(v, 1) },
+	}
+	timestampDomain = domain[*knowntimestamp.Timestamp]{
+		compare: compareTimestamp,
+		min:     func(*knowntimestamp.Timestamp) bool { return false },
+		max:     func(*knowntimestamp.Timestamp) bool { return false },
+	}
+)
+
+func compareTimestamp(a, b *knowntimestamp.Timestamp) int {
+	if c := cmp.Compare(a.GetSeconds(), b.GetSeconds()); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.GetNanos(), b.GetNanos())
+}
+
+// sortedSet returns sorted copy of given values without duplicates.
+func sortedSet[T any](values []T, compare func(a, b T) int) []T {
+	res := slices.Clone(values)
+	slices.SortFunc(res, compare)
+	return slices.CompactFunc(res, func(a, b T) bool {
+		return compare(a, b) == 0
+	})
+}
+
+func int64Condition(i *Int64) condition[int64] {
+	return condition[int64]{
+		values:         i.GetValues(),
+		valid:          i.GetValid(),
+		negation:       i.GetNegation(),
+		t:              i.GetType(),
+		lowerExclusive: i.GetLowerExclusive(),
+		upperExclusive: i.GetUpperExclusive(),
+	}
+}
+
+func newInt64(c condition[int64]) *Int64 {
+	return &Int64{
+		Values:         slices.Clone(c.values),
+		Valid:          c.valid,
+		Negation:       c.negation,
+		Type:           c.t,
+		LowerExclusive: c.lowerExclusive,
+		UpperExclusive: c.upperExclusive,
+	}
+}
+
+func uint64Condition(u *Uint64) condition[uint64] {
+	return condition[uint64]{
+		values:         u.GetValues(),
+		valid:          u.GetValid(),
+		negation:       u.GetNegation(),
+		t:              u.GetType(),
+		lowerExclusive: u.GetLowerExclusive(),
+		upperExclusive: u.GetUpperExclusive(),
+	}
+}
+
+func newUint64(c condition[uint64]) *Uint64 {
+	return &Uint64{
+		Values:         slices.Clone(c.values),
+		Valid:          c.valid,
+		Negation:       c.negation,
+		Type:           c.t,
+		LowerExclusive: c.lowerExclusive,
+		UpperExclusive: c.upperExclusive,
+	}
+}
+
+func float64Condition(f *Float64) condition[float64] {
+	return condition[float64]{
+		values:         f.GetValues(),
+		valid:          f.GetValid(),
+		negation:       f.GetNegation(),
+		t:              f.GetType(),
+		lowerExclusive: f.GetLowerExclusive(),
+		upperExclusive: f.GetUpperExclusive(),
+	}
+}
+
+func newFloat64(c condition[float64]) *Float64 {
+	return &Float64{
+		Values:         slices.Clone(c.values),
+		Valid:          c.valid,
+		Negation:       c.negation,
+		Type:           c.t,
+		LowerExclusive: c.lowerExclusive,
+		UpperExclusive: c.upperExclusive,
+	}
+}
+
+func timestampCondition(t *Timestamp) condition[*knowntimestamp.Timestamp] {
+	return condition[*knowntimestamp.Timestamp]{
+		values:         t.GetValues(),
+		valid:          t.GetValid(),
+		negation:       t.GetNegation(),
+		t:              t.GetType(),
+		lowerExclusive: t.GetLowerExclusive(),
+		upperExclusive: t.GetUpperExclusive(),
+	}
+}
+
+func newTimestamp(c condition[*knowntimestamp.Timestamp]) *Timestamp {
+	var values []*knowntimestamp.Timestamp
+	if c.values != nil {
+		values = make([]*knowntimestamp.Timestamp, 0, len(c.values))
+		for _, v := range c.values {
+			if v == nil {
+				values = append(values, nil)
+				continue
+			}
+			values = append(values, &knowntimestamp.Timestamp{Seconds: v.Seconds, Nanos: v.Nanos})
+		}
+	}
+	return &Timestamp{
+		Values:         values,
+		Valid:          c.valid,
+		Negation:       c.negation,
+		Type:           c.t,
+		LowerExclusive: c.lowerExclusive,
+		UpperExclusive: c.upperExclusive,
+	}
+}
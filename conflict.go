@@ -0,0 +1,323 @@
+package qtypes
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Conflict is returned by the checkers when given conditions cannot be satisfied together.
+// It wraps ErrUnsatisfiable.
+type Conflict struct {
+	// Indexes point to a minimal subset of conditions that cannot be satisfied together.
+	// Removing any of them makes the rest satisfiable.
+	Indexes []int
+}
+
+// Error implements error interface.
+func (c *Conflict) Error() string {
+	if len(c.Indexes) == 1 {
+		return fmt.Sprintf("qtypes: condition at position %d cannot be satisfied", c.Indexes[0])
+	}
+	positions := make([]string, 0, len(c.Indexes))
+	for _, i := range c.Indexes {
+		positions = append(positions, strconv.Itoa(i))
+	}
+	return fmt.Sprintf("qtypes: conditions at positions %s cannot be satisfied together", strings.Join(positions, ", "))
+}
+
+// Unwrap returns ErrUnsatisfiable.
+func (c *Conflict) Unwrap() error {
+	return ErrUnsatisfiable
+}
+
+// CheckInt64 reports whether any value can satisfy all given conditions.
+// If none can, *Conflict that points to the conflicting conditions is returned.
+// Invalid conditions and conditions that cannot be evaluated (e.g. array operations) are ignored,
+// so nil means that no conflict has been found.
+func CheckInt64(conditions ...*Int64) error {
+	cs := make([]condition[int64], 0, len(conditions))
+	for _, c := range conditions {
+		cs = append(cs, int64Condition(c))
+	}
+	return check(cs, int64Domain)
+}
+
+// CheckUint64 works like CheckInt64.
+func CheckUint64(conditions ...*Uint64) error {
+	cs := make([]condition[uint64], 0, len(conditions))
+	for _, c := range conditions {
+		cs = append(cs, uint64Condition(c))
+	}
+	return check(cs, uint64Domain)
+}
+
+// CheckFloat64 works like CheckInt64.
+func CheckFloat64(conditions ...*Float64) error {
+	cs := make([]condition[float64], 0, len(conditions))
+	for _, c := range conditions {
+		cs = append(cs, float64Condition(c))
+	}
+	return check(cs, float64Domain)
+}
+
+// CheckTimestamp works like CheckInt64.
+func CheckTimestamp(conditions ...*Timestamp) error {
+	cs := make([]condition[*knowntimestamp.Timestamp], 0, len(conditions))
+	for _, c := range conditions {
+		if slices.Contains(c.GetValues(), nil) {
+			cs = append(cs, condition[*knowntimestamp.Timestamp]{})
+			continue
+		}
+		cs = append(cs, timestampCondition(c))
+	}
+	return check(cs, timestampDomain)
+}
+
+func check[T any](conditions []condition[T], d domain[T]) error {
+	sets := make(map[int]valueSet[T], len(conditions))
+	indexes := make([]int, 0, len(conditions))
+	for i, c := range conditions {
+		if s, ok := d.valueSet(c); ok {
+			sets[i] = s
+			indexes = append(indexes, i)
+		}
+	}
+
+	return findConflict(indexes, func(indexes []int) bool {
+		res := valueSet[T]{
+			null:      true,
+			intervals: []interval[T]{{lo: bound[T]{infinite: true}, hi: bound[T]{infinite: true}}},
+		}
+		for _, i := range indexes {
+			res = d.intersect(res, sets[i])
+		}
+		return res.empty()
+	})
+}
+
+// findConflict returns nil if conditions at given indexes are satisfiable.
+// Otherwise it narrows them down to a minimal conflicting subset by removing conditions one by one.
+func findConflict(indexes []int, unsatisfiable func(indexes []int) bool) error {
+	if !unsatisfiable(indexes) {
+		return nil
+	}
+	for i := 0; i < len(indexes); {
+		rest := slices.Delete(slices.Clone(indexes), i, i+1)
+		if unsatisfiable(rest) {
+			indexes = rest
+		} else {
+			i++
+		}
+	}
+	return &Conflict{Indexes: indexes}
+}
+
+// CheckString reports whether any value can satisfy all given conditions.
+// If none can, *Conflict that points to the conflicting conditions is returned.
+// Beside exact values it takes into account prefixes, suffixes, substrings and length limits,
+// e.g. HAS_PREFIX "abc" conflicts with MAX_LENGTH 2.
+// Conditions that cannot be reasoned about (e.g. PATTERN) are only evaluated against exact values,
+// so nil means that no conflict has been found.
+func CheckString(conditions ...*String) error {
+	normalized := make([]*String, 0, len(conditions))
+	indexes := make([]int, 0, len(conditions))
+	for i, c := range conditions {
+		if c.GetValid() {
+			normalized = append(normalized, c.Normalize())
+			indexes = append(indexes, i)
+		}
+	}
+
+	return findConflict(indexes, func(subset []int) bool {
+		cs := make([]*String, 0, len(subset))
+		for _, i := range subset {
+			cs = append(cs, normalized[slices.Index(indexes, i)])
+		}
+		return unsatisfiableStrings(cs)
+	})
+}
+
+// unsatisfiableStrings reports whether given normalized conditions certainly cannot be satisfied together.
+func unsatisfiableStrings(conditions []*String) bool {
+	var (
+		null, notNull    bool
+		candidates       []string
+		hasCandidates    bool
+		prefix, suffix   string
+		iprefix, isuffix string
+		substrings       []string
+		isubstrings      []string
+		minLength        uint64
+		maxLength        uint64 = math.MaxUint64
+	)
+
+	for _, s := range conditions {
+		if s.Type == QueryType_NULL {
+			if s.Negation {
+				notNull = true
+			} else {
+				null = true
+			}
+			continue
+		}
+		notNull = true
+		if s.Negation {
+			if s.Type == QueryType_MIN_LENGTH && len(s.Values) == 1 && s.Values[0] == "0" {
+				return true
+			}
+			continue
+		}
+		if len(s.Values) == 0 {
+			if s.Type == QueryType_IN {
+				return true
+			}
+			continue
+		}
+
+		v := s.Values[0]
+		if s.Insensitive {
+			v = foldCase(v)
+		}
+		switch s.Type {
+		case QueryType_EQUAL, QueryType_IN:
+			if s.Insensitive {
+				continue
+			}
+			if !hasCandidates {
+				candidates, hasCandidates = slices.Clone(s.Values), true
+				continue
+			}
+			candidates = slices.DeleteFunc(candidates, func(c string) bool {
+				return !slices.Contains(s.Values, c)
+			})
+		case QueryType_HAS_PREFIX:
+			p := &prefix
+			if s.Insensitive {
+				p = &iprefix
+			}
+			if !compatiblePrefix(*p, v) {
+				return true
+			}
+			if len(v) > len(*p) {
+				*p = v
+			}
+		case QueryType_HAS_SUFFIX:
+			p := &suffix
+			if s.Insensitive {
+				p = &isuffix
+			}
+			if !compatibleSuffix(*p, v) {
+				return true
+			}
+			if len(v) > len(*p) {
+				*p = v
+			}
+		case QueryType_SUBSTRING:
+			if s.Insensitive {
+				isubstrings = append(isubstrings, v)
+			} else {
+				substrings = append(substrings, v)
+			}
+		case QueryType_MIN_LENGTH, QueryType_MAX_LENGTH:
+			n, err := strconv.ParseUint(v, 10, 63)
+			if err != nil {
+				continue
+			}
+			if s.Type == QueryType_MIN_LENGTH {
+				minLength = max(minLength, n)
+			} else {
+				maxLength = min(maxLength, n)
+			}
+		}
+	}
+
+	switch {
+	case null && notNull:
+		return true
+	case null:
+		return false
+	case !compatiblePrefix(foldCase(prefix), iprefix), !compatibleSuffix(foldCase(suffix), isuffix):
+		return true
+	}
+
+	for _, v := range append([]string{prefix, suffix, iprefix, isuffix}, append(substrings, isubstrings...)...) {
+		minLength = max(minLength, uint64(utf8.RuneCountInString(v)))
+	}
+	if minLength > maxLength {
+		return true
+	}
+	if maxLength == 0 && !hasCandidates {
+		candidates, hasCandidates = []string{""}, true
+	}
+
+	if hasCandidates {
+		for _, c := range candidates {
+			if matchStrings(conditions, c) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Without exact values, negated conditions conflict only if they are contradicted by what is known for sure.
+	for _, s := range conditions {
+		if !s.Negation || len(s.Values) != 1 {
+			continue
+		}
+		v, p, sf, subs := s.Values[0], prefix, suffix, substrings
+		if s.Insensitive {
+			v, p, sf = foldCase(v), foldCase(prefix), foldCase(suffix)
+			subs = isubstrings
+			for _, sub := range substrings {
+				subs = append(subs, foldCase(sub))
+			}
+		}
+		switch s.Type {
+		case QueryType_HAS_PREFIX:
+			if strings.HasPrefix(p, v) || (s.Insensitive && strings.HasPrefix(iprefix, v)) {
+				return true
+			}
+		case QueryType_HAS_SUFFIX:
+			if strings.HasSuffix(sf, v) || (s.Insensitive && strings.HasSuffix(isuffix, v)) {
+				return true
+			}
+		case QueryType_SUBSTRING:
+			known := append([]string{p, sf}, subs...)
+			if s.Insensitive {
+				known = append(known, iprefix, isuffix)
+			}
+			for _, k := range known {
+				if strings.Contains(k, v) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// compatiblePrefix reports whether a value can have both given prefixes.
+func compatiblePrefix(a, b string) bool {
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// compatibleSuffix reports whether a value can have both given suffixes.
+func compatibleSuffix(a, b string) bool {
+	return strings.HasSuffix(a, b) || strings.HasSuffix(b, a)
+}
+
+// matchStrings reports whether value satisfies all conditions, conditions that cannot be evaluated are assumed to be satisfied.
+func matchStrings(conditions []*String, v string) bool {
+	for _, s := range conditions {
+		if ok, err := MatchString(s, v); err == nil && !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,197 @@
+package qtypes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCheckInt64(t *testing.T) {
+	cases := map[string]struct {
+		given    []*Int64
+		expected []int
+	}{
+		"none": {},
+		"satisfiable": {
+			given: []*Int64{InInt64(1, 2, 6), GreaterInt64(5)},
+		},
+		"in-greater": {
+			given:    []*Int64{InInt64(1, 2), GreaterInt64(5)},
+			expected: []int{0, 1},
+		},
+		"minimal-subset": {
+			given:    []*Int64{NotEqualInt64(3), LessInt64(10), InInt64(1, 2), nil, GreaterInt64(5)},
+			expected: []int{2, 4},
+		},
+		"range": {
+			given:    []*Int64{GreaterEqualInt64(5), LessEqualInt64(7), NotEqualInt64(6), RangeInt64(4, 7, false, true), NotEqualInt64(5)},
+			expected: []int{0, 2, 3, 4},
+		},
+		"empty-in": {
+			given:    []*Int64{EqualInt64(1), InInt64()},
+			expected: []int{1},
+		},
+		"null": {
+			given:    []*Int64{NullInt64(), NotEqualInt64(1)},
+			expected: []int{0, 1},
+		},
+		"array-ignored": {
+			given: []*Int64{{Values: []int64{1}, Valid: true, Type: QueryType_CONTAINS}, EqualInt64(1)},
+		},
+	}
+
+	for hint, c := range cases {
+		err := CheckInt64(c.given...)
+		if c.expected == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			}
+			continue
+		}
+		var conflict *Conflict
+		if !errors.As(err, &conflict) {
+			t.Errorf("%s: expected conflict, got %v", hint, err)
+			continue
+		}
+		if !errors.Is(err, ErrUnsatisfiable) {
+			t.Errorf("%s: conflict should wrap ErrUnsatisfiable", hint)
+		}
+		if !reflect.DeepEqual(c.expected, conflict.Indexes) {
+			t.Errorf("%s: wrong indexes, expected %v but got %v", hint, c.expected, conflict.Indexes)
+		}
+	}
+}
+
+func TestCheckFloat64(t *testing.T) {
+	err := CheckFloat64(
+		&Float64{Values: []float64{1}, Valid: true, Type: QueryType_GREATER},
+		&Float64{Values: []float64{1.5}, Valid: true, Type: QueryType_LESS},
+	)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	err = CheckFloat64(
+		&Float64{Values: []float64{1}, Valid: true, Type: QueryType_GREATER},
+		&Float64{Values: []float64{1}, Valid: true, Type: QueryType_LESS_EQUAL},
+	)
+	if !errors.Is(err, ErrUnsatisfiable) {
+		t.Errorf("expected conflict, got %v", err)
+	}
+}
+
+func TestCheckUint64(t *testing.T) {
+	err := CheckUint64(&Uint64{Values: []uint64{0}, Valid: true, Type: QueryType_LESS})
+	if !errors.Is(err, ErrUnsatisfiable) {
+		t.Errorf("expected conflict, got %v", err)
+	}
+}
+
+func TestCheckTimestamp(t *testing.T) {
+	a := &knowntimestamp.Timestamp{Seconds: 1}
+	b := &knowntimestamp.Timestamp{Seconds: 2}
+
+	err := CheckTimestamp(
+		RangeTimestamp(a, b, false, true),
+		&Timestamp{Values: []*knowntimestamp.Timestamp{b}, Valid: true, Type: QueryType_EQUAL},
+		&Timestamp{Values: []*knowntimestamp.Timestamp{nil}, Valid: true, Type: QueryType_EQUAL},
+	)
+	var conflict *Conflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if expected := []int{0, 1}; !reflect.DeepEqual(expected, conflict.Indexes) {
+		t.Errorf("wrong indexes, expected %v but got %v", expected, conflict.Indexes)
+	}
+}
+
+func TestCheckString(t *testing.T) {
+	cases := map[string]struct {
+		given    []*String
+		expected []int
+	}{
+		"max-length-prefix": {
+			given:    []*String{{Values: []string{"2"}, Valid: true, Type: QueryType_MAX_LENGTH}, HasPrefixString("abc")},
+			expected: []int{0, 1},
+		},
+		"max-length-prefix-satisfiable": {
+			given: []*String{{Values: []string{"3"}, Valid: true, Type: QueryType_MAX_LENGTH}, HasPrefixString("abc")},
+		},
+		"prefixes": {
+			given:    []*String{HasPrefixString("ab"), HasSuffixString("c"), HasPrefixString("b")},
+			expected: []int{0, 2},
+		},
+		"insensitive-prefixes": {
+			given: []*String{HasPrefixString("AB"), {Values: []string{"a"}, Valid: true, Type: QueryType_HAS_PREFIX, Insensitive: true}},
+		},
+		"insensitive-prefixes-conflict": {
+			given:    []*String{HasPrefixString("AB"), {Values: []string{"b"}, Valid: true, Type: QueryType_HAS_PREFIX, Insensitive: true}},
+			expected: []int{0, 1},
+		},
+		"equal-insensitive": {
+			given:    []*String{EqualString("John"), EqualStringInsensitive("jane")},
+			expected: []int{0, 1},
+		},
+		"equal-insensitive-satisfiable": {
+			given: []*String{EqualString("John"), InStringInsensitive("jane", "JOHN")},
+		},
+		"equal-prefix": {
+			given:    []*String{EqualString("abc"), HasPrefixString("b")},
+			expected: []int{0, 1},
+		},
+		"in-pattern": {
+			given:    []*String{inString("a", "b"), {Values: []string{"^[0-9]+$"}, Valid: true, Type: QueryType_PATTERN}},
+			expected: []int{0, 1},
+		},
+		"pattern-without-values": {
+			given: []*String{HasPrefixString("a"), {Values: []string{"^[0-9]+$"}, Valid: true, Type: QueryType_PATTERN}},
+		},
+		"not-prefix": {
+			given:    []*String{HasPrefixString("abc"), {Values: []string{"ab"}, Valid: true, Negation: true, Type: QueryType_HAS_PREFIX}},
+			expected: []int{0, 1},
+		},
+		"not-substring": {
+			given:    []*String{HasSuffixString("xyz"), {Values: []string{"Y"}, Valid: true, Negation: true, Type: QueryType_SUBSTRING, Insensitive: true}},
+			expected: []int{0, 1},
+		},
+		"not-equal-empty": {
+			given:    []*String{{Values: []string{"0"}, Valid: true, Type: QueryType_MAX_LENGTH}, {Values: []string{""}, Valid: true, Negation: true, Type: QueryType_EQUAL}},
+			expected: []int{0, 1},
+		},
+		"null": {
+			given:    []*String{NullString(), SubString("a")},
+			expected: []int{0, 1},
+		},
+		"invalid-ignored": {
+			given: []*String{{Values: []string{"a"}}, EqualString("b")},
+		},
+	}
+
+	for hint, c := range cases {
+		err := CheckString(c.given...)
+		if c.expected == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			}
+			continue
+		}
+		var conflict *Conflict
+		if !errors.As(err, &conflict) {
+			t.Errorf("%s: expected conflict, got %v", hint, err)
+			continue
+		}
+		if !reflect.DeepEqual(c.expected, conflict.Indexes) {
+			t.Errorf("%s: wrong indexes, expected %v but got %v", hint, c.expected, conflict.Indexes)
+		}
+	}
+}
+
+func TestConflict_Error(t *testing.T) {
+	if got := (&Conflict{Indexes: []int{0, 2}}).Error(); got != "qtypes: conditions at positions 0, 2 cannot be satisfied together" {
+		t.Errorf("wrong message: %s", got)
+	}
+	if got := (&Conflict{Indexes: []int{1}}).Error(); got != "qtypes: condition at position 1 cannot be satisfied" {
+		t.Errorf("wrong message: %s", got)
+	}
+}
@@ -0,0 +1,133 @@
+package qtypes
+
+import (
+	"hash/fnv"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// EquivalentInt64 reports whether a and b are satisfied by exactly the same values,
+// e.g. IN (2, 1) is equivalent to IN (1, 2) and to BETWEEN 1 AND 2.
+// Nil is equivalent to invalid object, and all conditions that cannot be satisfied are equivalent to each other.
+func EquivalentInt64(a, b *Int64) bool {
+	return proto.Equal(a.canonical(), b.canonical())
+}
+
+// EquivalentUint64 works like EquivalentInt64.
+func EquivalentUint64(a, b *Uint64) bool {
+	return proto.Equal(a.canonical(), b.canonical())
+}
+
+// EquivalentFloat64 works like EquivalentInt64.
+func EquivalentFloat64(a, b *Float64) bool {
+	return proto.Equal(a.canonical(), b.canonical())
+}
+
+// EquivalentTimestamp works like EquivalentInt64.
+func EquivalentTimestamp(a, b *Timestamp) bool {
+	return proto.Equal(a.canonical(), b.canonical())
+}
+
+// EquivalentString reports whether a and b are satisfied by exactly the same values,
+// e.g. IN ("b", "a") is equivalent to IN ("a", "b") and case insensitive EQUAL "A" to case insensitive EQUAL "a".
+// Nil is equivalent to invalid object.
+func EquivalentString(a, b *String) bool {
+	return proto.Equal(a.canonical(), b.canonical())
+}
+
+// Hash returns hash of the condition, that is the same for equivalent conditions (see EquivalentInt64).
+// It does not change between processes, so it can be used as a cache key or an ETag,
+// but it is not guaranteed to stay the same across releases of the library.
+func (i *Int64) Hash() uint64 {
+	return hash(i.canonical())
+}
+
+// Hash returns hash of the condition, that is the same for equivalent conditions (see EquivalentUint64).
+func (u *Uint64) Hash() uint64 {
+	return hash(u.canonical())
+}
+
+// Hash returns hash of the condition, that is the same for equivalent conditions (see EquivalentFloat64).
+func (f *Float64) Hash() uint64 {
+	return hash(f.canonical())
+}
+
+// Hash returns hash of the condition, that is the same for equivalent conditions (see EquivalentTimestamp).
+func (t *Timestamp) Hash() uint64 {
+	return hash(t.canonical())
+}
+
+// Hash returns hash of the condition, that is the same for equivalent conditions (see EquivalentString).
+func (s *String) Hash() uint64 {
+	return hash(s.canonical())
+}
+
+func hash(m proto.Message) uint64 {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		// Messages of this package always marshal, it can happen only for message that is not valid UTF-8.
+		b = []byte(m.ProtoReflect().Descriptor().FullName())
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	return h.Sum64()
+}
+
+func (i *Int64) canonical() *Int64 {
+	return newInt64(canonical(int64Condition(i), int64Domain))
+}
+
+func (u *Uint64) canonical() *Uint64 {
+	return newUint64(canonical(uint64Condition(u), uint64Domain))
+}
+
+func (f *Float64) canonical() *Float64 {
+	c := canonical(float64Condition(f), float64Domain)
+	for i, v := range c.values {
+		// Negative zero is equal to zero, but it is encoded differently.
+		if v == 0 {
+			c.values[i] = 0
+		}
+	}
+	return newFloat64(c)
+}
+
+func (t *Timestamp) canonical() *Timestamp {
+	if slices.Contains(t.GetValues(), nil) {
+		return newTimestamp(timestampCondition(t))
+	}
+	return newTimestamp(canonical(timestampCondition(t), timestampDomain))
+}
+
+func (s *String) canonical() *String {
+	if s == nil {
+		return &String{}
+	}
+	res := s.Normalize()
+	if !res.Insensitive || res.Type == QueryType_PATTERN {
+		return res
+	}
+	for i, v := range res.Values {
+		res.Values[i] = foldCase(v)
+	}
+	return res.Normalize()
+}
+
+// canonical returns the single representation of all conditions that are satisfied by the same values.
+func canonical[T any](c condition[T], d domain[T]) condition[T] {
+	c = normalize(c, d)
+	s, ok := d.valueSet(c)
+	if !ok {
+		return c
+	}
+	if s.empty() {
+		return condition[T]{valid: true, t: QueryType_IN}
+	}
+	s.intervals = d.join(s.intervals)
+	res, err := d.condition(s)
+	if err != nil {
+		return c
+	}
+	return normalize(res, d)
+}
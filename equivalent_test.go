@@ -0,0 +1,107 @@
+package qtypes
+
+import (
+	"math"
+	"testing"
+
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEquivalentInt64(t *testing.T) {
+	cases := map[string]struct {
+		a, b     *Int64
+		expected bool
+	}{
+		"in-order":                   {a: InInt64(2, 1), b: InInt64(1, 2), expected: true},
+		"in-duplicates":              {a: InInt64(1, 1, 2), b: InInt64(2, 1), expected: true},
+		"in-between":                 {a: InInt64(1, 2, 3), b: BetweenInt64(1, 3), expected: true},
+		"in-different":               {a: InInt64(1, 3), b: BetweenInt64(1, 3), expected: false},
+		"greater":                    {a: GreaterInt64(1), b: GreaterEqualInt64(2), expected: true},
+		"not-less-equal":             {a: &Int64{Values: []int64{1}, Valid: true, Negation: true, Type: QueryType_LESS_EQUAL}, b: GreaterInt64(1), expected: true},
+		"not-in-not-between":         {a: &Int64{Values: []int64{2, 1}, Valid: true, Negation: true, Type: QueryType_IN}, b: &Int64{Values: []int64{1, 2}, Valid: true, Negation: true, Type: QueryType_BETWEEN}, expected: true},
+		"between-min":                {a: BetweenInt64(math.MinInt64, 5), b: LessEqualInt64(5), expected: true},
+		"nil-invalid":                {a: nil, b: &Int64{Values: []int64{1}}, expected: true},
+		"unsatisfiable":              {a: GreaterInt64(math.MaxInt64), b: InInt64(), expected: true},
+		"equal-not-equal":            {a: EqualInt64(1), b: NotEqualInt64(1), expected: false},
+		"contains-order":             {a: &Int64{Values: []int64{2, 1}, Valid: true, Type: QueryType_CONTAINS}, b: &Int64{Values: []int64{1, 2}, Valid: true, Type: QueryType_CONTAINS}, expected: true},
+		"contains-is-contained-by":   {a: &Int64{Values: []int64{1}, Valid: true, Type: QueryType_CONTAINS}, b: &Int64{Values: []int64{1}, Valid: true, Type: QueryType_IS_CONTAINED_BY}, expected: false},
+		"null-not-null":              {a: NullInt64(), b: &Int64{Valid: true, Negation: true, Type: QueryType_NULL}, expected: false},
+		"not-null-greater-equal-min": {a: GreaterEqualInt64(math.MinInt64), b: &Int64{Valid: true, Negation: true, Type: QueryType_NULL}, expected: true},
+	}
+
+	for hint, c := range cases {
+		if got := EquivalentInt64(c.a, c.b); got != c.expected {
+			t.Errorf("%s: expected %t but got %t", hint, c.expected, got)
+		}
+		if got := c.a.Hash() == c.b.Hash(); c.expected && !got {
+			t.Errorf("%s: equivalent conditions should have the same hash", hint)
+		}
+	}
+}
+
+func TestEquivalentFloat64(t *testing.T) {
+	if !EquivalentFloat64(&Float64{Values: []float64{2, 1}, Valid: true, Type: QueryType_IN}, &Float64{Values: []float64{1, 2}, Valid: true, Type: QueryType_IN}) {
+		t.Error("conditions should be equivalent")
+	}
+	if EquivalentFloat64(&Float64{Values: []float64{1, 2}, Valid: true, Type: QueryType_IN}, &Float64{Values: []float64{1, 2}, Valid: true, Type: QueryType_BETWEEN}) {
+		t.Error("conditions should not be equivalent")
+	}
+	a, b := EqualFloat64(0), EqualFloat64(math.Copysign(0, -1))
+	if !EquivalentFloat64(a, b) || a.Hash() != b.Hash() {
+		t.Error("zero and negative zero should be equivalent")
+	}
+}
+
+func TestEquivalentUint64(t *testing.T) {
+	if !EquivalentUint64(BetweenUint64(0, 5), &Uint64{Values: []uint64{6}, Valid: true, Type: QueryType_LESS}) {
+		t.Error("conditions should be equivalent")
+	}
+}
+
+func TestEquivalentTimestamp(t *testing.T) {
+	a := &knowntimestamp.Timestamp{Seconds: 1}
+	b := &knowntimestamp.Timestamp{Seconds: 2}
+
+	x := &Timestamp{Values: []*knowntimestamp.Timestamp{b, a}, Valid: true, Type: QueryType_BETWEEN}
+	y := BetweenTimestamp(a, b)
+	if !EquivalentTimestamp(x, y) || x.Hash() != y.Hash() {
+		t.Error("conditions should be equivalent")
+	}
+	if EquivalentTimestamp(y, RangeTimestamp(a, b, false, true)) {
+		t.Error("conditions should not be equivalent")
+	}
+}
+
+func TestEquivalentString(t *testing.T) {
+	cases := map[string]struct {
+		a, b     *String
+		expected bool
+	}{
+		"in-order":            {a: inString("b", "a"), b: inString("a", "b"), expected: true},
+		"in-single":           {a: inString("a"), b: EqualString("a"), expected: true},
+		"insensitive":         {a: &String{Values: []string{"A"}, Valid: true, Type: QueryType_EQUAL, Insensitive: true}, b: &String{Values: []string{"a"}, Valid: true, Type: QueryType_EQUAL, Insensitive: true}, expected: true},
+		"insensitive-in":      {a: &String{Values: []string{"A", "a", "B"}, Valid: true, Type: QueryType_IN, Insensitive: true}, b: &String{Values: []string{"b", "a"}, Valid: true, Type: QueryType_IN, Insensitive: true}, expected: true},
+		"sensitive":           {a: EqualString("A"), b: EqualString("a"), expected: false},
+		"null-insensitive":    {a: &String{Valid: true, Type: QueryType_NULL, Insensitive: true}, b: NullString(), expected: true},
+		"pattern-insensitive": {a: &String{Values: []string{"A"}, Valid: true, Type: QueryType_PATTERN, Insensitive: true}, b: &String{Values: []string{"a"}, Valid: true, Type: QueryType_PATTERN, Insensitive: true}, expected: false},
+		"nil-invalid":         {a: nil, b: &String{Values: []string{"a"}}, expected: true},
+	}
+
+	for hint, c := range cases {
+		if got := EquivalentString(c.a, c.b); got != c.expected {
+			t.Errorf("%s: expected %t but got %t", hint, c.expected, got)
+		}
+		if got := c.a.Hash() == c.b.Hash(); got != c.expected {
+			t.Errorf("%s: expected hashes to be equal: %t", hint, c.expected)
+		}
+	}
+}
+
+func TestString_Hash(t *testing.T) {
+	if got := EqualString("a").Hash(); got != EqualString("a").Hash() {
+		t.Errorf("hash is not deterministic: %d", got)
+	}
+	if EqualString("a").Hash() == EqualString("b").Hash() {
+		t.Error("different conditions should have different hashes")
+	}
+}
@@ -0,0 +1,217 @@
+package qtypes
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ExplainData is passed to explanation templates.
+type ExplainData struct {
+	// Field is the name of the field as given to the explainer.
+	Field string
+	// Value is the first of the values, for convenience.
+	Value string
+	// Values are formatted values of the condition, strings are quoted.
+	Values         []string
+	Negation       bool
+	Insensitive    bool
+	LowerExclusive bool
+	UpperExclusive bool
+}
+
+var defaultExplainTemplates = map[QueryType]string{
+	QueryType_NULL:             `{{.Field}} is {{if .Negation}}not {{end}}null`,
+	QueryType_EQUAL:            `{{.Field}} is {{if .Negation}}not {{end}}equal to {{.Value}}{{if .Insensitive}} (case-insensitive){{end}}`,
+	QueryType_GREATER:          `{{.Field}} is {{if .Negation}}not {{end}}greater than {{.Value}}`,
+	QueryType_GREATER_EQUAL:    `{{.Field}} is {{if .Negation}}not {{end}}greater than or equal to {{.Value}}`,
+	QueryType_LESS:             `{{.Field}} is {{if .Negation}}not {{end}}less than {{.Value}}`,
+	QueryType_LESS_EQUAL:       `{{.Field}} is {{if .Negation}}not {{end}}less than or equal to {{.Value}}`,
+	QueryType_IN:               `{{.Field}} is {{if .Negation}}not {{end}}one of {{join .Values ", "}}{{if .Insensitive}} (case-insensitive){{end}}`,
+	QueryType_BETWEEN:          `{{.Field}} is {{if .Negation}}not {{end}}between {{index .Values 0}}{{if .LowerExclusive}} (exclusive){{end}} and {{index .Values 1}}{{if .UpperExclusive}} (exclusive){{end}}`,
+	QueryType_HAS_PREFIX:       `{{.Field}} {{if .Negation}}does not start{{else}}starts{{end}} with {{.Value}}{{if .Insensitive}} (case-insensitive){{end}}`,
+	QueryType_HAS_SUFFIX:       `{{.Field}} {{if .Negation}}does not end{{else}}ends{{end}} with {{.Value}}{{if .Insensitive}} (case-insensitive){{end}}`,
+	QueryType_SUBSTRING:        `{{.Field}} {{if .Negation}}does not contain{{else}}contains{{end}} {{.Value}}{{if .Insensitive}} (case-insensitive){{end}}`,
+	QueryType_PATTERN:          `{{.Field}} {{if .Negation}}does not match{{else}}matches{{end}} pattern {{.Value}}{{if .Insensitive}} (case-insensitive){{end}}`,
+	QueryType_MIN_LENGTH:       `{{.Field}} is {{if .Negation}}shorter than{{else}}at least{{end}} {{.Value}} characters long`,
+	QueryType_MAX_LENGTH:       `{{.Field}} is {{if .Negation}}longer than{{else}}at most{{end}} {{.Value}} characters long`,
+	QueryType_OVERLAP:          `{{.Field}} {{if .Negation}}has no{{else}}has some{{end}} elements in common with {{join .Values ", "}}`,
+	QueryType_CONTAINS:         `{{.Field}} {{if .Negation}}does not contain all of{{else}}contains all of{{end}} {{join .Values ", "}}`,
+	QueryType_IS_CONTAINED_BY:  `{{.Field}} {{if .Negation}}has elements other than{{else}}has only elements of{{end}} {{join .Values ", "}}`,
+	QueryType_HAS_ELEMENT:      `{{.Field}} {{if .Negation}}does not have{{else}}has{{end}} element {{.Value}}`,
+	QueryType_HAS_ANY_ELEMENT:  `{{.Field}} {{if .Negation}}has none of{{else}}has any of{{end}} {{join .Values ", "}}`,
+	QueryType_HAS_ALL_ELEMENTS: `{{.Field}} {{if .Negation}}does not have all of{{else}}has all of{{end}} {{join .Values ", "}}`,
+	QueryType_WILDCARD:         `{{.Field}} {{if .Negation}}does not match{{else}}matches{{end}} wildcard {{.Value}}{{if .Insensitive}} (case-insensitive){{end}}`,
+}
+
+var explainFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+var defaultExplainer = func() *Explainer {
+	e, err := NewExplainer(nil)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}()
+
+// DefaultExplainTemplates returns copy of English templates used by default.
+// They can serve as a starting point for a translation.
+func DefaultExplainTemplates() map[QueryType]string {
+	return maps.Clone(defaultExplainTemplates)
+}
+
+// Explainer turns conditions into human readable sentences like "age is between 18 and 65".
+// Each query type is rendered using text/template executed with ExplainData.
+type Explainer struct {
+	templates map[QueryType]*template.Template
+}
+
+// NewExplainer allocates new Explainer that uses given templates.
+// Query types without a template fall back to the default, English ones.
+// Templates can use join function, e.g. {{join .Values ", "}}.
+func NewExplainer(templates map[QueryType]string) (*Explainer, error) {
+	texts := DefaultExplainTemplates()
+	maps.Copy(texts, templates)
+
+	e := &Explainer{templates: make(map[QueryType]*template.Template, len(texts))}
+	for t, text := range texts {
+		tmpl, err := template.New(QueryType_name[int32(t)]).Funcs(explainFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("qtypes: explain template for %s: %w", t, err)
+		}
+		e.templates[t] = tmpl
+	}
+	return e, nil
+}
+
+// ExplainInt64 explains condition using default templates.
+func ExplainInt64(field string, i *Int64) (string, error) {
+	return defaultExplainer.ExplainInt64(field, i)
+}
+
+// ExplainUint64 explains condition using default templates.
+func ExplainUint64(field string, u *Uint64) (string, error) {
+	return defaultExplainer.ExplainUint64(field, u)
+}
+
+// ExplainFloat64 explains condition using default templates.
+func ExplainFloat64(field string, f *Float64) (string, error) {
+	return defaultExplainer.ExplainFloat64(field, f)
+}
+
+// ExplainTimestamp explains condition using default templates.
+func ExplainTimestamp(field string, t *Timestamp) (string, error) {
+	return defaultExplainer.ExplainTimestamp(field, t)
+}
+
+// ExplainString explains condition using default templates.
+func ExplainString(field string, s *String) (string, error) {
+	return defaultExplainer.ExplainString(field, s)
+}
+
+// ExplainInt64 returns sentence that describes the condition on given field.
+// Invalid condition does not constrain the field, so it is explained as an empty string.
+func (e *Explainer) ExplainInt64(field string, i *Int64) (string, error) {
+	values := make([]string, 0, len(i.GetValues()))
+	for _, v := range i.GetValues() {
+		values = append(values, strconv.FormatInt(v, 10))
+	}
+	return e.explain(i.GetValid(), i.GetType(), ExplainData{
+		Field:          field,
+		Values:         values,
+		Negation:       i.GetNegation(),
+		LowerExclusive: i.GetLowerExclusive(),
+		UpperExclusive: i.GetUpperExclusive(),
+	})
+}
+
+// ExplainUint64 works like ExplainInt64.
+func (e *Explainer) ExplainUint64(field string, u *Uint64) (string, error) {
+	values := make([]string, 0, len(u.GetValues()))
+	for _, v := range u.GetValues() {
+		values = append(values, strconv.FormatUint(v, 10))
+	}
+	return e.explain(u.GetValid(), u.GetType(), ExplainData{
+		Field:          field,
+		Values:         values,
+		Negation:       u.GetNegation(),
+		LowerExclusive: u.GetLowerExclusive(),
+		UpperExclusive: u.GetUpperExclusive(),
+	})
+}
+
+// ExplainFloat64 works like ExplainInt64.
+func (e *Explainer) ExplainFloat64(field string, f *Float64) (string, error) {
+	values := make([]string, 0, len(f.GetValues()))
+	for _, v := range f.GetValues() {
+		values = append(values, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return e.explain(f.GetValid(), f.GetType(), ExplainData{
+		Field:          field,
+		Values:         values,
+		Negation:       f.GetNegation(),
+		LowerExclusive: f.GetLowerExclusive(),
+		UpperExclusive: f.GetUpperExclusive(),
+	})
+}
+
+// ExplainTimestamp works like ExplainInt64. Values are formatted using RFC 3339 in UTC.
+func (e *Explainer) ExplainTimestamp(field string, t *Timestamp) (string, error) {
+	values := make([]string, 0, len(t.GetValues()))
+	for _, v := range t.GetValues() {
+		if v == nil {
+			values = append(values, "null")
+			continue
+		}
+		values = append(values, v.AsTime().UTC().Format(time.RFC3339Nano))
+	}
+	return e.explain(t.GetValid(), t.GetType(), ExplainData{
+		Field:          field,
+		Values:         values,
+		Negation:       t.GetNegation(),
+		LowerExclusive: t.GetLowerExclusive(),
+		UpperExclusive: t.GetUpperExclusive(),
+	})
+}
+
+// ExplainString works like ExplainInt64. Values are put in single quotes, except for MIN_LENGTH and MAX_LENGTH.
+func (e *Explainer) ExplainString(field string, s *String) (string, error) {
+	values := make([]string, 0, len(s.GetValues()))
+	for _, v := range s.GetValues() {
+		if s.GetType() == QueryType_MIN_LENGTH || s.GetType() == QueryType_MAX_LENGTH {
+			values = append(values, v)
+			continue
+		}
+		values = append(values, "'"+strings.ReplaceAll(v, "'", `\'`)+"'")
+	}
+	return e.explain(s.GetValid(), s.GetType(), ExplainData{
+		Field:       field,
+		Values:      values,
+		Negation:    s.GetNegation(),
+		Insensitive: s.GetInsensitive(),
+	})
+}
+
+func (e *Explainer) explain(valid bool, t QueryType, data ExplainData) (string, error) {
+	if !valid {
+		return "", nil
+	}
+	tmpl, ok := e.templates[t]
+	if !ok {
+		return "", fmt.Errorf("qtypes: missing explain template for %s", t)
+	}
+	if len(data.Values) > 0 {
+		data.Value = data.Values[0]
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("qtypes: explain %s: %w", t, err)
+	}
+	return b.String(), nil
+}
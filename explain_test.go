@@ -0,0 +1,155 @@
+package qtypes
+
+import (
+	"testing"
+
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestExplainInt64(t *testing.T) {
+	cases := map[string]struct {
+		given    *Int64
+		expected string
+	}{
+		"invalid":      {given: nil, expected: ""},
+		"between":      {given: BetweenInt64(18, 65), expected: "age is between 18 and 65"},
+		"half-open":    {given: RangeInt64(18, 65, false, true), expected: "age is between 18 and 65 (exclusive)"},
+		"not-equal":    {given: NotEqualInt64(18), expected: "age is not equal to 18"},
+		"in":           {given: InInt64(1, 2, 3), expected: "age is one of 1, 2, 3"},
+		"null":         {given: NullInt64(), expected: "age is null"},
+		"not-null":     {given: &Int64{Valid: true, Negation: true, Type: QueryType_NULL}, expected: "age is not null"},
+		"greater":      {given: GreaterEqualInt64(18), expected: "age is greater than or equal to 18"},
+		"has-element":  {given: &Int64{Values: []int64{1}, Valid: true, Type: QueryType_HAS_ELEMENT}, expected: "age has element 1"},
+		"not-contains": {given: &Int64{Values: []int64{1, 2}, Valid: true, Negation: true, Type: QueryType_CONTAINS}, expected: "age does not contain all of 1, 2"},
+	}
+
+	for hint, c := range cases {
+		got, err := ExplainInt64("age", c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestExplainInt64_missingValue(t *testing.T) {
+	if _, err := ExplainInt64("age", &Int64{Values: []int64{1}, Valid: true, Type: QueryType_BETWEEN}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestExplainString(t *testing.T) {
+	cases := map[string]struct {
+		given    *String
+		expected string
+	}{
+		"not-has-prefix": {
+			given:    &String{Values: []string{"ab"}, Valid: true, Negation: true, Type: QueryType_HAS_PREFIX, Insensitive: true},
+			expected: "name does not start with 'ab' (case-insensitive)",
+		},
+		"equal": {
+			given:    EqualString("it's"),
+			expected: `name is equal to 'it\'s'`,
+		},
+		"max-length": {
+			given:    &String{Values: []string{"5"}, Valid: true, Type: QueryType_MAX_LENGTH},
+			expected: "name is at most 5 characters long",
+		},
+		"not-min-length": {
+			given:    &String{Values: []string{"5"}, Valid: true, Negation: true, Type: QueryType_MIN_LENGTH},
+			expected: "name is shorter than 5 characters long",
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := ExplainString("name", c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestExplainFloat64(t *testing.T) {
+	got, err := ExplainFloat64("price", &Float64{Values: []float64{9.99}, Valid: true, Type: QueryType_LESS})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "price is less than 9.99"; got != expected {
+		t.Errorf("wrong output, expected %q but got %q", expected, got)
+	}
+}
+
+func TestExplainUint64(t *testing.T) {
+	got, err := ExplainUint64("count", &Uint64{Values: []uint64{1}, Valid: true, Negation: true, Type: QueryType_GREATER})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "count is not greater than 1"; got != expected {
+		t.Errorf("wrong output, expected %q but got %q", expected, got)
+	}
+}
+
+func TestExplainTimestamp(t *testing.T) {
+	got, err := ExplainTimestamp("created_at", RangeTimestamp(
+		&knowntimestamp.Timestamp{Seconds: 0},
+		&knowntimestamp.Timestamp{Seconds: 86400},
+		false, true,
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "created_at is between 1970-01-01T00:00:00Z and 1970-01-02T00:00:00Z (exclusive)"; got != expected {
+		t.Errorf("wrong output, expected %q but got %q", expected, got)
+	}
+}
+
+func TestNewExplainer(t *testing.T) {
+	e, err := NewExplainer(map[QueryType]string{
+		QueryType_BETWEEN: `{{.Field}} {{if .Negation}}nie {{end}}jest pomiędzy {{index .Values 0}} a {{index .Values 1}}`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	got, err := e.ExplainInt64("wiek", BetweenInt64(18, 65))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "wiek jest pomiędzy 18 a 65"; got != expected {
+		t.Errorf("wrong output, expected %q but got %q", expected, got)
+	}
+
+	got, err = e.ExplainInt64("wiek", EqualInt64(18))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "wiek is equal to 18"; got != expected {
+		t.Errorf("missing template should fall back to the default one, expected %q but got %q", expected, got)
+	}
+}
+
+func TestNewExplainer_invalidTemplate(t *testing.T) {
+	if _, err := NewExplainer(map[QueryType]string{QueryType_EQUAL: "{{.Field"}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDefaultExplainTemplates(t *testing.T) {
+	templates := DefaultExplainTemplates()
+	for qt := range QueryType_name {
+		if _, ok := templates[QueryType(qt)]; !ok {
+			t.Errorf("missing template for %s", QueryType(qt))
+		}
+	}
+	templates[QueryType_EQUAL] = "changed"
+	if DefaultExplainTemplates()[QueryType_EQUAL] == "changed" {
+		t.Error("default templates should not be modified")
+	}
+}
@@ -0,0 +1,171 @@
+package qtypes
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization is a Unicode normalization form applied before strings are compared.
+type Normalization int
+
+const (
+	// NormalizationNone leaves strings as they are.
+	NormalizationNone Normalization = iota
+	// NormalizationNFC composes characters, so that "e" followed by a combining acute accent equals "é".
+	NormalizationNFC
+	// NormalizationNFKC additionally replaces compatibility characters, e.g. "ﬁ" ligature becomes "fi" and "²" becomes "2".
+	NormalizationNFKC
+)
+
+// Folding defines what case insensitive conditions mean.
+// Strings are normalized first, then Unicode full case folding is applied, e.g. "Straße" and "STRASSE" are equal.
+// By default, language independent folding is used, so that "İ" does not fold to "i" and "ı" does not fold to "i".
+type Folding struct {
+	// Normalization applied before and after case folding.
+	Normalization Normalization
+	// Language, if set, applies language specific rules before case folding,
+	// e.g. in Turkish "I" folds to dotless "ı" and "İ" folds to "i".
+	Language language.Tag
+	// IgnoreAccents removes diacritical marks, so that "é" matches "e".
+	// Letters that do not decompose, e.g. "ł" or "ø", are kept.
+	IgnoreAccents bool
+}
+
+var defaultFolding = Folding{Normalization: NormalizationNFC}
+
+// DefaultFolding returns folding that is used by this package unless specified otherwise:
+// NFC normalization followed by language independent case folding, accents are significant.
+func DefaultFolding() Folding {
+	return defaultFolding
+}
+
+// Fold returns case folded form of given string.
+// Two strings are equal, case insensitively, if their folded forms are equal.
+func (f Folding) Fold(s string) string {
+	s = f.normalize(s)
+	if f.Language != language.Und {
+		s = cases.Lower(f.Language).String(s)
+	}
+	s = cases.Fold().String(s)
+	if f.IgnoreAccents {
+		s, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	}
+	return f.normalize(s)
+}
+
+func (f Folding) normalize(s string) string {
+	switch f.Normalization {
+	case NormalizationNFC:
+		return norm.NFC.String(s)
+	case NormalizationNFKC:
+		return norm.NFKC.String(s)
+	default:
+		return s
+	}
+}
+
+// PostgreSQL returns SQL expression that folds given one the same way as Fold does,
+// e.g. "casefold(normalize(name, NFC))", so that it can be compared to values folded in Go.
+// The casefold function requires PostgreSQL 18, and it performs full case folding only with ICU or pg_unicode_fast collations.
+// Language specific rules are applied through ICU collation, e.g. COLLATE "tr-x-icu".
+// Accents are removed by unaccent function of the unaccent extension, which does it approximately.
+func (f Folding) PostgreSQL(expr string) string {
+	switch f.Normalization {
+	case NormalizationNFC:
+		expr = "normalize(" + expr + ", NFC)"
+	case NormalizationNFKC:
+		expr = "normalize(" + expr + ", NFKC)"
+	}
+	if f.Language != language.Und {
+		base, _ := f.Language.Base()
+		expr = fmt.Sprintf("%s COLLATE %q", expr, base.String()+"-x-icu")
+	}
+	expr = "casefold(" + expr + ")"
+	if f.IgnoreAccents {
+		expr = "unaccent(" + expr + ")"
+	}
+	return expr
+}
+
+// MatchString reports whether not null value satisfies given condition, using DefaultFolding.
+// Error is returned if the condition cannot be evaluated, e.g. array conditions.
+func MatchString(s *String, v string) (bool, error) {
+	return defaultFolding.Match(s, v)
+}
+
+// Match reports whether not null value satisfies given condition.
+// Case insensitive conditions are folded, except PATTERN that relies on case insensitive flag of regular expressions.
+// Lengths are counted in characters of the value as it is.
+// Error is returned if the condition cannot be evaluated, e.g. array conditions.
+func (f Folding) Match(s *String, v string) (bool, error) {
+	if !s.GetValid() {
+		return true, nil
+	}
+
+	x := v
+	values := s.Values
+	if s.Insensitive && s.Type != QueryType_PATTERN {
+		x = f.Fold(v)
+		values = make([]string, 0, len(s.Values))
+		for _, w := range s.Values {
+			values = append(values, f.Fold(w))
+		}
+	}
+
+	var ok bool
+	switch s.Type {
+	case QueryType_NULL:
+		ok = false
+	case QueryType_EQUAL, QueryType_IN:
+		ok = slices.Contains(values, x)
+	case QueryType_GREATER, QueryType_GREATER_EQUAL, QueryType_LESS, QueryType_LESS_EQUAL, QueryType_BETWEEN:
+		return f.MatchRange(s, v, ByteOrder)
+	case QueryType_HAS_PREFIX, QueryType_HAS_SUFFIX, QueryType_SUBSTRING, QueryType_PATTERN, QueryType_WILDCARD,
+		QueryType_MIN_LENGTH, QueryType_MAX_LENGTH:
+		if len(values) != 1 {
+			return false, fmt.Errorf("qtypes: %s condition requires exactly one value", s.Type)
+		}
+		switch s.Type {
+		case QueryType_HAS_PREFIX:
+			ok = strings.HasPrefix(x, values[0])
+		case QueryType_HAS_SUFFIX:
+			ok = strings.HasSuffix(x, values[0])
+		case QueryType_SUBSTRING:
+			ok = strings.Contains(x, values[0])
+		case QueryType_PATTERN:
+			re, err := CompilePattern(values[0], s.Insensitive)
+			if err != nil {
+				return false, err
+			}
+			ok = re.MatchString(x)
+		case QueryType_WILDCARD:
+			ok = MatchWildcard(values[0], x, false)
+		default:
+			n, err := strconv.ParseUint(values[0], 10, 63)
+			if err != nil {
+				return false, err
+			}
+			if s.Type == QueryType_MIN_LENGTH {
+				ok = uint64(utf8.RuneCountInString(v)) >= n
+			} else {
+				ok = uint64(utf8.RuneCountInString(v)) <= n
+			}
+		}
+	default:
+		return false, fmt.Errorf("qtypes: %s condition cannot be evaluated", s.Type)
+	}
+	if s.Negation {
+		return !ok, nil
+	}
+	return ok, nil
+}
@@ -0,0 +1,106 @@
+package qtypes
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestFolding_Fold(t *testing.T) {
+	turkish := Folding{Normalization: NormalizationNFC, Language: language.Turkish}
+	accents := Folding{Normalization: NormalizationNFC, IgnoreAccents: true}
+
+	cases := map[string]struct {
+		folding Folding
+		a, b    string
+		equal   bool
+	}{
+		"ascii":                     {folding: defaultFolding, a: "John", b: "JOHN", equal: true},
+		"sharp-s":                   {folding: defaultFolding, a: "Straße", b: "STRASSE", equal: true},
+		"sigma":                     {folding: defaultFolding, a: "ΣΊΣΥΦΟΣ", b: "σίσυφος", equal: true},
+		"final-sigma":               {folding: defaultFolding, a: "σίσυφος", b: "σίσυφοσ", equal: true},
+		"composed-decomposed":       {folding: defaultFolding, a: "Café", b: "café", equal: true},
+		"without-normalization":     {folding: Folding{}, a: "Café", b: "café", equal: false},
+		"dotted-capital-i":          {folding: defaultFolding, a: "İstanbul", b: "istanbul", equal: false},
+		"dotless-i":                 {folding: defaultFolding, a: "ılık", b: "ILIK", equal: false},
+		"turkish-dotted-capital-i":  {folding: turkish, a: "İstanbul", b: "istanbul", equal: true},
+		"turkish-dotless-i":         {folding: turkish, a: "ılık", b: "ILIK", equal: true},
+		"turkish-i":                 {folding: turkish, a: "ILIK", b: "ilik", equal: false},
+		"accents":                   {folding: defaultFolding, a: "Zażółć", b: "zazolc", equal: false},
+		"ignore-accents":            {folding: accents, a: "Crème Brûlée", b: "CREME BRULEE", equal: true},
+		"ignore-accents-decomposed": {folding: accents, a: "café", b: "CAFE", equal: true},
+		"ignore-accents-stroke":     {folding: accents, a: "łódź", b: "lodz", equal: false},
+		"compatibility":             {folding: defaultFolding, a: "file²", b: "FILE2", equal: false},
+		"nfkc-compatibility":        {folding: Folding{Normalization: NormalizationNFKC}, a: "ﬁle²", b: "FILE2", equal: true},
+	}
+
+	for hint, c := range cases {
+		a, b := c.folding.Fold(c.a), c.folding.Fold(c.b)
+		if (a == b) != c.equal {
+			t.Errorf("%s: wrong output, expected equal %v, but got %q and %q", hint, c.equal, a, b)
+		}
+	}
+}
+
+func TestFolding_PostgreSQL(t *testing.T) {
+	cases := map[string]struct {
+		given    Folding
+		expected string
+	}{
+		"default":      {given: DefaultFolding(), expected: "casefold(normalize(name, NFC))"},
+		"none":         {given: Folding{}, expected: "casefold(name)"},
+		"nfkc-accents": {given: Folding{Normalization: NormalizationNFKC, IgnoreAccents: true}, expected: "unaccent(casefold(normalize(name, NFKC)))"},
+		"language":     {given: Folding{Language: language.MustParse("tr-TR")}, expected: `casefold(name COLLATE "tr-x-icu")`},
+	}
+
+	for hint, c := range cases {
+		if got := c.given.PostgreSQL("name"); got != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestFolding_Match(t *testing.T) {
+	insensitive := func(t QueryType, values ...string) *String {
+		return &String{Values: values, Valid: true, Type: t, Insensitive: true}
+	}
+	accents := Folding{Normalization: NormalizationNFC, IgnoreAccents: true}
+
+	cases := map[string]struct {
+		folding  Folding
+		given    *String
+		value    string
+		expected bool
+	}{
+		"equal":             {folding: defaultFolding, given: insensitive(QueryType_EQUAL, "straße"), value: "STRASSE", expected: true},
+		"equal-sensitive":   {folding: defaultFolding, given: EqualString("straße"), value: "STRASSE", expected: false},
+		"in-decomposed":     {folding: defaultFolding, given: insensitive(QueryType_IN, "josé", "maria"), value: "JOSÉ", expected: true},
+		"prefix-accents":    {folding: accents, given: insensitive(QueryType_HAS_PREFIX, "creme"), value: "Crème brûlée", expected: true},
+		"prefix-no-accents": {folding: defaultFolding, given: insensitive(QueryType_HAS_PREFIX, "creme"), value: "Crème brûlée", expected: false},
+		"substring":         {folding: defaultFolding, given: insensitive(QueryType_SUBSTRING, "SS"), value: "Straße", expected: true},
+		"wildcard":          {folding: defaultFolding, given: insensitive(QueryType_WILDCARD, "STRA*E"), value: "straße", expected: true},
+		"length-not-folded": {folding: defaultFolding, given: insensitive(QueryType_MAX_LENGTH, "6"), value: "Straße", expected: true},
+		"pattern":           {folding: defaultFolding, given: insensitive(QueryType_PATTERN, "^stra"), value: "STRASSE", expected: true},
+		"negated-equal":     {folding: defaultFolding, given: &String{Values: []string{"a"}, Valid: true, Negation: true, Type: QueryType_EQUAL, Insensitive: true}, value: "A", expected: false},
+		"range":             {folding: defaultFolding, given: insensitive(QueryType_LESS, "b"), value: "A", expected: true},
+		"invalid":           {folding: defaultFolding, given: &String{}, value: "a", expected: true},
+		"null":              {folding: defaultFolding, given: NullString(), value: "a", expected: false},
+	}
+
+	for hint, c := range cases {
+		got, err := c.folding.Match(c.given, c.value)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestMatchString_error(t *testing.T) {
+	if _, err := MatchString(&String{Values: []string{"a"}, Valid: true, Type: QueryType_CONTAINS}, "a"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -2,4 +2,7 @@ module github.com/piotrkowalczuk/qtypes
 
 go 1.23.1
 
-require google.golang.org/protobuf v1.34.2
+require (
+	golang.org/x/text v0.24.0
+	google.golang.org/protobuf v1.34.2
+)
@@ -0,0 +1,172 @@
+package qtypes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrDisjointPeriods is returned when periods of values of a condition do not form a single range,
+// e.g. days of IN 2024-05-01, 2024-05-03, as a single BETWEEN condition cannot express them.
+var ErrDisjointPeriods = errors.New("qtypes: timestamp expansion produced disjoint periods")
+
+// Granularity describes calendar precision of a timestamp value.
+type Granularity int
+
+const (
+	// GranularityNone means that value represents an exact instant.
+	GranularityNone Granularity = iota
+	// GranularityYear means that value represents whole calendar year.
+	GranularityYear
+	// GranularityMonth means that value represents whole calendar month.
+	GranularityMonth
+	// GranularityDay means that value represents whole calendar day.
+	GranularityDay
+	// GranularityHour means that value represents whole hour.
+	GranularityHour
+)
+
+// String implements fmt.Stringer interface.
+func (g Granularity) String() string {
+	switch g {
+	case GranularityNone:
+		return "none"
+	case GranularityYear:
+		return "year"
+	case GranularityMonth:
+		return "month"
+	case GranularityDay:
+		return "day"
+	case GranularityHour:
+		return "hour"
+	default:
+		return fmt.Sprintf("Granularity(%d)", int(g))
+	}
+}
+
+// Period returns half-open range [from, to) of instants that belong to the calendar period of given granularity.
+// The period is identified by the wall clock reading of t and interpreted in loc, regardless of location of t.
+// Daylight saving time transitions are taken into account, so a day can last 23 or 25 hours,
+// an hour repeated by the transition lasts two hours and an hour skipped by the transition is empty (from equals to).
+func (g Granularity) Period(t time.Time, loc *time.Location) (from, to time.Time) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	year, month, day := t.Date()
+	hour := t.Hour()
+
+	switch g {
+	case GranularityYear:
+		return wallStart(year, time.January, 1, 0, loc), wallStart(year+1, time.January, 1, 0, loc)
+	case GranularityMonth:
+		return wallStart(year, month, 1, 0, loc), wallStart(year, month+1, 1, 0, loc)
+	case GranularityDay:
+		return wallStart(year, month, day, 0, loc), wallStart(year, month, day+1, 0, loc)
+	case GranularityHour:
+		return wallStart(year, month, day, hour, loc), wallStart(year, month, day, hour+1, loc)
+	default:
+		from = time.Date(year, month, day, hour, t.Minute(), t.Second(), t.Nanosecond(), loc)
+		return from, from.Add(time.Nanosecond)
+	}
+}
+
+// wallStart returns the earliest instant at which wall clock in loc reaches given date and hour.
+// Unlike time.Date it is well defined for wall clock readings that are skipped or repeated by a zone transition.
+func wallStart(year int, month time.Month, day, hour int, loc *time.Location) time.Time {
+	wall := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	guess := time.Date(year, month, day, hour, 0, 0, 0, loc)
+
+	offsets := []int{zoneOffset(guess)}
+	start, end := guess.ZoneBounds()
+	if !start.IsZero() {
+		offsets = append(offsets, zoneOffset(start.Add(-time.Nanosecond)))
+	}
+	if !end.IsZero() {
+		offsets = append(offsets, zoneOffset(end))
+	}
+
+	var first time.Time
+	for _, offset := range offsets {
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if wallClock(candidate).Before(wall) {
+			continue
+		}
+		if first.IsZero() || candidate.Before(first) {
+			first = candidate
+		}
+	}
+	if first.IsZero() {
+		return guess
+	}
+	return first
+}
+
+func zoneOffset(t time.Time) int {
+	_, offset := t.Zone()
+	return offset
+}
+
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// ExpandTimestamp turns EQUAL or IN condition into BETWEEN condition that covers
+// whole calendar periods of given granularity, in given location, that the values fall into.
+// For example a day granularity makes EQUAL 2024-05-01T13:00:00Z match any instant during May 1st.
+// The range is half-open [start, end), so the upper bound is exclusive.
+// Negation is preserved. Periods of all values have to form a single contiguous range, otherwise ErrDisjointPeriods is returned.
+// Conditions of other types, invalid conditions and GranularityNone are returned untouched.
+func ExpandTimestamp(t *Timestamp, g Granularity, loc *time.Location) (*Timestamp, error) {
+	if t == nil || !t.Valid || g == GranularityNone {
+		return t, nil
+	}
+	if t.Type != QueryType_EQUAL && t.Type != QueryType_IN {
+		return t, nil
+	}
+	if len(t.Values) == 0 {
+		return nil, fmt.Errorf("qtypes: timestamp expansion requires at least one value")
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	type period struct {
+		from, to time.Time
+	}
+	periods := make([]period, 0, len(t.Values))
+	for i, v := range t.Values {
+		if v == nil {
+			return nil, fmt.Errorf("qtypes: timestamp expansion got nil value at index %d", i)
+		}
+		from, to := g.Period(v.AsTime().In(loc), loc)
+		periods = append(periods, period{from: from, to: to})
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].from.Before(periods[j].from)
+	})
+
+	from, to := periods[0].from, periods[0].to
+	for _, p := range periods[1:] {
+		if p.from.After(to) {
+			return nil, fmt.Errorf("%w of %s granularity", ErrDisjointPeriods, g)
+		}
+		if p.to.After(to) {
+			to = p.to
+		}
+	}
+
+	return &Timestamp{
+		Values: []*knowntimestamp.Timestamp{
+			knowntimestamp.New(from),
+			knowntimestamp.New(to),
+		},
+		Valid:          true,
+		Negation:       t.Negation,
+		Type:           QueryType_BETWEEN,
+		UpperExclusive: true,
+	}, nil
+}
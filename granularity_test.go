@@ -0,0 +1,233 @@
+package qtypes
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGranularity_Period(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %s", err.Error())
+	}
+
+	cases := map[string]struct {
+		given       time.Time
+		granularity Granularity
+		loc         *time.Location
+		from, to    string
+	}{
+		"year": {
+			given:       time.Date(2024, time.May, 1, 13, 0, 0, 0, time.UTC),
+			granularity: GranularityYear,
+			loc:         time.UTC,
+			from:        "2024-01-01T00:00:00Z",
+			to:          "2025-01-01T00:00:00Z",
+		},
+		"month": {
+			given:       time.Date(2024, time.December, 31, 13, 0, 0, 0, time.UTC),
+			granularity: GranularityMonth,
+			loc:         time.UTC,
+			from:        "2024-12-01T00:00:00Z",
+			to:          "2025-01-01T00:00:00Z",
+		},
+		"day": {
+			given:       time.Date(2024, time.May, 1, 13, 0, 0, 0, time.UTC),
+			granularity: GranularityDay,
+			loc:         newYork,
+			from:        "2024-05-01T04:00:00Z",
+			to:          "2024-05-02T04:00:00Z",
+		},
+		"day-spring-forward": {
+			given:       time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+			granularity: GranularityDay,
+			loc:         newYork,
+			from:        "2024-03-10T05:00:00Z",
+			to:          "2024-03-11T04:00:00Z",
+		},
+		"day-fall-back": {
+			given:       time.Date(2024, time.November, 3, 0, 0, 0, 0, time.UTC),
+			granularity: GranularityDay,
+			loc:         newYork,
+			from:        "2024-11-03T04:00:00Z",
+			to:          "2024-11-04T05:00:00Z",
+		},
+		"hour": {
+			given:       time.Date(2024, time.May, 1, 13, 45, 0, 0, time.UTC),
+			granularity: GranularityHour,
+			loc:         time.UTC,
+			from:        "2024-05-01T13:00:00Z",
+			to:          "2024-05-01T14:00:00Z",
+		},
+		"hour-skipped": {
+			given:       time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC),
+			granularity: GranularityHour,
+			loc:         newYork,
+			from:        "2024-03-10T07:00:00Z",
+			to:          "2024-03-10T07:00:00Z",
+		},
+		"hour-repeated": {
+			given:       time.Date(2024, time.November, 3, 1, 0, 0, 0, time.UTC),
+			granularity: GranularityHour,
+			loc:         newYork,
+			from:        "2024-11-03T05:00:00Z",
+			to:          "2024-11-03T07:00:00Z",
+		},
+		"none": {
+			given:       time.Date(2024, time.May, 1, 13, 45, 0, 0, time.UTC),
+			granularity: GranularityNone,
+			loc:         time.UTC,
+			from:        "2024-05-01T13:45:00Z",
+			to:          "2024-05-01T13:45:00.000000001Z",
+		},
+	}
+
+	for hint, c := range cases {
+		from, to := c.granularity.Period(c.given, c.loc)
+		if got := from.UTC().Format(time.RFC3339Nano); got != c.from {
+			t.Errorf("%s: wrong start, expected %s but got %s", hint, c.from, got)
+		}
+		if got := to.UTC().Format(time.RFC3339Nano); got != c.to {
+			t.Errorf("%s: wrong end, expected %s but got %s", hint, c.to, got)
+		}
+	}
+}
+
+func TestExpandTimestamp(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database not available: %s", err.Error())
+	}
+	ts := func(s string) *knowntimestamp.Timestamp {
+		pt, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			t.Fatalf("string cant be parsed into time: %s", err.Error())
+		}
+		return knowntimestamp.New(pt)
+	}
+
+	cases := map[string]struct {
+		given       *Timestamp
+		granularity Granularity
+		loc         *time.Location
+		expected    *Timestamp
+	}{
+		"equal": {
+			given: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{ts("2024-05-01T13:00:00Z")},
+				Valid:  true,
+				Type:   QueryType_EQUAL,
+			},
+			granularity: GranularityDay,
+			loc:         berlin,
+			expected: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					ts("2024-04-30T22:00:00Z"),
+					ts("2024-05-01T22:00:00Z"),
+				},
+				Valid:          true,
+				Type:           QueryType_BETWEEN,
+				UpperExclusive: true,
+			},
+		},
+		"not-equal": {
+			given: &Timestamp{
+				Values:   []*knowntimestamp.Timestamp{ts("2024-05-01T13:00:00Z")},
+				Valid:    true,
+				Negation: true,
+				Type:     QueryType_EQUAL,
+			},
+			granularity: GranularityMonth,
+			loc:         time.UTC,
+			expected: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					ts("2024-05-01T00:00:00Z"),
+					ts("2024-06-01T00:00:00Z"),
+				},
+				Valid:          true,
+				Negation:       true,
+				Type:           QueryType_BETWEEN,
+				UpperExclusive: true,
+			},
+		},
+		"in-contiguous": {
+			given: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					ts("2024-05-02T00:00:00Z"),
+					ts("2024-05-01T00:00:00Z"),
+					ts("2024-05-01T12:00:00Z"),
+				},
+				Valid: true,
+				Type:  QueryType_IN,
+			},
+			granularity: GranularityDay,
+			loc:         time.UTC,
+			expected: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					ts("2024-05-01T00:00:00Z"),
+					ts("2024-05-03T00:00:00Z"),
+				},
+				Valid:          true,
+				Type:           QueryType_BETWEEN,
+				UpperExclusive: true,
+			},
+		},
+		"greater": {
+			given: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{ts("2024-05-01T13:00:00Z")},
+				Valid:  true,
+				Type:   QueryType_GREATER,
+			},
+			granularity: GranularityDay,
+			loc:         time.UTC,
+			expected: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{ts("2024-05-01T13:00:00Z")},
+				Valid:  true,
+				Type:   QueryType_GREATER,
+			},
+		},
+		"none": {
+			given: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{ts("2024-05-01T13:00:00Z")},
+				Valid:  true,
+				Type:   QueryType_EQUAL,
+			},
+			granularity: GranularityNone,
+			loc:         time.UTC,
+			expected: &Timestamp{
+				Values: []*knowntimestamp.Timestamp{ts("2024-05-01T13:00:00Z")},
+				Valid:  true,
+				Type:   QueryType_EQUAL,
+			},
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := ExpandTimestamp(c.given, c.granularity, c.loc)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestExpandTimestamp_disjoint(t *testing.T) {
+	_, err := ExpandTimestamp(&Timestamp{
+		Values: []*knowntimestamp.Timestamp{
+			knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)),
+			knowntimestamp.New(time.Date(2024, time.May, 3, 0, 0, 0, 0, time.UTC)),
+		},
+		Valid: true,
+		Type:  QueryType_IN,
+	}, GranularityDay, time.UTC)
+	if !errors.Is(err, ErrDisjointPeriods) {
+		t.Fatalf("expected disjoint periods error, got %v", err)
+	}
+}
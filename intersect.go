@@ -0,0 +1,293 @@
+package qtypes
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrUnsatisfiable is returned when no value can satisfy given conditions.
+	ErrUnsatisfiable = errors.New("qtypes: conditions cannot be satisfied")
+	// ErrNotRepresentable is returned when result cannot be expressed as a single condition.
+	ErrNotRepresentable = errors.New("qtypes: result cannot be expressed as a single condition")
+)
+
+// IntersectInt64 returns the narrowest condition that is satisfied by exactly those values that satisfy both a and b.
+// Invalid condition matches every value, so the other one is returned.
+// ErrUnsatisfiable is returned if no value can satisfy both conditions,
+// ErrNotRepresentable if the intersection cannot be expressed as a single condition.
+func IntersectInt64(a, b *Int64) (*Int64, error) {
+	c, err := intersect(int64Condition(a), int64Condition(b), int64Domain)
+	if err != nil {
+		return nil, err
+	}
+	return newInt64(c), nil
+}
+
+// IntersectUint64 works like IntersectInt64.
+func IntersectUint64(a, b *Uint64) (*Uint64, error) {
+	c, err := intersect(uint64Condition(a), uint64Condition(b), uint64Domain)
+	if err != nil {
+		return nil, err
+	}
+	return newUint64(c), nil
+}
+
+// IntersectFloat64 works like IntersectInt64.
+func IntersectFloat64(a, b *Float64) (*Float64, error) {
+	c, err := intersect(float64Condition(a), float64Condition(b), float64Domain)
+	if err != nil {
+		return nil, err
+	}
+	return newFloat64(c), nil
+}
+
+// IntersectTimestamp works like IntersectInt64.
+func IntersectTimestamp(a, b *Timestamp) (*Timestamp, error) {
+	for _, t := range []*Timestamp{a, b} {
+		if t.GetValid() && slices.Contains(t.GetValues(), nil) {
+			return nil, ErrNotRepresentable
+		}
+	}
+	c, err := intersect(timestampCondition(a), timestampCondition(b), timestampDomain)
+	if err != nil {
+		return nil, err
+	}
+	return newTimestamp(c), nil
+}
+
+func intersect[T any](a, b condition[T], d domain[T]) (condition[T], error) {
+	switch {
+	case !a.valid:
+		return normalize(b, d), nil
+	case !b.valid:
+		return normalize(a, d), nil
+	}
+
+	a, b = normalize(a, d), normalize(b, d)
+	if equalConditions(a, b, d) {
+		return a, nil
+	}
+
+	sa, oka := d.valueSet(a)
+	sb, okb := d.valueSet(b)
+	if oka && okb {
+		c, err := d.condition(d.intersect(sa, sb))
+		if err != nil {
+			return condition[T]{}, err
+		}
+		return normalize(c, d), nil
+	}
+	if values, ok := intersectElements(a.t, a.negation, a.values, b.t, b.negation, b.values, d.compare); ok {
+		return condition[T]{values: values, valid: true, t: a.t}, nil
+	}
+	// NOT NULL is implied by any other condition on a value.
+	if isNotNull(a.t, a.negation) && b.t != QueryType_NULL {
+		return b, nil
+	}
+	if isNotNull(b.t, b.negation) && a.t != QueryType_NULL {
+		return a, nil
+	}
+	return condition[T]{}, ErrNotRepresentable
+}
+
+func equalConditions[T any](a, b condition[T], d domain[T]) bool {
+	return a.valid == b.valid &&
+		a.negation == b.negation &&
+		a.t == b.t &&
+		a.lowerExclusive == b.lowerExclusive &&
+		a.upperExclusive == b.upperExclusive &&
+		slices.EqualFunc(a.values, b.values, func(x, y T) bool {
+			return d.compare(x, y) == 0
+		})
+}
+
+func isNotNull(t QueryType, negation bool) bool {
+	return t == QueryType_NULL && negation
+}
+
+// intersectElements combines array conditions of the same type.
+// It reports false if given conditions cannot be combined.
+func intersectElements[T any](ta QueryType, na bool, a []T, tb QueryType, nb bool, b []T, compare func(a, b T) int) ([]T, bool) {
+	if ta != tb || na || nb {
+		return nil, false
+	}
+	switch ta {
+	case QueryType_CONTAINS, QueryType_HAS_ALL_ELEMENTS:
+		return sortedSet(append(slices.Clone(a), b...), compare), true
+	case QueryType_IS_CONTAINED_BY:
+		var res []T
+		for _, v := range a {
+			if slices.ContainsFunc(b, func(w T) bool { return compare(v, w) == 0 }) {
+				res = append(res, v)
+			}
+		}
+		return res, true
+	default:
+		return nil, false
+	}
+}
+
+// IntersectString returns the narrowest condition that is satisfied by exactly those values that satisfy both a and b.
+// Invalid condition matches every value, so the other one is returned.
+// ErrUnsatisfiable is returned if no value can satisfy both conditions,
+// ErrNotRepresentable if the intersection cannot be expressed as a single condition.
+func IntersectString(a, b *String) (*String, error) {
+	switch {
+	case !a.GetValid() && !b.GetValid():
+		return &String{}, nil
+	case !a.GetValid():
+		return b.Normalize(), nil
+	case !b.GetValid():
+		return a.Normalize(), nil
+	}
+
+	a, b = a.Normalize(), b.Normalize()
+	if proto.Equal(a, b) {
+		return a, nil
+	}
+
+	switch {
+	case a.Type == QueryType_NULL && b.Type == QueryType_NULL:
+		return nil, ErrUnsatisfiable
+	case a.Type == QueryType_NULL && !a.Negation, b.Type == QueryType_NULL && !b.Negation:
+		// Any other condition on a value is not satisfied by NULL.
+		return nil, ErrUnsatisfiable
+	case isNotNull(a.Type, a.Negation):
+		return b, nil
+	case isNotNull(b.Type, b.Negation):
+		return a, nil
+	}
+
+	for _, pair := range [][2]*String{{a, b}, {b, a}} {
+		if res, ok := filterString(pair[0], pair[1]); ok {
+			if res == nil {
+				return nil, ErrUnsatisfiable
+			}
+			return res, nil
+		}
+	}
+	if res, ok := excludeStrings(a, b); ok {
+		return res, nil
+	}
+	if a.Insensitive == b.Insensitive {
+		if values, ok := intersectElements(a.Type, a.Negation, a.Values, b.Type, b.Negation, b.Values, compareString); ok {
+			return &String{Values: values, Valid: true, Type: a.Type, Insensitive: a.Insensitive}, nil
+		}
+	}
+	return intersectStringType(a, b)
+}
+
+// filterString narrows EQUAL or IN condition down to values that satisfy the other condition.
+// It returns nil if none of the values does.
+func filterString(in, other *String) (*String, bool) {
+	if in.Negation || (in.Type != QueryType_EQUAL && in.Type != QueryType_IN) {
+		return nil, false
+	}
+	// Insensitive value stands for all its case variants, they have to be matched by the other condition in the same way.
+	if in.Insensitive && !other.Insensitive && other.Type != QueryType_MIN_LENGTH && other.Type != QueryType_MAX_LENGTH {
+		return nil, false
+	}
+
+	var values []string
+	for _, v := range in.Values {
+		ok, err := MatchString(other, v)
+		if err != nil {
+			return nil, false
+		}
+		if ok {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return nil, true
+	}
+	return (&String{Values: values, Valid: true, Type: QueryType_IN, Insensitive: in.Insensitive}).Normalize(), true
+}
+
+// excludeStrings merges two negated EQUAL or IN conditions into a single NOT IN condition.
+func excludeStrings(a, b *String) (*String, bool) {
+	for _, s := range []*String{a, b} {
+		if !s.Negation || (s.Type != QueryType_EQUAL && s.Type != QueryType_IN) {
+			return nil, false
+		}
+	}
+	if a.Insensitive != b.Insensitive {
+		return nil, false
+	}
+	values := append(slices.Clone(a.Values), b.Values...)
+	return (&String{Values: values, Valid: true, Negation: true, Type: QueryType_IN, Insensitive: a.Insensitive}).Normalize(), true
+}
+
+// intersectStringType combines not negated conditions of the same type.
+func intersectStringType(a, b *String) (*String, error) {
+	if a.Negation || b.Negation || len(a.Values) != 1 || len(b.Values) != 1 {
+		return nil, ErrNotRepresentable
+	}
+
+	switch {
+	case a.Type == QueryType_MIN_LENGTH || a.Type == QueryType_MAX_LENGTH:
+		return intersectStringLength(a, b)
+	case a.Type != b.Type || a.Insensitive != b.Insensitive:
+		return nil, ErrNotRepresentable
+	}
+
+	x, y := a.Values[0], b.Values[0]
+	if a.Insensitive {
+		x, y = foldCase(x), foldCase(y)
+	}
+	longer := a
+	if len(y) > len(x) {
+		x, y, longer = y, x, b
+	}
+
+	switch a.Type {
+	case QueryType_HAS_PREFIX:
+		if !strings.HasPrefix(x, y) {
+			return nil, ErrUnsatisfiable
+		}
+		return longer, nil
+	case QueryType_HAS_SUFFIX:
+		if !strings.HasSuffix(x, y) {
+			return nil, ErrUnsatisfiable
+		}
+		return longer, nil
+	case QueryType_SUBSTRING:
+		if strings.Contains(x, y) {
+			return longer, nil
+		}
+	}
+	return nil, ErrNotRepresentable
+}
+
+func intersectStringLength(a, b *String) (*String, error) {
+	if b.Type != QueryType_MIN_LENGTH && b.Type != QueryType_MAX_LENGTH {
+		return nil, ErrNotRepresentable
+	}
+	x, errx := strconv.ParseUint(a.Values[0], 10, 63)
+	y, erry := strconv.ParseUint(b.Values[0], 10, 63)
+	if errx != nil || erry != nil {
+		return nil, ErrNotRepresentable
+	}
+
+	switch {
+	case a.Type == b.Type && (a.Type == QueryType_MIN_LENGTH) == (x > y):
+		return a, nil
+	case a.Type == b.Type:
+		return b, nil
+	case a.Type == QueryType_MAX_LENGTH:
+		x, y = y, x
+	}
+	if x > y {
+		return nil, ErrUnsatisfiable
+	}
+	return nil, ErrNotRepresentable
+}
+
+func foldCase(s string) string {
+	return defaultFolding.Fold(s)
+}
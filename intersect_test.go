@@ -0,0 +1,341 @@
+package qtypes
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestIntersectInt64(t *testing.T) {
+	cases := map[string]struct {
+		a, b     *Int64
+		expected *Int64
+		err      error
+	}{
+		"both-invalid": {
+			a:        nil,
+			b:        &Int64{},
+			expected: &Int64{},
+		},
+		"invalid": {
+			a:        nil,
+			b:        InInt64(2, 1),
+			expected: InInt64(1, 2),
+		},
+		"in-equal": {
+			a:        InInt64(1, 2),
+			b:        EqualInt64(2),
+			expected: EqualInt64(2),
+		},
+		"in-equal-unsatisfiable": {
+			a:   InInt64(1, 2),
+			b:   EqualInt64(3),
+			err: ErrUnsatisfiable,
+		},
+		"in-in": {
+			a:        InInt64(1, 2, 3),
+			b:        InInt64(2, 3, 4),
+			expected: InInt64(2, 3),
+		},
+		"in-not-in": {
+			a:        InInt64(1, 2, 3),
+			b:        &Int64{Values: []int64{2}, Valid: true, Negation: true, Type: QueryType_IN},
+			expected: InInt64(1, 3),
+		},
+		"in-greater": {
+			a:        InInt64(1, 5, 10),
+			b:        GreaterInt64(4),
+			expected: InInt64(5, 10),
+		},
+		"greater-less": {
+			a:        GreaterInt64(1),
+			b:        LessInt64(10),
+			expected: BetweenInt64(2, 9),
+		},
+		"greater-less-unsatisfiable": {
+			a:   GreaterInt64(1),
+			b:   LessInt64(2),
+			err: ErrUnsatisfiable,
+		},
+		"between-single-value": {
+			a:        BetweenInt64(1, 5),
+			b:        BetweenInt64(5, 10),
+			expected: EqualInt64(5),
+		},
+		"between-between": {
+			a:        BetweenInt64(1, 5),
+			b:        BetweenInt64(3, 10),
+			expected: BetweenInt64(3, 5),
+		},
+		"greater-not-equal": {
+			a:        GreaterEqualInt64(1),
+			b:        NotEqualInt64(1),
+			expected: GreaterEqualInt64(2),
+		},
+		"not-equal-not-equal": {
+			a:        NotEqualInt64(1),
+			b:        NotEqualInt64(3),
+			expected: &Int64{Values: []int64{1, 3}, Valid: true, Negation: true, Type: QueryType_IN},
+		},
+		"not-between-not-between": {
+			a:        &Int64{Values: []int64{1, 5}, Valid: true, Negation: true, Type: QueryType_BETWEEN},
+			b:        &Int64{Values: []int64{3, 10}, Valid: true, Negation: true, Type: QueryType_BETWEEN},
+			expected: &Int64{Values: []int64{1, 10}, Valid: true, Negation: true, Type: QueryType_BETWEEN},
+		},
+		"between-not-equal": {
+			a:   BetweenInt64(1, 10),
+			b:   NotEqualInt64(5),
+			err: ErrNotRepresentable,
+		},
+		"null-not-null": {
+			a:   NullInt64(),
+			b:   &Int64{Valid: true, Negation: true, Type: QueryType_NULL},
+			err: ErrUnsatisfiable,
+		},
+		"null-equal": {
+			a:   NullInt64(),
+			b:   EqualInt64(1),
+			err: ErrUnsatisfiable,
+		},
+		"not-null-equal": {
+			a:        &Int64{Valid: true, Negation: true, Type: QueryType_NULL},
+			b:        EqualInt64(1),
+			expected: EqualInt64(1),
+		},
+		"not-null-contains": {
+			a:        &Int64{Valid: true, Negation: true, Type: QueryType_NULL},
+			b:        &Int64{Values: []int64{1}, Valid: true, Type: QueryType_CONTAINS},
+			expected: &Int64{Values: []int64{1}, Valid: true, Type: QueryType_CONTAINS},
+		},
+		"contains-contains": {
+			a:        &Int64{Values: []int64{1, 2}, Valid: true, Type: QueryType_CONTAINS},
+			b:        &Int64{Values: []int64{3, 2}, Valid: true, Type: QueryType_CONTAINS},
+			expected: &Int64{Values: []int64{1, 2, 3}, Valid: true, Type: QueryType_CONTAINS},
+		},
+		"is-contained-by": {
+			a:        &Int64{Values: []int64{1, 2}, Valid: true, Type: QueryType_IS_CONTAINED_BY},
+			b:        &Int64{Values: []int64{3, 2}, Valid: true, Type: QueryType_IS_CONTAINED_BY},
+			expected: &Int64{Values: []int64{2}, Valid: true, Type: QueryType_IS_CONTAINED_BY},
+		},
+		"overlap-overlap": {
+			a:   &Int64{Values: []int64{1}, Valid: true, Type: QueryType_OVERLAP},
+			b:   &Int64{Values: []int64{2}, Valid: true, Type: QueryType_OVERLAP},
+			err: ErrNotRepresentable,
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := IntersectInt64(c.a, c.b)
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: wrong error, expected %v but got %v", hint, c.err, err)
+			continue
+		}
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestIntersectUint64(t *testing.T) {
+	got, err := IntersectUint64(&Uint64{Values: []uint64{5}, Valid: true, Type: QueryType_LESS}, &Uint64{Values: []uint64{1}, Valid: true, Negation: true, Type: QueryType_GREATER})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := (&Uint64{Values: []uint64{1}, Valid: true, Type: QueryType_LESS_EQUAL}); !proto.Equal(expected, got) {
+		t.Errorf("wrong output, expected %v but got %v", expected, got)
+	}
+}
+
+func TestIntersectFloat64(t *testing.T) {
+	cases := map[string]struct {
+		a, b     *Float64
+		expected *Float64
+		err      error
+	}{
+		"greater-less": {
+			a:        &Float64{Values: []float64{1}, Valid: true, Type: QueryType_GREATER},
+			b:        &Float64{Values: []float64{2}, Valid: true, Type: QueryType_LESS},
+			expected: RangeFloat64(1, 2, true, true),
+		},
+		"touching": {
+			a:        &Float64{Values: []float64{1}, Valid: true, Type: QueryType_GREATER_EQUAL},
+			b:        &Float64{Values: []float64{1}, Valid: true, Type: QueryType_LESS_EQUAL},
+			expected: EqualFloat64(1),
+		},
+		"half-open-ranges": {
+			a:   RangeFloat64(0, 1, false, true),
+			b:   RangeFloat64(1, 2, false, true),
+			err: ErrUnsatisfiable,
+		},
+		"not-between": {
+			a:        RangeFloat64(0, 10, false, false),
+			b:        &Float64{Values: []float64{5, 20}, Valid: true, Negation: true, Type: QueryType_BETWEEN},
+			expected: RangeFloat64(0, 5, false, true),
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := IntersectFloat64(c.a, c.b)
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: wrong error, expected %v but got %v", hint, c.err, err)
+			continue
+		}
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestIntersectTimestamp(t *testing.T) {
+	a := &knowntimestamp.Timestamp{Seconds: 1}
+	b := &knowntimestamp.Timestamp{Seconds: 2}
+	c := &knowntimestamp.Timestamp{Seconds: 3}
+
+	got, err := IntersectTimestamp(RangeTimestamp(a, c, false, true), &Timestamp{Values: []*knowntimestamp.Timestamp{b}, Valid: true, Type: QueryType_GREATER_EQUAL})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := RangeTimestamp(b, c, false, true); !proto.Equal(expected, got) {
+		t.Errorf("wrong output, expected %v but got %v", expected, got)
+	}
+
+	_, err = IntersectTimestamp(&Timestamp{Values: []*knowntimestamp.Timestamp{a}, Valid: true, Type: QueryType_EQUAL}, &Timestamp{Values: []*knowntimestamp.Timestamp{b}, Valid: true, Type: QueryType_EQUAL})
+	if !errors.Is(err, ErrUnsatisfiable) {
+		t.Errorf("wrong error, expected %v but got %v", ErrUnsatisfiable, err)
+	}
+	_, err = IntersectTimestamp(&Timestamp{Values: []*knowntimestamp.Timestamp{nil}, Valid: true, Type: QueryType_EQUAL}, &Timestamp{Values: []*knowntimestamp.Timestamp{b}, Valid: true, Type: QueryType_EQUAL})
+	if !errors.Is(err, ErrNotRepresentable) {
+		t.Errorf("wrong error, expected %v but got %v", ErrNotRepresentable, err)
+	}
+}
+
+func TestIntersectString(t *testing.T) {
+	cases := map[string]struct {
+		a, b     *String
+		expected *String
+		err      error
+	}{
+		"invalid": {
+			a:        &String{},
+			b:        EqualString("a"),
+			expected: EqualString("a"),
+		},
+		"in-equal": {
+			a:        inString("a", "b"),
+			b:        EqualString("b"),
+			expected: EqualString("b"),
+		},
+		"in-in-unsatisfiable": {
+			a:   inString("a", "b"),
+			b:   inString("c", "d"),
+			err: ErrUnsatisfiable,
+		},
+		"in-has-prefix": {
+			a:        inString("foo", "bar", "fizz"),
+			b:        HasPrefixString("f"),
+			expected: inString("fizz", "foo"),
+		},
+		"in-not-in": {
+			a:        inString("a", "b", "c"),
+			b:        &String{Values: []string{"b"}, Valid: true, Negation: true, Type: QueryType_EQUAL},
+			expected: inString("a", "c"),
+		},
+		"in-max-length": {
+			a:        inString("a", "abc"),
+			b:        &String{Values: []string{"2"}, Valid: true, Type: QueryType_MAX_LENGTH},
+			expected: EqualString("a"),
+		},
+		"in-insensitive-prefix": {
+			a:        inString("Foo", "bar"),
+			b:        &String{Values: []string{"f"}, Valid: true, Type: QueryType_HAS_PREFIX, Insensitive: true},
+			expected: EqualString("Foo"),
+		},
+		"in-pattern": {
+			a:        inString("a1", "b"),
+			b:        &String{Values: []string{"[0-9]$"}, Valid: true, Type: QueryType_PATTERN},
+			expected: EqualString("a1"),
+		},
+		"not-in-not-in": {
+			a:        &String{Values: []string{"b"}, Valid: true, Negation: true, Type: QueryType_EQUAL},
+			b:        &String{Values: []string{"a"}, Valid: true, Negation: true, Type: QueryType_EQUAL},
+			expected: &String{Values: []string{"a", "b"}, Valid: true, Negation: true, Type: QueryType_IN},
+		},
+		"in-insensitive-prefix-insensitive": {
+			a:        InStringInsensitive("John@example.com", "jane@example.org"),
+			b:        &String{Values: []string{"JOHN"}, Valid: true, Type: QueryType_HAS_PREFIX, Insensitive: true},
+			expected: EqualStringInsensitive("John@example.com"),
+		},
+		"in-insensitive-prefix-sensitive": {
+			a:   InStringInsensitive("John@example.com"),
+			b:   HasPrefixString("john"),
+			err: ErrNotRepresentable,
+		},
+		"not-in-insensitive": {
+			a:        &String{Values: []string{"a"}, Valid: true, Negation: true, Type: QueryType_EQUAL, Insensitive: true},
+			b:        &String{Values: []string{"b"}, Valid: true, Negation: true, Type: QueryType_EQUAL, Insensitive: true},
+			expected: &String{Values: []string{"a", "b"}, Valid: true, Negation: true, Type: QueryType_IN, Insensitive: true},
+		},
+		"has-prefix": {
+			a:        HasPrefixString("ab"),
+			b:        HasPrefixString("a"),
+			expected: HasPrefixString("ab"),
+		},
+		"has-prefix-unsatisfiable": {
+			a:   HasPrefixString("ab"),
+			b:   HasPrefixString("b"),
+			err: ErrUnsatisfiable,
+		},
+		"has-suffix-insensitive": {
+			a:        &String{Values: []string{"B"}, Valid: true, Type: QueryType_HAS_SUFFIX, Insensitive: true},
+			b:        &String{Values: []string{"ab"}, Valid: true, Type: QueryType_HAS_SUFFIX, Insensitive: true},
+			expected: &String{Values: []string{"ab"}, Valid: true, Type: QueryType_HAS_SUFFIX, Insensitive: true},
+		},
+		"substring": {
+			a:        SubString("abc"),
+			b:        SubString("b"),
+			expected: SubString("abc"),
+		},
+		"substring-different": {
+			a:   SubString("a"),
+			b:   SubString("b"),
+			err: ErrNotRepresentable,
+		},
+		"min-max-length": {
+			a:   &String{Values: []string{"5"}, Valid: true, Type: QueryType_MIN_LENGTH},
+			b:   &String{Values: []string{"3"}, Valid: true, Type: QueryType_MAX_LENGTH},
+			err: ErrUnsatisfiable,
+		},
+		"max-max-length": {
+			a:        &String{Values: []string{"5"}, Valid: true, Type: QueryType_MAX_LENGTH},
+			b:        &String{Values: []string{"3"}, Valid: true, Type: QueryType_MAX_LENGTH},
+			expected: &String{Values: []string{"3"}, Valid: true, Type: QueryType_MAX_LENGTH},
+		},
+		"null-equal": {
+			a:   NullString(),
+			b:   EqualString("a"),
+			err: ErrUnsatisfiable,
+		},
+		"not-null-prefix": {
+			a:        &String{Valid: true, Negation: true, Type: QueryType_NULL},
+			b:        HasPrefixString("a"),
+			expected: HasPrefixString("a"),
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := IntersectString(c.a, c.b)
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: wrong error, expected %v but got %v", hint, c.err, err)
+			continue
+		}
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func inString(values ...string) *String {
+	return &String{Values: values, Valid: true, Type: QueryType_IN}
+}
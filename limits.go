@@ -0,0 +1,183 @@
+package qtypes
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrLimitExceeded is wrapped by errors returned when a condition exceeds limits.
+var ErrLimitExceeded = errors.New("qtypes: limit exceeded")
+
+// Limit identifies a limit of Limits.
+type Limit int
+
+const (
+	// LimitValues is the number of values of a condition.
+	LimitValues Limit = iota
+	// LimitStringLength is the length of a string value, in bytes.
+	LimitStringLength
+	// LimitPatternLength is the length of a PATTERN or WILDCARD value, in bytes.
+	LimitPatternLength
+	// LimitPatternComplexity is the complexity of a pattern.
+	LimitPatternComplexity
+	// LimitConditions is the number of conditions.
+	LimitConditions
+)
+
+// String implements fmt.Stringer interface.
+func (l Limit) String() string {
+	switch l {
+	case LimitValues:
+		return "values"
+	case LimitStringLength:
+		return "string length"
+	case LimitPatternLength:
+		return "pattern length"
+	case LimitPatternComplexity:
+		return "pattern complexity"
+	case LimitConditions:
+		return "conditions"
+	default:
+		return fmt.Sprintf("Limit(%d)", int(l))
+	}
+}
+
+// Limits restricts size of conditions, so that abusive filters can be rejected before they reach a database.
+// Zero value of any field means no limit.
+type Limits struct {
+	// MaxValues is the maximum number of values of a single condition.
+	MaxValues int
+	// MaxStringLength is the maximum length of a single string value, in bytes.
+	MaxStringLength int
+	// MaxPatternLength is the maximum length of a PATTERN or WILDCARD value, in bytes.
+	MaxPatternLength int
+	// MaxPatternComplexity is the maximum complexity of a PATTERN value,
+	// measured as the number of instructions of the compiled expression.
+	// Repetitions count as many times as they can repeat, e.g. "a{100}" is more complex than "a+".
+	// WILDCARD values are not compiled, and time of matching them is bounded by their length, see MatchWildcard.
+	MaxPatternComplexity int
+	// MaxConditions is the maximum number of valid conditions within a message or a request.
+	MaxConditions int
+}
+
+// LimitError is returned when a condition exceeds limits. It wraps ErrLimitExceeded.
+type LimitError struct {
+	// Field identifies the condition, it is empty if the limit applies to all conditions.
+	Field  string
+	Limit  Limit
+	Max    int
+	Actual int
+}
+
+// Error implements error interface.
+func (e *LimitError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("qtypes: %s limit of %d exceeded: got %d", e.Limit, e.Max, e.Actual)
+	}
+	return fmt.Sprintf("qtypes: %s limit of %d exceeded for field %q: got %d", e.Limit, e.Max, e.Field, e.Actual)
+}
+
+// Unwrap returns ErrLimitExceeded.
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Check returns *LimitError if given condition on given field exceeds the limits.
+// Limit of number of conditions is not checked, see CheckCount.
+func (l Limits) Check(field string, c Condition) error {
+	if c == nil || !c.GetValid() {
+		return nil
+	}
+
+	var n int
+	switch v := c.(type) {
+	case *Int64:
+		n = len(v.Values)
+	case *Uint64:
+		n = len(v.Values)
+	case *Float64:
+		n = len(v.Values)
+	case *Timestamp:
+		n = len(v.Values)
+	case *String:
+		n = len(v.Values)
+		if err := l.checkString(field, v); err != nil {
+			return err
+		}
+	}
+	return l.CheckValues(field, n)
+}
+
+// CheckValues returns *LimitError if n exceeds maximum number of values.
+func (l Limits) CheckValues(field string, n int) error {
+	if l.MaxValues > 0 && n > l.MaxValues {
+		return &LimitError{Field: field, Limit: LimitValues, Max: l.MaxValues, Actual: n}
+	}
+	return nil
+}
+
+// CheckCount returns *LimitError if n exceeds maximum number of conditions.
+func (l Limits) CheckCount(n int) error {
+	if l.MaxConditions > 0 && n > l.MaxConditions {
+		return &LimitError{Limit: LimitConditions, Max: l.MaxConditions, Actual: n}
+	}
+	return nil
+}
+
+func (l Limits) checkString(field string, s *String) error {
+	for _, v := range s.Values {
+		if l.MaxStringLength > 0 && len(v) > l.MaxStringLength {
+			return &LimitError{Field: field, Limit: LimitStringLength, Max: l.MaxStringLength, Actual: len(v)}
+		}
+		if s.Type != QueryType_PATTERN && s.Type != QueryType_WILDCARD {
+			continue
+		}
+		if l.MaxPatternLength > 0 && len(v) > l.MaxPatternLength {
+			return &LimitError{Field: field, Limit: LimitPatternLength, Max: l.MaxPatternLength, Actual: len(v)}
+		}
+		if l.MaxPatternComplexity > 0 && s.Type == QueryType_PATTERN {
+			n, err := patternComplexity(v)
+			if err != nil {
+				return fmt.Errorf("qtypes: pattern of field %q: %w", field, &PatternError{Pattern: v, Reason: err.Error()})
+			}
+			if n > l.MaxPatternComplexity {
+				return &LimitError{Field: field, Limit: LimitPatternComplexity, Max: l.MaxPatternComplexity, Actual: n}
+			}
+		}
+	}
+	return nil
+}
+
+func patternComplexity(expr string) (int, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return 0, err
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return 0, err
+	}
+	return len(prog.Inst), nil
+}
+
+// Validate walks through given message (e.g. a gRPC request) and checks every condition it contains against the limits,
+// including the total number of valid conditions. Fields are identified the same way as in Policy.CheckMessage.
+func (l Limits) Validate(m proto.Message) error {
+	if m == nil {
+		return nil
+	}
+	var n int
+	return walkConditions(m.ProtoReflect(), "", func(field string, c Condition) error {
+		if !c.GetValid() {
+			return nil
+		}
+		n++
+		if err := l.CheckCount(n); err != nil {
+			return err
+		}
+		return l.Check(field, c)
+	})
+}
@@ -0,0 +1,166 @@
+package qtypes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestLimits_Check(t *testing.T) {
+	limits := Limits{
+		MaxValues:            3,
+		MaxStringLength:      10,
+		MaxPatternLength:     8,
+		MaxPatternComplexity: 20,
+	}
+	pattern := func(v string) *String {
+		return &String{Values: []string{v}, Valid: true, Type: QueryType_PATTERN}
+	}
+
+	cases := map[string]struct {
+		given    Condition
+		expected Limit
+		ok       bool
+	}{
+		"int64":              {given: InInt64(1, 2, 3), ok: true},
+		"int64-values":       {given: InInt64(1, 2, 3, 4), expected: LimitValues},
+		"uint64":             {given: BetweenUint64(1, 2), ok: true},
+		"float64-values":     {given: &Float64{Values: []float64{1, 2, 3, 4}, Valid: true, Type: QueryType_IN}, expected: LimitValues},
+		"invalid":            {given: &Int64{Values: []int64{1, 2, 3, 4}, Type: QueryType_IN}, ok: true},
+		"nil":                {given: nil, ok: true},
+		"string":             {given: EqualString("abc"), ok: true},
+		"string-values":      {given: inString("a", "b", "c", "d"), expected: LimitValues},
+		"string-length":      {given: EqualString(strings.Repeat("a", 11)), expected: LimitStringLength},
+		"pattern":            {given: pattern("^a+b$"), ok: true},
+		"pattern-length":     {given: pattern(strings.Repeat("a", 9)), expected: LimitPatternLength},
+		"pattern-complexity": {given: pattern("a{1,50}"), expected: LimitPatternComplexity},
+		"not-a-pattern":      {given: EqualString("a{1,50}"), ok: true},
+		"wildcard":           {given: WildcardString("b{1,50}"), ok: true},
+		"wildcard-length":    {given: WildcardString(strings.Repeat("*", 9)), expected: LimitPatternLength},
+	}
+
+	for hint, c := range cases {
+		err := limits.Check("field", c.given)
+		if c.ok {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			}
+			continue
+		}
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) || !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%s: expected limit error, got %v", hint, err)
+			continue
+		}
+		if limitErr.Limit != c.expected || limitErr.Field != "field" {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, limitErr.Limit)
+		}
+	}
+}
+
+func TestLimits_Check_invalidPattern(t *testing.T) {
+	err := Limits{MaxPatternComplexity: 10}.Check("field", &String{Values: []string{"a("}, Valid: true, Type: QueryType_PATTERN})
+	if !errors.Is(err, ErrUnsupportedPattern) || errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected pattern error, got %v", err)
+	}
+}
+
+func TestLimits_zero(t *testing.T) {
+	var limits Limits
+	if err := limits.Check("field", InInt64(make([]int64, 1000)...)); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if err := limits.CheckCount(1000); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestLimitError_Error(t *testing.T) {
+	cases := map[string]struct {
+		given    *LimitError
+		expected string
+	}{
+		"field": {
+			given:    &LimitError{Field: "name", Limit: LimitStringLength, Max: 10, Actual: 11},
+			expected: `qtypes: string length limit of 10 exceeded for field "name": got 11`,
+		},
+		"conditions": {
+			given:    &LimitError{Limit: LimitConditions, Max: 2, Actual: 3},
+			expected: "qtypes: conditions limit of 2 exceeded: got 3",
+		},
+	}
+
+	for hint, c := range cases {
+		if got := c.given.Error(); got != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestLimits_Validate(t *testing.T) {
+	mt := testMessageType(t)
+	fields := mt.Descriptor().Fields()
+
+	newFilter := func(age *Int64, names ...*String) protoreflect.Message {
+		m := mt.New()
+		if age != nil {
+			m.Set(fields.ByName("age"), protoreflect.ValueOfMessage(age.ProtoReflect()))
+		}
+		list := m.Mutable(fields.ByName("name")).List()
+		for _, n := range names {
+			list.Append(protoreflect.ValueOfMessage(n.ProtoReflect()))
+		}
+		return m
+	}
+
+	limits := Limits{MaxValues: 2, MaxConditions: 3}
+
+	cases := map[string]struct {
+		given    protoreflect.Message
+		field    string
+		expected Limit
+		ok       bool
+	}{
+		"empty": {
+			given: mt.New(),
+			ok:    true,
+		},
+		"within": {
+			given: newFilter(BetweenInt64(1, 2), EqualString("a"), &String{Values: []string{"a", "b", "c"}}),
+			ok:    true,
+		},
+		"values": {
+			given:    newFilter(nil, EqualString("a"), inString("a", "b", "c")),
+			field:    "name",
+			expected: LimitValues,
+		},
+		"conditions": {
+			given: func() protoreflect.Message {
+				m := newFilter(EqualInt64(1), EqualString("a"))
+				m.Set(fields.ByName("nested"), protoreflect.ValueOfMessage(newFilter(EqualInt64(2), EqualString("b"))))
+				return m
+			}(),
+			expected: LimitConditions,
+		},
+	}
+
+	for hint, c := range cases {
+		err := limits.Validate(c.given.Interface())
+		if c.ok {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			}
+			continue
+		}
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) {
+			t.Errorf("%s: expected limit error, got %v", hint, err)
+			continue
+		}
+		if limitErr.Limit != c.expected || limitErr.Field != c.field {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v %q\nbut got:\n	%v %q\n", hint, c.expected, c.field, limitErr.Limit, limitErr.Field)
+		}
+	}
+}
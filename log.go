@@ -0,0 +1,217 @@
+package qtypes
+
+import (
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logOperators follow prefixes used by qtypeshttp package.
+// Negation is expressed by "n" prefix and insensitivity by "i" suffix, e.g. "nhpi".
+var logOperators = map[QueryType]string{
+	QueryType_NULL:             "null",
+	QueryType_EQUAL:            "eq",
+	QueryType_GREATER:          "gt",
+	QueryType_GREATER_EQUAL:    "gte",
+	QueryType_LESS:             "lt",
+	QueryType_LESS_EQUAL:       "lte",
+	QueryType_IN:               "in",
+	QueryType_BETWEEN:          "bw",
+	QueryType_HAS_PREFIX:       "hp",
+	QueryType_HAS_SUFFIX:       "hs",
+	QueryType_SUBSTRING:        "sub",
+	QueryType_PATTERN:          "rgx",
+	QueryType_MIN_LENGTH:       "minl",
+	QueryType_MAX_LENGTH:       "maxl",
+	QueryType_OVERLAP:          "ovl",
+	QueryType_CONTAINS:         "cts",
+	QueryType_IS_CONTAINED_BY:  "icb",
+	QueryType_HAS_ELEMENT:      "he",
+	QueryType_HAS_ANY_ELEMENT:  "hae",
+	QueryType_HAS_ALL_ELEMENTS: "hle",
+	QueryType_WILDCARD:         "glob",
+}
+
+const redactedValue = "***"
+
+// logValue is a compact, textual representation of a condition, e.g. "in:1,2,3" or "bw:[1,5)".
+// It is passed to slog as is, so that ReplaceAttr functions can still redact it.
+type logValue struct {
+	valid          bool
+	t              QueryType
+	negation       bool
+	insensitive    bool
+	values         []string
+	lowerExclusive bool
+	upperExclusive bool
+	redacted       bool
+}
+
+// MarshalText implements encoding.TextMarshaler interface, that slog handlers use to render the value.
+func (v logValue) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// String implements fmt.Stringer interface.
+func (v logValue) String() string {
+	if !v.valid {
+		return ""
+	}
+
+	op, ok := logOperators[v.t]
+	if !ok {
+		op = strconv.Itoa(int(v.t))
+	}
+	if v.negation {
+		op = "n" + op
+	}
+	if v.insensitive {
+		op += "i"
+	}
+	if v.t == QueryType_NULL {
+		return op
+	}
+
+	values := v.values
+	if v.redacted {
+		values = make([]string, len(v.values))
+		for i := range values {
+			values[i] = redactedValue
+		}
+	}
+	if v.t == QueryType_BETWEEN && len(values) == 2 && (v.lowerExclusive || v.upperExclusive) {
+		lo, hi := "[", "]"
+		if v.lowerExclusive {
+			lo = "("
+		}
+		if v.upperExclusive {
+			hi = ")"
+		}
+		return op + ":" + lo + strings.Join(values, ",") + hi
+	}
+	return op + ":" + strings.Join(values, ",")
+}
+
+// LogValue implements slog.LogValuer interface.
+// The condition is rendered in a compact form, e.g. "in:1,2,3", "nbw:[1,5)" or "null".
+func (i *Int64) LogValue() slog.Value {
+	values := make([]string, 0, len(i.GetValues()))
+	for _, v := range i.GetValues() {
+		values = append(values, strconv.FormatInt(v, 10))
+	}
+	return slog.AnyValue(logValue{
+		valid:          i.GetValid(),
+		t:              i.GetType(),
+		negation:       i.GetNegation(),
+		values:         values,
+		lowerExclusive: i.GetLowerExclusive(),
+		upperExclusive: i.GetUpperExclusive(),
+	})
+}
+
+// LogValue implements slog.LogValuer interface, see Int64.LogValue.
+func (u *Uint64) LogValue() slog.Value {
+	values := make([]string, 0, len(u.GetValues()))
+	for _, v := range u.GetValues() {
+		values = append(values, strconv.FormatUint(v, 10))
+	}
+	return slog.AnyValue(logValue{
+		valid:          u.GetValid(),
+		t:              u.GetType(),
+		negation:       u.GetNegation(),
+		values:         values,
+		lowerExclusive: u.GetLowerExclusive(),
+		upperExclusive: u.GetUpperExclusive(),
+	})
+}
+
+// LogValue implements slog.LogValuer interface, see Int64.LogValue.
+func (f *Float64) LogValue() slog.Value {
+	values := make([]string, 0, len(f.GetValues()))
+	for _, v := range f.GetValues() {
+		values = append(values, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return slog.AnyValue(logValue{
+		valid:          f.GetValid(),
+		t:              f.GetType(),
+		negation:       f.GetNegation(),
+		values:         values,
+		lowerExclusive: f.GetLowerExclusive(),
+		upperExclusive: f.GetUpperExclusive(),
+	})
+}
+
+// LogValue implements slog.LogValuer interface, see Int64.LogValue.
+// Values are rendered in RFC3339 format, in UTC.
+func (t *Timestamp) LogValue() slog.Value {
+	values := make([]string, 0, len(t.GetValues()))
+	for _, v := range t.GetValues() {
+		if v == nil {
+			values = append(values, "null")
+			continue
+		}
+		values = append(values, v.AsTime().Format(time.RFC3339Nano))
+	}
+	return slog.AnyValue(logValue{
+		valid:          t.GetValid(),
+		t:              t.GetType(),
+		negation:       t.GetNegation(),
+		values:         values,
+		lowerExclusive: t.GetLowerExclusive(),
+		upperExclusive: t.GetUpperExclusive(),
+	})
+}
+
+// LogValue implements slog.LogValuer interface, see Int64.LogValue.
+func (s *String) LogValue() slog.Value {
+	return slog.AnyValue(logValue{
+		valid:       s.GetValid(),
+		t:           s.GetType(),
+		negation:    s.GetNegation(),
+		insensitive: s.GetInsensitive(),
+		values:      slices.Clone(s.GetValues()),
+	})
+}
+
+// Redact wraps given value so that it is logged without values.
+// Conditions of this package keep the operator and the number of values, e.g. "in:***,***".
+// Any other value is masked entirely.
+func Redact(v slog.LogValuer) slog.LogValuer {
+	return redacted{v: v}
+}
+
+type redacted struct {
+	v slog.LogValuer
+}
+
+// LogValue implements slog.LogValuer interface.
+func (r redacted) LogValue() slog.Value {
+	if r.v == nil {
+		return slog.StringValue(redactedValue)
+	}
+	return redact(r.v.LogValue())
+}
+
+func redact(v slog.Value) slog.Value {
+	if v.Kind() == slog.KindAny {
+		if lv, ok := v.Any().(logValue); ok {
+			lv.redacted = true
+			return slog.AnyValue(lv)
+		}
+	}
+	return slog.StringValue(redactedValue)
+}
+
+// RedactKeys returns function that can be used as slog.HandlerOptions.ReplaceAttr.
+// It redacts conditions logged under given keys, see Redact.
+// Attributes within groups are matched by their own key.
+func RedactKeys(keys ...string) func(groups []string, a slog.Attr) slog.Attr {
+	return func(_ []string, a slog.Attr) slog.Attr {
+		if slices.Contains(keys, a.Key) && a.Value.Kind() != slog.KindGroup {
+			a.Value = redact(a.Value)
+		}
+		return a
+	}
+}
@@ -0,0 +1,85 @@
+package qtypes
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLogValue(t *testing.T) {
+	cases := map[string]struct {
+		given    slog.LogValuer
+		expected string
+	}{
+		"int64-in":             {given: InInt64(1, 2, 3), expected: "in:1,2,3"},
+		"int64-not-between":    {given: &Int64{Values: []int64{1, 5}, Valid: true, Negation: true, Type: QueryType_BETWEEN, UpperExclusive: true}, expected: "nbw:[1,5)"},
+		"int64-null":           {given: NullInt64(), expected: "null"},
+		"int64-not-null":       {given: &Int64{Valid: true, Negation: true, Type: QueryType_NULL}, expected: "nnull"},
+		"int64-invalid":        {given: &Int64{Values: []int64{1}}, expected: ""},
+		"int64-nil":            {given: (*Int64)(nil), expected: ""},
+		"uint64-greater":       {given: &Uint64{Values: []uint64{1}, Valid: true, Type: QueryType_GREATER}, expected: "gt:1"},
+		"float64-less-equal":   {given: &Float64{Values: []float64{1.5}, Valid: true, Type: QueryType_LESS_EQUAL}, expected: "lte:1.5"},
+		"timestamp-equal":      {given: &Timestamp{Values: []*knowntimestamp.Timestamp{{Seconds: 1}}, Valid: true, Type: QueryType_EQUAL}, expected: "eq:1970-01-01T00:00:01Z"},
+		"string-has-prefix":    {given: &String{Values: []string{"ab"}, Valid: true, Type: QueryType_HAS_PREFIX, Insensitive: true}, expected: "hpi:ab"},
+		"string-not-substring": {given: &String{Values: []string{"ab"}, Valid: true, Negation: true, Type: QueryType_SUBSTRING, Insensitive: true}, expected: "nsubi:ab"},
+		"redact-string":        {given: Redact(inString("john@example.com", "jane@example.com")), expected: "in:***,***"},
+		"redact-between":       {given: Redact(RangeInt64(1, 5, true, false)), expected: "bw:(***,***]"},
+		"redact-null":          {given: Redact(NullString()), expected: "null"},
+		"redact-other":         {given: Redact(secret("password")), expected: "***"},
+		"redact-nil":           {given: Redact(nil), expected: "***"},
+	}
+
+	for hint, c := range cases {
+		got := c.given.LogValue().Resolve()
+		if s := valueString(got); s != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, s)
+		}
+	}
+}
+
+type secret string
+
+func (s secret) LogValue() slog.Value {
+	return slog.StringValue(string(s))
+}
+
+func valueString(v slog.Value) string {
+	if v.Kind() == slog.KindAny {
+		if lv, ok := v.Any().(logValue); ok {
+			return lv.String()
+		}
+	}
+	return v.String()
+}
+
+func TestLogValue_handlers(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: RedactKeys("email"),
+	}))
+	logger.Info("search",
+		slog.Any("email", EqualString("john@example.com")),
+		slog.Any("age", BetweenInt64(18, 65)),
+		slog.Group("filter", slog.Any("email", HasSuffixString("@example.com"))),
+	)
+
+	got := buf.String()
+	for _, expected := range []string{`"email":"eq:***"`, `"age":"bw:18,65"`, `"filter":{"email":"hs:***"}`} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("output should contain %s, got: %s", expected, got)
+		}
+	}
+	if strings.Contains(got, "example.com") {
+		t.Errorf("output should not contain redacted values, got: %s", got)
+	}
+
+	buf.Reset()
+	logger = slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("search", slog.Any("name", &String{Values: []string{"ab"}, Valid: true, Type: QueryType_HAS_PREFIX}))
+	if got := buf.String(); !strings.Contains(got, "name=hp:ab") {
+		t.Errorf("text output should contain compact form, got: %s", got)
+	}
+}
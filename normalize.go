@@ -0,0 +1,237 @@
+package qtypes
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Normalize returns canonical equivalent of the object.
+// Two objects that express the same condition in a different way normalize to the same object, e.g.:
+//
+//   - IN with a single value becomes EQUAL,
+//   - values of IN are sorted and deduplicated,
+//   - negated GREATER becomes LESS_EQUAL (and so on for other comparisons),
+//   - BETWEEN with swapped bounds gets them in order,
+//   - exclusive bounds become inclusive, e.g. GREATER 5 becomes GREATER_EQUAL 6,
+//   - comparison that covers every possible value becomes NOT NULL.
+//
+// Invalid object normalizes to an empty one. The receiver is never modified.
+func (i *Int64) Normalize() *Int64 {
+	if i == nil {
+		return nil
+	}
+	return newInt64(normalize(int64Condition(i), int64Domain))
+}
+
+// Normalize returns canonical equivalent of the object.
+// It follows the same rules as Int64.Normalize.
+func (u *Uint64) Normalize() *Uint64 {
+	if u == nil {
+		return nil
+	}
+	return newUint64(normalize(uint64Condition(u), uint64Domain))
+}
+
+// Normalize returns canonical equivalent of the object.
+// It follows the same rules as Int64.Normalize, except that exclusive bounds are kept as they are.
+func (f *Float64) Normalize() *Float64 {
+	if f == nil {
+		return nil
+	}
+	return newFloat64(normalize(float64Condition(f), float64Domain))
+}
+
+// Normalize returns canonical equivalent of the object.
+// It follows the same rules as Int64.Normalize, except that exclusive bounds are kept as they are.
+func (t *Timestamp) Normalize() *Timestamp {
+	if t == nil {
+		return nil
+	}
+	for _, v := range t.Values {
+		if v == nil {
+			return newTimestamp(timestampCondition(t))
+		}
+	}
+	return newTimestamp(normalize(timestampCondition(t), timestampDomain))
+}
+
+// Normalize returns canonical equivalent of the object, e.g.:
+//
+//   - IN with a single value becomes EQUAL,
+//   - values of IN are sorted and deduplicated,
+//   - negated MIN_LENGTH becomes MAX_LENGTH and vice versa,
+//   - insensitivity is dropped where it does not matter (e.g. NULL or MIN_LENGTH).
+//
+// Invalid object normalizes to an empty one. The receiver is never modified.
+func (s *String) Normalize() *String {
+	if s == nil {
+		return nil
+	}
+	if !s.Valid {
+		return &String{}
+	}
+
+	res := &String{
+		Values:      slices.Clone(s.Values),
+		Valid:       true,
+		Negation:    s.Negation,
+		Type:        s.Type,
+		Insensitive: s.Insensitive,
+	}
+	switch s.Type {
+	case QueryType_NULL:
+		res.Values = nil
+		res.Insensitive = false
+	case QueryType_IN:
+		res.Values = sortedSet(s.Values, compareString)
+		if len(res.Values) == 1 {
+			res.Type = QueryType_EQUAL
+		}
+	case QueryType_CONTAINS, QueryType_IS_CONTAINED_BY, QueryType_OVERLAP, QueryType_HAS_ANY_ELEMENT, QueryType_HAS_ALL_ELEMENTS:
+		res.Values = sortedSet(s.Values, compareString)
+	case QueryType_MIN_LENGTH, QueryType_MAX_LENGTH:
+		res.Insensitive = false
+		if len(s.Values) != 1 {
+			break
+		}
+		n, err := strconv.ParseUint(s.Values[0], 10, 63)
+		if err != nil {
+			break
+		}
+		switch {
+		case s.Negation && s.Type == QueryType_MIN_LENGTH && n > 0:
+			res.Type, res.Negation, n = QueryType_MAX_LENGTH, false, n-1
+		case s.Negation && s.Type == QueryType_MAX_LENGTH:
+			res.Type, res.Negation, n = QueryType_MIN_LENGTH, false, n+1
+		}
+		res.Values = []string{strconv.FormatUint(n, 10)}
+	}
+	return res
+}
+
+func compareString(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func normalize[T any](c condition[T], d domain[T]) condition[T] {
+	if !c.valid {
+		return condition[T]{}
+	}
+
+	res := condition[T]{
+		values:   slices.Clone(c.values),
+		valid:    true,
+		negation: c.negation,
+		t:        c.t,
+	}
+	switch c.t {
+	case QueryType_NULL:
+		res.values = nil
+	case QueryType_GREATER, QueryType_GREATER_EQUAL, QueryType_LESS, QueryType_LESS_EQUAL:
+		if len(c.values) != 1 {
+			break
+		}
+		if res.negation {
+			res.t, res.negation = complementComparison(res.t), false
+		}
+		return normalizeComparison(res, d)
+	case QueryType_IN:
+		res.values = sortedSet(c.values, d.compare)
+		if len(res.values) == 1 {
+			res.t = QueryType_EQUAL
+		}
+	case QueryType_BETWEEN:
+		if len(c.values) != 2 {
+			res.lowerExclusive, res.upperExclusive = c.lowerExclusive, c.upperExclusive
+			break
+		}
+		return normalizeBetween(c, d)
+	case QueryType_CONTAINS, QueryType_IS_CONTAINED_BY, QueryType_OVERLAP, QueryType_HAS_ANY_ELEMENT, QueryType_HAS_ALL_ELEMENTS:
+		res.values = sortedSet(c.values, d.compare)
+	}
+	return res
+}
+
+// normalizeComparison expects not negated comparison with a single value.
+func normalizeComparison[T any](c condition[T], d domain[T]) condition[T] {
+	v := c.values[0]
+	if d.discrete() {
+		switch c.t {
+		case QueryType_GREATER:
+			if n, ok := d.next(v); ok {
+				c.t, c.values[0], v = QueryType_GREATER_EQUAL, n, n
+			}
+		case QueryType_LESS:
+			if p, ok := d.prev(v); ok {
+				c.t, c.values[0], v = QueryType_LESS_EQUAL, p, p
+			}
+		}
+	}
+	if (c.t == QueryType_GREATER_EQUAL && d.min(v)) || (c.t == QueryType_LESS_EQUAL && d.max(v)) {
+		return condition[T]{valid: true, negation: true, t: QueryType_NULL}
+	}
+	return c
+}
+
+// normalizeBetween expects between with exactly two values.
+func normalizeBetween[T any](c condition[T], d domain[T]) condition[T] {
+	lo, hi := c.values[0], c.values[1]
+	le, ue := c.lowerExclusive, c.upperExclusive
+	if d.compare(lo, hi) > 0 {
+		lo, hi, le, ue = hi, lo, ue, le
+	}
+	if d.discrete() && (le || ue) {
+		nlo, nhi, ok := lo, hi, true
+		if le {
+			nlo, ok = d.next(lo)
+		}
+		if ue && ok {
+			nhi, ok = d.prev(hi)
+		}
+		if ok && d.compare(nlo, nhi) <= 0 {
+			lo, hi, le, ue = nlo, nhi, false, false
+		}
+	}
+
+	switch {
+	case !le && !ue && d.compare(lo, hi) == 0:
+		return condition[T]{values: []T{lo}, valid: true, negation: c.negation, t: QueryType_EQUAL}
+	case !le && d.min(lo) && !ue && d.max(hi) && !c.negation:
+		return condition[T]{valid: true, negation: true, t: QueryType_NULL}
+	case !le && d.min(lo):
+		return normalize(condition[T]{values: []T{hi}, valid: true, negation: c.negation, t: unboundedType(true, le, ue)}, d)
+	case !ue && d.max(hi):
+		return normalize(condition[T]{values: []T{lo}, valid: true, negation: c.negation, t: unboundedType(false, le, ue)}, d)
+	}
+	return condition[T]{
+		values:         []T{lo, hi},
+		valid:          true,
+		negation:       c.negation,
+		t:              QueryType_BETWEEN,
+		lowerExclusive: le,
+		upperExclusive: ue,
+	}
+}
+
+// complementComparison returns comparison that matches every not null value that given one does not.
+func complementComparison(t QueryType) QueryType {
+	switch t {
+	case QueryType_GREATER:
+		return QueryType_LESS_EQUAL
+	case QueryType_GREATER_EQUAL:
+		return QueryType_LESS
+	case QueryType_LESS:
+		return QueryType_GREATER_EQUAL
+	case QueryType_LESS_EQUAL:
+		return QueryType_GREATER
+	default:
+		return t
+	}
+}
@@ -0,0 +1,251 @@
+package qtypes
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestInt64_Normalize(t *testing.T) {
+	cases := map[string]struct {
+		given, expected *Int64
+	}{
+		"nil": {
+			given:    nil,
+			expected: nil,
+		},
+		"invalid": {
+			given:    &Int64{Values: []int64{1}, Type: QueryType_IN},
+			expected: &Int64{},
+		},
+		"null": {
+			given:    &Int64{Values: []int64{1}, Valid: true, Type: QueryType_NULL, LowerExclusive: true},
+			expected: NullInt64(),
+		},
+		"in-single-value": {
+			given:    InInt64(1),
+			expected: EqualInt64(1),
+		},
+		"not-in-single-value": {
+			given:    &Int64{Values: []int64{1}, Valid: true, Negation: true, Type: QueryType_IN},
+			expected: NotEqualInt64(1),
+		},
+		"in-unsorted-with-duplicates": {
+			given:    InInt64(3, 1, 2, 3, 1),
+			expected: InInt64(1, 2, 3),
+		},
+		"not-greater": {
+			given:    &Int64{Values: []int64{5}, Valid: true, Negation: true, Type: QueryType_GREATER},
+			expected: LessEqualInt64(5),
+		},
+		"not-less-equal": {
+			given:    &Int64{Values: []int64{5}, Valid: true, Negation: true, Type: QueryType_LESS_EQUAL},
+			expected: GreaterEqualInt64(6),
+		},
+		"greater": {
+			given:    GreaterInt64(5),
+			expected: GreaterEqualInt64(6),
+		},
+		"less": {
+			given:    LessInt64(5),
+			expected: LessEqualInt64(4),
+		},
+		"greater-max": {
+			given:    GreaterInt64(math.MaxInt64),
+			expected: GreaterInt64(math.MaxInt64),
+		},
+		"greater-equal-min": {
+			given:    GreaterEqualInt64(math.MinInt64),
+			expected: &Int64{Valid: true, Negation: true, Type: QueryType_NULL},
+		},
+		"between-swapped": {
+			given:    BetweenInt64(5, 1),
+			expected: BetweenInt64(1, 5),
+		},
+		"between-swapped-half-open": {
+			given:    &Int64{Values: []int64{5, 1}, Valid: true, Type: QueryType_BETWEEN, UpperExclusive: true},
+			expected: BetweenInt64(2, 5),
+		},
+		"between-open": {
+			given:    RangeInt64(1, 5, true, true),
+			expected: BetweenInt64(2, 4),
+		},
+		"between-open-empty": {
+			given:    &Int64{Values: []int64{1, 2}, Valid: true, Type: QueryType_BETWEEN, LowerExclusive: true, UpperExclusive: true},
+			expected: &Int64{Values: []int64{1, 2}, Valid: true, Type: QueryType_BETWEEN, LowerExclusive: true, UpperExclusive: true},
+		},
+		"between-single-value": {
+			given:    &Int64{Values: []int64{5, 5}, Valid: true, Negation: true, Type: QueryType_BETWEEN},
+			expected: NotEqualInt64(5),
+		},
+		"between-without-lower-bound": {
+			given:    &Int64{Values: []int64{math.MinInt64, 5}, Valid: true, Negation: true, Type: QueryType_BETWEEN},
+			expected: GreaterEqualInt64(6),
+		},
+		"contains": {
+			given:    &Int64{Values: []int64{2, 1, 2}, Valid: true, Type: QueryType_CONTAINS},
+			expected: &Int64{Values: []int64{1, 2}, Valid: true, Type: QueryType_CONTAINS},
+		},
+		"equal-exclusive": {
+			given:    &Int64{Values: []int64{1}, Valid: true, Type: QueryType_EQUAL, UpperExclusive: true},
+			expected: EqualInt64(1),
+		},
+	}
+
+	for hint, c := range cases {
+		got := c.given.Normalize()
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestInt64_Normalize_immutable(t *testing.T) {
+	given := InInt64(3, 1, 2)
+	_ = given.Normalize()
+	if !proto.Equal(given, InInt64(3, 1, 2)) {
+		t.Errorf("receiver has been modified: %v", given)
+	}
+}
+
+func TestUint64_Normalize(t *testing.T) {
+	cases := map[string]struct {
+		given, expected *Uint64
+	}{
+		"between-from-zero": {
+			given:    BetweenUint64(0, 5),
+			expected: &Uint64{Values: []uint64{5}, Valid: true, Type: QueryType_LESS_EQUAL},
+		},
+		"less-zero": {
+			given:    &Uint64{Values: []uint64{0}, Valid: true, Type: QueryType_LESS},
+			expected: &Uint64{Values: []uint64{0}, Valid: true, Type: QueryType_LESS},
+		},
+		"not-greater-equal": {
+			given:    &Uint64{Values: []uint64{1}, Valid: true, Negation: true, Type: QueryType_GREATER_EQUAL},
+			expected: &Uint64{Values: []uint64{0}, Valid: true, Type: QueryType_LESS_EQUAL},
+		},
+	}
+
+	for hint, c := range cases {
+		got := c.given.Normalize()
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestFloat64_Normalize(t *testing.T) {
+	cases := map[string]struct {
+		given, expected *Float64
+	}{
+		"greater": {
+			given:    &Float64{Values: []float64{1.5}, Valid: true, Type: QueryType_GREATER},
+			expected: &Float64{Values: []float64{1.5}, Valid: true, Type: QueryType_GREATER},
+		},
+		"not-greater": {
+			given:    &Float64{Values: []float64{1.5}, Valid: true, Negation: true, Type: QueryType_GREATER},
+			expected: &Float64{Values: []float64{1.5}, Valid: true, Type: QueryType_LESS_EQUAL},
+		},
+		"between-swapped": {
+			given:    &Float64{Values: []float64{2.5, 1.5}, Valid: true, Type: QueryType_BETWEEN, LowerExclusive: true},
+			expected: &Float64{Values: []float64{1.5, 2.5}, Valid: true, Type: QueryType_BETWEEN, UpperExclusive: true},
+		},
+		"between-infinite": {
+			given:    &Float64{Values: []float64{math.Inf(-1), math.Inf(1)}, Valid: true, Type: QueryType_BETWEEN},
+			expected: &Float64{Valid: true, Negation: true, Type: QueryType_NULL},
+		},
+		"in": {
+			given:    &Float64{Values: []float64{2.5, 1.5, 2.5}, Valid: true, Type: QueryType_IN},
+			expected: &Float64{Values: []float64{1.5, 2.5}, Valid: true, Type: QueryType_IN},
+		},
+	}
+
+	for hint, c := range cases {
+		got := c.given.Normalize()
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestTimestamp_Normalize(t *testing.T) {
+	a := &knowntimestamp.Timestamp{Seconds: 1}
+	b := &knowntimestamp.Timestamp{Seconds: 1, Nanos: 1}
+	c := &knowntimestamp.Timestamp{Seconds: 2}
+
+	cases := map[string]struct {
+		given, expected *Timestamp
+	}{
+		"in": {
+			given:    &Timestamp{Values: []*knowntimestamp.Timestamp{c, a, b, a}, Valid: true, Type: QueryType_IN},
+			expected: &Timestamp{Values: []*knowntimestamp.Timestamp{a, b, c}, Valid: true, Type: QueryType_IN},
+		},
+		"between-swapped": {
+			given:    &Timestamp{Values: []*knowntimestamp.Timestamp{c, a}, Valid: true, Type: QueryType_BETWEEN, LowerExclusive: true},
+			expected: RangeTimestamp(a, c, false, true),
+		},
+		"not-less": {
+			given:    &Timestamp{Values: []*knowntimestamp.Timestamp{a}, Valid: true, Negation: true, Type: QueryType_LESS},
+			expected: &Timestamp{Values: []*knowntimestamp.Timestamp{a}, Valid: true, Type: QueryType_GREATER_EQUAL},
+		},
+		"nil-value": {
+			given:    &Timestamp{Values: []*knowntimestamp.Timestamp{c, nil}, Valid: true, Type: QueryType_IN},
+			expected: &Timestamp{Values: []*knowntimestamp.Timestamp{c, nil}, Valid: true, Type: QueryType_IN},
+		},
+	}
+
+	for hint, c := range cases {
+		got := c.given.Normalize()
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestString_Normalize(t *testing.T) {
+	cases := map[string]struct {
+		given, expected *String
+	}{
+		"invalid": {
+			given:    &String{Values: []string{"a"}},
+			expected: &String{},
+		},
+		"null": {
+			given:    &String{Values: []string{""}, Valid: true, Type: QueryType_NULL, Insensitive: true},
+			expected: NullString(),
+		},
+		"in-single-value": {
+			given:    &String{Values: []string{"a", "a"}, Valid: true, Negation: true, Type: QueryType_IN},
+			expected: &String{Values: []string{"a"}, Valid: true, Negation: true, Type: QueryType_EQUAL},
+		},
+		"in": {
+			given:    &String{Values: []string{"b", "a", "b"}, Valid: true, Type: QueryType_IN},
+			expected: &String{Values: []string{"a", "b"}, Valid: true, Type: QueryType_IN},
+		},
+		"has-prefix": {
+			given:    &String{Values: []string{"a"}, Valid: true, Type: QueryType_HAS_PREFIX, Insensitive: true},
+			expected: &String{Values: []string{"a"}, Valid: true, Type: QueryType_HAS_PREFIX, Insensitive: true},
+		},
+		"not-min-length": {
+			given:    &String{Values: []string{"05"}, Valid: true, Negation: true, Type: QueryType_MIN_LENGTH, Insensitive: true},
+			expected: &String{Values: []string{"4"}, Valid: true, Type: QueryType_MAX_LENGTH},
+		},
+		"not-max-length": {
+			given:    &String{Values: []string{"5"}, Valid: true, Negation: true, Type: QueryType_MAX_LENGTH},
+			expected: &String{Values: []string{"6"}, Valid: true, Type: QueryType_MIN_LENGTH},
+		},
+		"not-min-length-zero": {
+			given:    &String{Values: []string{"0"}, Valid: true, Negation: true, Type: QueryType_MIN_LENGTH},
+			expected: &String{Values: []string{"0"}, Valid: true, Negation: true, Type: QueryType_MIN_LENGTH},
+		},
+	}
+
+	for hint, c := range cases {
+		got := c.given.Normalize()
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
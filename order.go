@@ -0,0 +1,91 @@
+package qtypes
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// StringOrder defines ordering of strings that range conditions (GREATER, GREATER_EQUAL, LESS, LESS_EQUAL and BETWEEN) follow.
+// It returns a negative number if a is less than b, zero if they are equal and a positive number if a is greater than b.
+type StringOrder func(a, b string) int
+
+// ByteOrder compares strings byte-wise, which for valid UTF-8 is the order of code points.
+// It corresponds to COLLATE "C" in PostgreSQL and binary collations in MySQL, and it is the default ordering of this package.
+var ByteOrder StringOrder = strings.Compare
+
+// Collation returns StringOrder that follows linguistic rules of given language, e.g. "ą" goes between "a" and "b" in Polish.
+// It should match a database collation of the same language, e.g. COLLATE "pl-PL-x-icu" in PostgreSQL.
+// Returned function is safe for concurrent use.
+func Collation(tag language.Tag, opts ...collate.Option) StringOrder {
+	pool := sync.Pool{
+		New: func() interface{} {
+			return collate.New(tag, opts...)
+		},
+	}
+	return func(a, b string) int {
+		c := pool.Get().(*collate.Collator)
+		defer pool.Put(c)
+		return c.CompareString(a, b)
+	}
+}
+
+// MatchStringRange reports whether not null value satisfies given range condition, using given order.
+// Case insensitive conditions are folded using DefaultFolding, see Folding.MatchRange.
+func MatchStringRange(s *String, v string, order StringOrder) (bool, error) {
+	return defaultFolding.MatchRange(s, v, order)
+}
+
+// MatchRange reports whether not null value satisfies given range condition, using given order.
+// If the condition is case insensitive, both sides are folded before comparison.
+// BETWEEN includes both of its values, e.g. "f" but not "fox" is between "a" and "f".
+func (f Folding) MatchRange(s *String, v string, order StringOrder) (bool, error) {
+	if !s.GetValid() {
+		return true, nil
+	}
+	if order == nil {
+		order = ByteOrder
+	}
+
+	values := s.Values
+	if s.Insensitive {
+		v = f.Fold(v)
+		values = make([]string, 0, len(s.Values))
+		for _, w := range s.Values {
+			values = append(values, f.Fold(w))
+		}
+	}
+
+	var ok bool
+	switch s.Type {
+	case QueryType_GREATER, QueryType_GREATER_EQUAL, QueryType_LESS, QueryType_LESS_EQUAL:
+		if len(values) != 1 {
+			return false, fmt.Errorf("qtypes: %s condition requires exactly one value", s.Type)
+		}
+		cmp := order(v, values[0])
+		switch s.Type {
+		case QueryType_GREATER:
+			ok = cmp > 0
+		case QueryType_GREATER_EQUAL:
+			ok = cmp >= 0
+		case QueryType_LESS:
+			ok = cmp < 0
+		default:
+			ok = cmp <= 0
+		}
+	case QueryType_BETWEEN:
+		if len(values) != 2 {
+			return false, fmt.Errorf("qtypes: %s condition requires exactly two values", s.Type)
+		}
+		ok = order(v, values[0]) >= 0 && order(v, values[1]) <= 0
+	default:
+		return false, fmt.Errorf("qtypes: %s is not a range condition", s.Type)
+	}
+	if s.Negation {
+		return !ok, nil
+	}
+	return ok, nil
+}
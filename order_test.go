@@ -0,0 +1,96 @@
+package qtypes
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func TestMatchStringRange(t *testing.T) {
+	polish := Collation(language.Polish)
+
+	cases := map[string]struct {
+		given    *String
+		value    string
+		order    StringOrder
+		expected bool
+	}{
+		"greater":                {given: GreaterString("b"), value: "ba", expected: true},
+		"greater-equal-value":    {given: GreaterString("b"), value: "b", expected: false},
+		"greater-equal":          {given: GreaterEqualString("b"), value: "b", expected: true},
+		"less":                   {given: LessString("b"), value: "a", expected: true},
+		"less-prefix":            {given: LessString("b"), value: "", expected: true},
+		"less-equal":             {given: LessEqualString("b"), value: "ba", expected: false},
+		"between":                {given: BetweenString("a", "f"), value: "f", expected: true},
+		"between-longer":         {given: BetweenString("a", "f"), value: "fox", expected: false},
+		"not-between":            {given: &String{Values: []string{"a", "f"}, Valid: true, Negation: true, Type: QueryType_BETWEEN}, value: "fox", expected: true},
+		"byte-order-uppercase":   {given: GreaterString("a"), value: "B", expected: false},
+		"insensitive":            {given: &String{Values: []string{"a"}, Valid: true, Type: QueryType_GREATER, Insensitive: true}, value: "B", expected: true},
+		"byte-order-diacritic":   {given: LessString("b"), value: "ą", expected: false},
+		"collation-diacritic":    {given: LessString("b"), value: "ą", order: polish, expected: true},
+		"collation-uppercase":    {given: GreaterString("a"), value: "B", order: polish, expected: true},
+		"collation-between":      {given: BetweenString("a", "c"), value: "ć", order: polish, expected: false},
+		"collation-ignore-case":  {given: GreaterEqualString("abc"), value: "ABC", order: Collation(language.English, collate.IgnoreCase), expected: true},
+		"invalid":                {given: &String{Values: []string{"a"}, Type: QueryType_GREATER}, value: "", expected: true},
+		"negated-less-or-equal":  {given: &String{Values: []string{"m"}, Valid: true, Negation: true, Type: QueryType_LESS_EQUAL}, value: "n", expected: true},
+		"negated-greater-or-not": {given: &String{Values: []string{"m"}, Valid: true, Negation: true, Type: QueryType_GREATER}, value: "n", expected: false},
+	}
+
+	for hint, c := range cases {
+		got, err := MatchStringRange(c.given, c.value, c.order)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestMatchStringRange_error(t *testing.T) {
+	cases := map[string]*String{
+		"not-range":       EqualString("a"),
+		"too-many-values": {Values: []string{"a", "b"}, Valid: true, Type: QueryType_GREATER},
+		"too-few-values":  {Values: []string{"a"}, Valid: true, Type: QueryType_BETWEEN},
+	}
+
+	for hint, given := range cases {
+		if _, err := MatchStringRange(given, "a", nil); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
+
+func TestCollation(t *testing.T) {
+	order := Collation(language.Polish)
+	given := []string{"ćma", "zebra", "Ala", "ala", "łódź", "lody", "żaba", "ąb"}
+	expected := []string{"ala", "Ala", "ąb", "ćma", "lody", "łódź", "zebra", "żaba"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got := slices.Clone(given)
+			slices.SortFunc(got, order)
+			if !slices.Equal(got, expected) {
+				t.Errorf("wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", expected, got)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestIntersectString_range(t *testing.T) {
+	got, err := IntersectString(inString("adam", "ewa", "zenon"), BetweenString("a", "f"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := inString("adam", "ewa"); !EquivalentString(got, expected) {
+		t.Errorf("wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", expected, got)
+	}
+}
@@ -0,0 +1,276 @@
+package qtypes
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// ErrUnsupportedPattern is wrapped by errors returned when a pattern is not part of the portable subset.
+var ErrUnsupportedPattern = errors.New("qtypes: unsupported pattern")
+
+// PatternError is returned when a pattern is not part of the portable subset. It wraps ErrUnsupportedPattern.
+type PatternError struct {
+	Pattern string
+	Reason  string
+}
+
+// Error implements error interface.
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("qtypes: pattern %q is not supported: %s", e.Pattern, e.Reason)
+}
+
+// Unwrap returns ErrUnsupportedPattern.
+func (e *PatternError) Unwrap() error {
+	return ErrUnsupportedPattern
+}
+
+// Dialect is a regular expression syntax that a pattern can be translated to.
+type Dialect int
+
+const (
+	// DialectGo is the syntax of regexp package, used for in-memory matching.
+	DialectGo Dialect = iota
+	// DialectPOSIX is the POSIX extended regular expression syntax, e.g. for PostgreSQL ~ and ~* operators.
+	DialectPOSIX
+)
+
+// posixEscapes translates class escapes to POSIX bracket expressions of ASCII characters,
+// as PostgreSQL applies locale rules to named classes, e.g. [[:alpha:]] matches é.
+var posixEscapes = map[byte]string{
+	'd': "[0-9]",
+	'D': "[^0-9]",
+	's': "[\t\n\f\r ]",
+	'S': "[^\t\n\f\r ]",
+	'w': "[0-9A-Za-z_]",
+	'W': "[^0-9A-Za-z_]",
+}
+
+// posixClasses translates named classes within bracket expressions to ASCII ranges, for the same reason.
+// NUL is left out, as PostgreSQL text cannot contain it.
+var posixClasses = strings.NewReplacer(
+	"[:alnum:]", "0-9A-Za-z",
+	"[:alpha:]", "A-Za-z",
+	"[:ascii:]", "\x01-\x7f",
+	"[:blank:]", " \t",
+	"[:cntrl:]", "\x01-\x1f\x7f",
+	"[:digit:]", "0-9",
+	"[:graph:]", "!-~",
+	"[:lower:]", "a-z",
+	"[:print:]", " -~",
+	"[:punct:]", "!-/:-@[-`{-~",
+	"[:space:]", "\t\n\v\f\r ",
+	"[:upper:]", "A-Z",
+	"[:word:]", "0-9A-Za-z_",
+	"[:xdigit:]", "0-9A-Fa-f",
+)
+
+// posixSpecial lists characters that have to be escaped outside of bracket expressions in POSIX syntax.
+const posixSpecial = `.[]\()*+?{}|^$`
+
+// ValidatePattern returns *PatternError if given PATTERN value is not part of the portable subset,
+// that has the same meaning in every supported dialect. The subset consists of:
+//   - literal characters and punctuation escaped with a backslash, e.g. \. or \{,
+//   - any character ., which matches a new line as well,
+//   - bracket expressions without escapes, e.g. [a-z], [^0-9], []a] or [[:alpha:]],
+//     but without negated classes [:^alpha:], collating elements [.a.] and equivalence classes [=a=],
+//   - character classes \d, \s, \w and their negations \D, \S, \W, outside of bracket expressions,
+//   - anchors ^ and $, that match only at the beginning and at the end of the text,
+//   - greedy repetitions *, +, ?, {n}, {n,} and {n,m}, up to 1000 times,
+//   - groups (...) and alternations |.
+//
+// Classes, both escapes and named ones like [:alpha:], match ASCII characters only, e.g. \w does not match é.
+// They are translated to explicit ranges for dialects that apply locale rules to them.
+//
+// Anything else, e.g. flags, non-capturing groups, non-greedy repetitions, word boundaries,
+// back references or look-arounds, is rejected.
+// Case insensitive matching is expressed by the Insensitive field of String, not within the pattern.
+func ValidatePattern(expr string) error {
+	_, err := parsePattern(expr)
+	return err
+}
+
+// TranslatePattern returns given PATTERN value in the syntax of given dialect.
+// The pattern is validated first, see ValidatePattern.
+func TranslatePattern(expr string, d Dialect) (string, error) {
+	if _, err := parsePattern(expr); err != nil {
+		return "", err
+	}
+
+	switch d {
+	case DialectGo:
+		return "(?s)" + expr, nil
+	case DialectPOSIX:
+		var b strings.Builder
+		var bracket bool
+		for i := 0; i < len(expr); i++ {
+			c := expr[i]
+			switch {
+			case bracket:
+				i = scanBracket(expr, i, &b)
+				bracket = false
+			case c == '[':
+				b.WriteByte(c)
+				bracket = true
+			case c == '\\':
+				i++
+				if s, ok := posixEscapes[expr[i]]; ok {
+					b.WriteString(s)
+				} else if strings.IndexByte(posixSpecial, expr[i]) >= 0 {
+					b.WriteByte('\\')
+					b.WriteByte(expr[i])
+				} else {
+					b.WriteByte(expr[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return b.String(), nil
+	default:
+		return "", fmt.Errorf("qtypes: unknown dialect %d", d)
+	}
+}
+
+// CompilePattern compiles given PATTERN value for in-memory matching.
+// The pattern is validated first, see ValidatePattern.
+func CompilePattern(expr string, insensitive bool) (*regexp.Regexp, error) {
+	expr, err := TranslatePattern(expr, DialectGo)
+	if err != nil {
+		return nil, err
+	}
+	if insensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+func parsePattern(expr string) (*syntax.Regexp, error) {
+	unsupported := func(format string, args ...interface{}) error {
+		return &PatternError{Pattern: expr, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '[':
+			j := scanBracket(expr, i+1, nil)
+			if j >= len(expr) {
+				return nil, unsupported("missing closing ]")
+			}
+			if k := strings.IndexByte(expr[i:j], '\\'); k >= 0 {
+				return nil, unsupported("escape sequence within bracket expression at position %d", i+k)
+			}
+			// Go reads [. and [= literally, while POSIX reads them as collating elements and equivalence classes.
+			for _, s := range []string{"[:^", "[.", "[="} {
+				if k := strings.Index(expr[i+1:j], s); k >= 0 {
+					return nil, unsupported("%s within bracket expression at position %d", s, i+1+k)
+				}
+			}
+			i = j
+		case '\\':
+			if i+1 == len(expr) {
+				return nil, unsupported("trailing backslash")
+			}
+			i++
+			c := expr[i]
+			if _, ok := posixEscapes[c]; !ok && (c >= 0x80 || isAlnum(c)) {
+				return nil, unsupported("escape sequence \\%c", c)
+			}
+		case '(':
+			if i+1 < len(expr) && expr[i+1] == '?' {
+				return nil, unsupported("flags and non-capturing groups")
+			}
+		case '{':
+			if !isRepetition(expr[i:]) {
+				return nil, unsupported("unescaped { at position %d", i)
+			}
+		}
+	}
+
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		var serr *syntax.Error
+		if errors.As(err, &serr) {
+			return nil, unsupported("%s", serr.Code)
+		}
+		return nil, unsupported("%s", err)
+	}
+	if err := walkPattern(re, func(re *syntax.Regexp) error {
+		switch re.Op {
+		case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+			if re.Flags&syntax.NonGreedy != 0 {
+				return unsupported("non-greedy repetition")
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return re, nil
+}
+
+func walkPattern(re *syntax.Regexp, fn func(*syntax.Regexp) error) error {
+	if err := fn(re); err != nil {
+		return err
+	}
+	for _, sub := range re.Sub {
+		if err := walkPattern(sub, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanBracket returns position of the end of bracket expression that content starts at i.
+// If b is not nil, the content, including the closing bracket, is written to it with named classes translated to ASCII ranges.
+func scanBracket(expr string, i int, b *strings.Builder) int {
+	start := i
+	if i < len(expr) && expr[i] == '^' {
+		i++
+	}
+	if i < len(expr) && expr[i] == ']' {
+		i++
+	}
+	for ; i < len(expr) && expr[i] != ']'; i++ {
+		if strings.HasPrefix(expr[i:], "[:") {
+			if j := strings.Index(expr[i+2:], ":]"); j >= 0 {
+				i += j + 3
+			}
+		}
+	}
+	if b != nil && i < len(expr) {
+		b.WriteString(posixClasses.Replace(expr[start : i+1]))
+	}
+	return i
+}
+
+// isRepetition reports whether s starts with {n}, {n,} or {n,m}.
+func isRepetition(s string) bool {
+	end := strings.IndexByte(s, '}')
+	if end < 0 {
+		return false
+	}
+	lo, hi, comma := strings.Cut(s[1:end], ",")
+	if !isDigits(lo) {
+		return false
+	}
+	return !comma || hi == "" || isDigits(hi)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
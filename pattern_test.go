@@ -0,0 +1,151 @@
+package qtypes
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestValidatePattern(t *testing.T) {
+	supported := []string{
+		"",
+		"abc",
+		"^a.c$",
+		`a\.b\(c\)\{1\}`,
+		"[a-z]+[^0-9]*",
+		"[]a]",
+		"[^]a-]",
+		"[[:alpha:]_]{2,}",
+		`\d{3}-\w+\s?\D\S\W`,
+		"(a|b)?c{1,3}",
+		"a{0}",
+	}
+	for _, given := range supported {
+		if err := ValidatePattern(given); err != nil {
+			t.Errorf("%q: unexpected error: %s", given, err.Error())
+		}
+	}
+
+	unsupported := map[string]string{
+		"flags":                 "(?i)abc",
+		"non-capturing-group":   "(?:a|b)",
+		"named-group":           "(?P<name>a)",
+		"non-greedy":            "a+?",
+		"non-greedy-repetition": "a{1,2}?",
+		"word-boundary":         `\bword\b`,
+		"begin-of-text":         `\Aabc`,
+		"unicode-class":         `\pL`,
+		"hex-escape":            `\x41`,
+		"quoted":                `\Qa.b\E`,
+		"escape-in-bracket":     `[\d]`,
+		"literal-brace":         "a{",
+		"literal-brace-text":    "{a}",
+		"trailing-backslash":    `a\`,
+		"missing-paren":         "(a",
+		"missing-bracket":       "[a",
+		"missing-argument":      "*a",
+		"too-many-repetitions":  "a{1001}",
+		"back-reference":        `(a)\1`,
+		"negated-class":         "[[:^alpha:]]",
+		"collating-element":     "[[.a.]]",
+		"equivalence-class":     "[[=a=]]",
+		"bracket-dot":           "[a[.]",
+	}
+	for hint, given := range unsupported {
+		err := ValidatePattern(given)
+		var patternErr *PatternError
+		if !errors.As(err, &patternErr) || !errors.Is(err, ErrUnsupportedPattern) {
+			t.Errorf("%s: expected pattern error, got %v", hint, err)
+			continue
+		}
+		if patternErr.Pattern != given {
+			t.Errorf("%s: wrong pattern, expected %q but got %q", hint, given, patternErr.Pattern)
+		}
+	}
+}
+
+func TestTranslatePattern(t *testing.T) {
+	cases := map[string]struct {
+		given    string
+		dialect  Dialect
+		expected string
+	}{
+		"go":             {given: `^a\.b\d+$`, dialect: DialectGo, expected: `(?s)^a\.b\d+$`},
+		"posix":          {given: "^(a|b)[[:alpha:]]{1,3}$", dialect: DialectPOSIX, expected: "^(a|b)[A-Za-z]{1,3}$"},
+		"posix-classes":  {given: `\d\D\s\S\w\W`, dialect: DialectPOSIX, expected: "[0-9][^0-9][\t\n\f\r ][^\t\n\f\r ][0-9A-Za-z_][^0-9A-Za-z_]"},
+		"posix-escapes":  {given: `\.\-\/\]`, dialect: DialectPOSIX, expected: `\.-/\]`},
+		"posix-brackets": {given: `[]a][^a-]\d`, dialect: DialectPOSIX, expected: `[]a][^a-][0-9]`},
+		"posix-named":    {given: "[^[:alnum:]_][[:punct:][:space:]]", dialect: DialectPOSIX, expected: "[^0-9A-Za-z_][!-/:-@[-`{-~\t\n\v\f\r ]"},
+	}
+
+	for hint, c := range cases {
+		got, err := TranslatePattern(c.given, c.dialect)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestTranslatePattern_ascii(t *testing.T) {
+	classes := []string{"alnum", "alpha", "ascii", "blank", "cntrl", "digit", "graph", "lower", "print", "punct", "space", "upper", "word", "xdigit"}
+	values := []string{"a", "Z", "5", "_", "-", "[", "]", "^", "`", "\\", " ", "\t", "\v", "\x01", "\x7f", "é", "\u00a0", "٣"}
+
+	for _, class := range classes {
+		for _, pattern := range []string{"^[[:" + class + ":]]$", "^[^[:" + class + ":]]$"} {
+			expr, err := TranslatePattern(pattern, DialectPOSIX)
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", pattern, err.Error())
+				continue
+			}
+			// Explicit ranges mean the same in POSIX syntax of regexp package, that does not apply any locale rules.
+			posix := regexp.MustCompilePOSIX(expr)
+			re, _ := CompilePattern(pattern, false)
+			for _, v := range values {
+				if expected := re.MatchString(v); posix.MatchString(v) != expected {
+					t.Errorf("%s: wrong output for value %q of expression %q, expected %t", pattern, v, expr, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestTranslatePattern_unsupported(t *testing.T) {
+	if _, err := TranslatePattern("a+?", DialectPOSIX); !errors.Is(err, ErrUnsupportedPattern) {
+		t.Errorf("expected pattern error, got %v", err)
+	}
+}
+
+func TestCompilePattern(t *testing.T) {
+	cases := map[string]struct {
+		given       string
+		insensitive bool
+		value       string
+		expected    bool
+	}{
+		"match":             {given: "^a.c$", value: "abc", expected: true},
+		"new-line":          {given: "^a.c$", value: "a\nc", expected: true},
+		"end-of-text":       {given: "a$", value: "a\n", expected: false},
+		"sensitive":         {given: "^abc$", value: "ABC", expected: false},
+		"insensitive":       {given: "^abc$", insensitive: true, value: "ABC", expected: true},
+		"insensitive-class": {given: "^[a-c]+$", insensitive: true, value: "AbC", expected: true},
+		"digits":            {given: `^\d{3}$`, value: "123", expected: true},
+		"digits-too-short":  {given: `^\d{3}$`, value: "12", expected: false},
+		"word-non-ascii":    {given: `^\w$`, value: "é", expected: false},
+		"alnum-non-ascii":   {given: "^[[:alnum:]]$", insensitive: true, value: "É", expected: false},
+	}
+
+	for hint, c := range cases {
+		re, err := CompilePattern(c.given, c.insensitive)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got := re.MatchString(c.value); got != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
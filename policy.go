@@ -0,0 +1,177 @@
+package qtypes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrNotAllowed is wrapped by errors returned when a condition is not allowed by a policy.
+var ErrNotAllowed = errors.New("qtypes: condition not allowed")
+
+// Condition is implemented by Int64, Uint64, Float64, Timestamp and String.
+type Condition interface {
+	proto.Message
+	GetValid() bool
+	GetNegation() bool
+	GetType() QueryType
+}
+
+// FieldPolicy describes which conditions are allowed for a field.
+type FieldPolicy struct {
+	// Types lists query types that are allowed.
+	Types []QueryType
+	// Negation allows negated conditions, e.g. NOT EQUAL or NOT NULL.
+	Negation bool
+	// Insensitive allows case insensitive conditions.
+	Insensitive bool
+}
+
+// Policy is an allowlist of conditions, per field.
+// Invalid conditions do not filter anything, so they are always allowed.
+// Any valid condition on a field that is not listed is not allowed.
+type Policy map[string]FieldPolicy
+
+// PolicyViolation is returned when condition is not allowed by a policy. It wraps ErrNotAllowed.
+type PolicyViolation struct {
+	Field       string
+	Type        QueryType
+	Negation    bool
+	Insensitive bool
+}
+
+// Error implements error interface.
+func (v *PolicyViolation) Error() string {
+	op := v.Type.String()
+	if v.Insensitive {
+		op = "case insensitive " + op
+	}
+	if v.Negation {
+		op = "negated " + op
+	}
+	return fmt.Sprintf("qtypes: %s condition is not allowed for field %q", op, v.Field)
+}
+
+// Unwrap returns ErrNotAllowed.
+func (v *PolicyViolation) Unwrap() error {
+	return ErrNotAllowed
+}
+
+// Check returns *PolicyViolation if given condition is not allowed for given field.
+func (p Policy) Check(field string, c Condition) error {
+	if c == nil || !c.GetValid() {
+		return nil
+	}
+	var insensitive bool
+	if i, ok := c.(interface{ GetInsensitive() bool }); ok {
+		insensitive = i.GetInsensitive()
+	}
+
+	fp, ok := p[field]
+	if !ok ||
+		!slices.Contains(fp.Types, c.GetType()) ||
+		(c.GetNegation() && !fp.Negation) ||
+		(insensitive && !fp.Insensitive) {
+		return &PolicyViolation{
+			Field:       field,
+			Type:        c.GetType(),
+			Negation:    c.GetNegation(),
+			Insensitive: insensitive,
+		}
+	}
+	return nil
+}
+
+// CheckMessage walks through given message (e.g. a gRPC request) and checks every condition it contains.
+// Conditions are identified by the path of proto field names, separated by dots, e.g. "filter.age".
+// Repeated fields and map values share the path of the field.
+// The first violation found is returned.
+func (p Policy) CheckMessage(m proto.Message) error {
+	if m == nil {
+		return nil
+	}
+	return walkConditions(m.ProtoReflect(), "", p.Check)
+}
+
+// walkConditions calls fn for every condition that given message contains.
+func walkConditions(m protoreflect.Message, path string, fn func(field string, c Condition) error) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil {
+			return true
+		}
+		name := string(fd.Name())
+		if path != "" {
+			name = path + "." + name
+		}
+
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				err = walkValue(list.Get(i).Message(), name, fn)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				err = walkValue(mv.Message(), name, fn)
+				return err == nil
+			})
+		default:
+			err = walkValue(v.Message(), name, fn)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func walkValue(m protoreflect.Message, path string, fn func(field string, c Condition) error) error {
+	c, err := conditionOf(m)
+	if err != nil {
+		return fmt.Errorf("qtypes: condition at %q: %w", path, err)
+	}
+	if c != nil {
+		return fn(path, c)
+	}
+	return walkConditions(m, path, fn)
+}
+
+// conditionOf returns nil if given message is not a condition.
+// Conditions that are not represented by types of this package (e.g. dynamic messages) are converted.
+func conditionOf(m protoreflect.Message) (Condition, error) {
+	if m.Descriptor().ParentFile().Package() != File_qtypes_proto.Package() {
+		return nil, nil
+	}
+	var c Condition
+	switch m.Descriptor().Name() {
+	case "Int64":
+		c = &Int64{}
+	case "Uint64":
+		c = &Uint64{}
+	case "Float64":
+		c = &Float64{}
+	case "Timestamp":
+		c = &Timestamp{}
+	case "String":
+		c = &String{}
+	default:
+		return nil, nil
+	}
+	if cc, ok := m.Interface().(Condition); ok {
+		return cc, nil
+	}
+
+	b, err := proto.Marshal(m.Interface())
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,212 @@
+package qtypes
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var testPolicy = Policy{
+	"age": {
+		Types:    []QueryType{QueryType_EQUAL, QueryType_BETWEEN, QueryType_NULL},
+		Negation: true,
+	},
+	"name": {
+		Types:       []QueryType{QueryType_EQUAL, QueryType_HAS_PREFIX},
+		Insensitive: true,
+	},
+}
+
+func TestPolicy_Check(t *testing.T) {
+	cases := map[string]struct {
+		field string
+		given Condition
+		ok    bool
+	}{
+		"allowed":              {field: "age", given: BetweenInt64(1, 2), ok: true},
+		"allowed-negation":     {field: "age", given: NotEqualInt64(1), ok: true},
+		"type-not-allowed":     {field: "age", given: GreaterInt64(1)},
+		"invalid":              {field: "age", given: &Int64{Type: QueryType_GREATER}, ok: true},
+		"nil":                  {field: "age", given: nil, ok: true},
+		"unknown-field":        {field: "description", given: EqualString("a")},
+		"unknown-field-nil":    {field: "description", given: (*String)(nil), ok: true},
+		"allowed-insensitive":  {field: "name", given: &String{Values: []string{"a"}, Valid: true, Type: QueryType_HAS_PREFIX, Insensitive: true}, ok: true},
+		"negation-not-allowed": {field: "name", given: &String{Values: []string{"a"}, Valid: true, Negation: true, Type: QueryType_EQUAL}},
+		"pattern-not-allowed":  {field: "name", given: &String{Values: []string{"a"}, Valid: true, Type: QueryType_PATTERN}},
+		"insensitive-not-allowed": {
+			field: "age",
+			given: &String{Values: []string{"1"}, Valid: true, Type: QueryType_EQUAL, Insensitive: true},
+		},
+	}
+
+	for hint, c := range cases {
+		err := testPolicy.Check(c.field, c.given)
+		if c.ok {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			}
+			continue
+		}
+		var violation *PolicyViolation
+		if !errors.As(err, &violation) || !errors.Is(err, ErrNotAllowed) {
+			t.Errorf("%s: expected policy violation, got %v", hint, err)
+			continue
+		}
+		if violation.Field != c.field || violation.Type != c.given.GetType() {
+			t.Errorf("%s: wrong violation: %v", hint, violation)
+		}
+	}
+}
+
+func TestPolicyViolation_Error(t *testing.T) {
+	err := &PolicyViolation{Field: "name", Type: QueryType_SUBSTRING, Negation: true, Insensitive: true}
+	if expected := `qtypes: negated case insensitive SUBSTRING condition is not allowed for field "name"`; err.Error() != expected {
+		t.Errorf("wrong message, expected %q but got %q", expected, err.Error())
+	}
+}
+
+// testMessageType returns type of message that looks like:
+//
+//	message Filter {
+//		qtypes.Int64 age = 1;
+//		repeated qtypes.String name = 2;
+//		Filter nested = 3;
+//		map<string, qtypes.Int64> labels = 4;
+//	}
+func testMessageType(t *testing.T) protoreflect.MessageType {
+	t.Helper()
+
+	label := func(l descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto_Label { return &l }
+	typ := func(t descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto_Type { return &t }
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("filter.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{File_qtypes_proto.Path()},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Filter"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("age"), Number: proto.Int32(1), Label: label(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL), Type: typ(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE), TypeName: proto.String(".qtypes.Int64")},
+				{Name: proto.String("name"), Number: proto.Int32(2), Label: label(descriptorpb.FieldDescriptorProto_LABEL_REPEATED), Type: typ(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE), TypeName: proto.String(".qtypes.String")},
+				{Name: proto.String("nested"), Number: proto.Int32(3), Label: label(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL), Type: typ(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE), TypeName: proto.String(".test.Filter")},
+				{Name: proto.String("labels"), Number: proto.Int32(4), Label: label(descriptorpb.FieldDescriptorProto_LABEL_REPEATED), Type: typ(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE), TypeName: proto.String(".test.Filter.LabelsEntry")},
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("LabelsEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("key"), Number: proto.Int32(1), Label: label(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL), Type: typ(descriptorpb.FieldDescriptorProto_TYPE_STRING)},
+					{Name: proto.String("value"), Number: proto.Int32(2), Label: label(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL), Type: typ(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE), TypeName: proto.String(".qtypes.Int64")},
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return dynamicpb.NewMessageType(fd.Messages().ByName("Filter"))
+}
+
+func TestPolicy_CheckMessage(t *testing.T) {
+	mt := testMessageType(t)
+	fields := mt.Descriptor().Fields()
+
+	newFilter := func(age *Int64, names ...*String) protoreflect.Message {
+		m := mt.New()
+		if age != nil {
+			m.Set(fields.ByName("age"), protoreflect.ValueOfMessage(age.ProtoReflect()))
+		}
+		list := m.Mutable(fields.ByName("name")).List()
+		for _, n := range names {
+			list.Append(protoreflect.ValueOfMessage(n.ProtoReflect()))
+		}
+		return m
+	}
+
+	policy := Policy{
+		"age":         testPolicy["age"],
+		"name":        testPolicy["name"],
+		"nested.age":  testPolicy["age"],
+		"nested.name": testPolicy["name"],
+		"labels":      {Types: []QueryType{QueryType_EQUAL}},
+	}
+
+	cases := map[string]struct {
+		given    protoreflect.Message
+		expected string
+	}{
+		"empty": {
+			given: mt.New(),
+		},
+		"allowed": {
+			given: newFilter(BetweenInt64(1, 2), EqualString("a"), HasPrefixString("b")),
+		},
+		"not-allowed": {
+			given:    newFilter(GreaterInt64(1)),
+			expected: "age",
+		},
+		"repeated": {
+			given:    newFilter(nil, EqualString("a"), SubString("b")),
+			expected: "name",
+		},
+		"nested": {
+			given: func() protoreflect.Message {
+				m := newFilter(EqualInt64(1))
+				m.Set(fields.ByName("nested"), protoreflect.ValueOfMessage(newFilter(nil, &String{Values: []string{"a"}, Valid: true, Type: QueryType_PATTERN})))
+				return m
+			}(),
+			expected: "nested.name",
+		},
+		"map": {
+			given: func() protoreflect.Message {
+				m := mt.New()
+				labels := m.Mutable(fields.ByName("labels")).Map()
+				labels.Set(protoreflect.ValueOfString("a").MapKey(), protoreflect.ValueOfMessage(EqualInt64(1).ProtoReflect()))
+				labels.Set(protoreflect.ValueOfString("b").MapKey(), protoreflect.ValueOfMessage(NotEqualInt64(1).ProtoReflect()))
+				return m
+			}(),
+			expected: "labels",
+		},
+	}
+
+	for hint, c := range cases {
+		err := policy.CheckMessage(c.given.Interface())
+		if c.expected == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			}
+			continue
+		}
+		var violation *PolicyViolation
+		if !errors.As(err, &violation) {
+			t.Errorf("%s: expected policy violation, got %v", hint, err)
+			continue
+		}
+		if violation.Field != c.expected {
+			t.Errorf("%s: wrong field, expected %q but got %q", hint, c.expected, violation.Field)
+		}
+	}
+}
+
+func TestPolicy_CheckMessage_dynamicCondition(t *testing.T) {
+	md := File_qtypes_proto.Messages().ByName("Int64")
+	m := dynamicpb.NewMessage(md)
+	m.Set(md.Fields().ByName("valid"), protoreflect.ValueOfBool(true))
+	m.Set(md.Fields().ByName("type"), protoreflect.ValueOfEnum(protoreflect.EnumNumber(QueryType_GREATER)))
+
+	mt := testMessageType(t)
+	filter := mt.New()
+	filter.Set(mt.Descriptor().Fields().ByName("age"), protoreflect.ValueOfMessage(m))
+
+	err := testPolicy.CheckMessage(filter.Interface())
+	if !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("expected policy violation, got %v", err)
+	}
+}
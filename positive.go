@@ -0,0 +1,73 @@
+package qtypes
+
+import (
+	"slices"
+)
+
+// Positive returns conditions without negation that, joined with OR, are equivalent to the object, e.g.:
+//
+//   - NOT GREATER 5 becomes LESS_EQUAL 5,
+//   - NOT BETWEEN 1 AND 5 becomes LESS_EQUAL 0 OR GREATER_EQUAL 6,
+//   - NOT IN (1, 3) becomes LESS_EQUAL 0 OR EQUAL 2 OR GREATER_EQUAL 4.
+//
+// NOT NULL and negated conditions that cannot be rewritten (e.g. array operations) are returned as a single, normalized condition.
+// Condition that cannot be satisfied produces no conditions at all.
+// Invalid object produces single empty object. The receiver is never modified.
+func (i *Int64) Positive() []*Int64 {
+	var res []*Int64
+	for _, c := range positive(int64Condition(i), int64Domain) {
+		res = append(res, newInt64(c))
+	}
+	return res
+}
+
+// Positive works like Int64.Positive.
+func (u *Uint64) Positive() []*Uint64 {
+	var res []*Uint64
+	for _, c := range positive(uint64Condition(u), uint64Domain) {
+		res = append(res, newUint64(c))
+	}
+	return res
+}
+
+// Positive works like Int64.Positive.
+func (f *Float64) Positive() []*Float64 {
+	var res []*Float64
+	for _, c := range positive(float64Condition(f), float64Domain) {
+		res = append(res, newFloat64(c))
+	}
+	return res
+}
+
+// Positive works like Int64.Positive.
+func (t *Timestamp) Positive() []*Timestamp {
+	if slices.Contains(t.GetValues(), nil) {
+		return []*Timestamp{t.Normalize()}
+	}
+	var res []*Timestamp
+	for _, c := range positive(timestampCondition(t), timestampDomain) {
+		res = append(res, newTimestamp(c))
+	}
+	return res
+}
+
+func positive[T any](c condition[T], d domain[T]) []condition[T] {
+	c = normalize(c, d)
+	s, ok := d.valueSet(c)
+	switch {
+	case ok && s.empty():
+		return nil
+	case !ok || !c.negation || c.t == QueryType_NULL:
+		return []condition[T]{c}
+	}
+
+	res := make([]condition[T], 0, len(s.intervals))
+	for _, i := range s.intervals {
+		if d.point(i) {
+			res = append(res, condition[T]{values: []T{i.lo.value}, valid: true, t: QueryType_EQUAL})
+			continue
+		}
+		res = append(res, normalize(d.intervalCondition(i, false), d))
+	}
+	return res
+}
@@ -0,0 +1,100 @@
+package qtypes
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestInt64_Positive(t *testing.T) {
+	cases := map[string]struct {
+		given    *Int64
+		expected []*Int64
+	}{
+		"invalid": {
+			given:    nil,
+			expected: []*Int64{{}},
+		},
+		"not-negated": {
+			given:    InInt64(2, 1),
+			expected: []*Int64{InInt64(1, 2)},
+		},
+		"not-greater": {
+			given:    &Int64{Values: []int64{5}, Valid: true, Negation: true, Type: QueryType_GREATER},
+			expected: []*Int64{LessEqualInt64(5)},
+		},
+		"not-between": {
+			given:    &Int64{Values: []int64{1, 5}, Valid: true, Negation: true, Type: QueryType_BETWEEN},
+			expected: []*Int64{LessEqualInt64(0), GreaterEqualInt64(6)},
+		},
+		"not-in": {
+			given:    &Int64{Values: []int64{3, 1}, Valid: true, Negation: true, Type: QueryType_IN},
+			expected: []*Int64{LessEqualInt64(0), EqualInt64(2), GreaterEqualInt64(4)},
+		},
+		"not-equal-min": {
+			given:    NotEqualInt64(math.MinInt64),
+			expected: []*Int64{GreaterEqualInt64(math.MinInt64 + 1)},
+		},
+		"not-between-everything": {
+			given:    &Int64{Values: []int64{math.MinInt64, math.MaxInt64}, Valid: true, Negation: true, Type: QueryType_BETWEEN},
+			expected: []*Int64{},
+		},
+		"not-null": {
+			given:    &Int64{Valid: true, Negation: true, Type: QueryType_NULL},
+			expected: []*Int64{{Valid: true, Negation: true, Type: QueryType_NULL}},
+		},
+		"not-contains": {
+			given:    &Int64{Values: []int64{1}, Valid: true, Negation: true, Type: QueryType_CONTAINS},
+			expected: []*Int64{{Values: []int64{1}, Valid: true, Negation: true, Type: QueryType_CONTAINS}},
+		},
+	}
+
+	for hint, c := range cases {
+		got := c.given.Positive()
+		if len(got) != len(c.expected) {
+			t.Errorf("%s: wrong number of conditions, expected %d but got %d: %v", hint, len(c.expected), len(got), got)
+			continue
+		}
+		for i := range got {
+			if !proto.Equal(c.expected[i], got[i]) {
+				t.Errorf("%s: wrong output at %d,\nexpected:\n	%v\nbut got:\n	%v\n", hint, i, c.expected[i], got[i])
+			}
+		}
+	}
+}
+
+func TestFloat64_Positive(t *testing.T) {
+	got := (&Float64{Values: []float64{1, 2}, Valid: true, Negation: true, Type: QueryType_BETWEEN, UpperExclusive: true}).Positive()
+	expected := []*Float64{
+		{Values: []float64{1}, Valid: true, Type: QueryType_LESS},
+		{Values: []float64{2}, Valid: true, Type: QueryType_GREATER_EQUAL},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("wrong number of conditions, expected %d but got %d: %v", len(expected), len(got), got)
+	}
+	for i := range got {
+		if !proto.Equal(expected[i], got[i]) {
+			t.Errorf("wrong output at %d, expected %v but got %v", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestUint64_Positive(t *testing.T) {
+	got := (&Uint64{Values: []uint64{0}, Valid: true, Negation: true, Type: QueryType_EQUAL}).Positive()
+	expected := &Uint64{Values: []uint64{1}, Valid: true, Type: QueryType_GREATER_EQUAL}
+	if len(got) != 1 || !proto.Equal(expected, got[0]) {
+		t.Errorf("wrong output, expected %v but got %v", expected, got)
+	}
+}
+
+func TestTimestamp_Positive(t *testing.T) {
+	a := &knowntimestamp.Timestamp{Seconds: 1}
+
+	got := (&Timestamp{Values: []*knowntimestamp.Timestamp{a}, Valid: true, Negation: true, Type: QueryType_LESS}).Positive()
+	expected := &Timestamp{Values: []*knowntimestamp.Timestamp{a}, Valid: true, Type: QueryType_GREATER_EQUAL}
+	if len(got) != 1 || !proto.Equal(expected, got[0]) {
+		t.Errorf("wrong output, expected %v but got %v", expected, got)
+	}
+}
@@ -1,7 +1,11 @@
 // Package qtypes provides set of types that helps to build complex protobuf messages that can express conditional statements.
 package qtypes
 
-import knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+import (
+	"math"
+
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
 
 // Value returns first value or empty string if none.
 func (qs *String) Value() string {
@@ -21,6 +25,26 @@ func EqualString(s string) *String {
 	}
 }
 
+// EqualStringInsensitive allocates valid String object that matches values equal to given one, ignoring case, see Folding.
+func EqualStringInsensitive(s string) *String {
+	return &String{
+		Values:      []string{s},
+		Valid:       true,
+		Type:        QueryType_EQUAL,
+		Insensitive: true,
+	}
+}
+
+// InStringInsensitive allocates valid String object that matches values equal to any of given ones, ignoring case, see Folding.
+func InStringInsensitive(values ...string) *String {
+	return &String{
+		Values:      values,
+		Valid:       true,
+		Type:        QueryType_IN,
+		Insensitive: true,
+	}
+}
+
 // HasPrefixString ...
 func HasPrefixString(s string) *String {
 	return &String{
@@ -48,6 +72,62 @@ func SubString(s string) *String {
 	}
 }
 
+// GreaterString allocates valid String object that matches values greater than given one, see StringOrder.
+func GreaterString(s string) *String {
+	return &String{
+		Values: []string{s},
+		Valid:  true,
+		Type:   QueryType_GREATER,
+	}
+}
+
+// GreaterEqualString allocates valid String object that matches values greater than or equal to given one, see StringOrder.
+func GreaterEqualString(s string) *String {
+	return &String{
+		Values: []string{s},
+		Valid:  true,
+		Type:   QueryType_GREATER_EQUAL,
+	}
+}
+
+// LessString allocates valid String object that matches values less than given one, see StringOrder.
+func LessString(s string) *String {
+	return &String{
+		Values: []string{s},
+		Valid:  true,
+		Type:   QueryType_LESS,
+	}
+}
+
+// LessEqualString allocates valid String object that matches values less than or equal to given one, see StringOrder.
+func LessEqualString(s string) *String {
+	return &String{
+		Values: []string{s},
+		Valid:  true,
+		Type:   QueryType_LESS_EQUAL,
+	}
+}
+
+// BetweenString allocates valid String object that matches values between given ones, inclusive, see StringOrder.
+// Inclusive upper bound does not match longer values, e.g. "fox" is not between "a" and "f".
+// For alphabetical ranges like "A-F" combine GreaterEqualString("a") with LessString("g") instead.
+func BetweenString(from, to string) *String {
+	return &String{
+		Values: []string{from, to},
+		Valid:  true,
+		Type:   QueryType_BETWEEN,
+	}
+}
+
+// WildcardString allocates valid String object that matches values against given wildcard pattern, see MatchWildcard.
+func WildcardString(s string) *String {
+	return &String{
+		Values: []string{s},
+		Valid:  true,
+		Type:   QueryType_WILDCARD,
+	}
+}
+
 // NullString ...
 func NullString() *String {
 	return &String{
@@ -93,7 +173,14 @@ func InInt64(v ...int64) *Int64 {
 }
 
 // BetweenInt64 allocates valid Int64 object of type between with given values.
+// Passing math.MinInt64 as a or math.MaxInt64 as b leaves that side unbounded,
+// in which case equivalent less equal or greater equal object is returned instead of between object
+// holding the sentinel value, e.g. BetweenInt64(math.MinInt64, 10) returns LessEqualInt64(10).
+// If both are passed, not null object, that matches any value, is returned.
 func BetweenInt64(a, b int64) *Int64 {
+	if a == math.MinInt64 || b == math.MaxInt64 {
+		return RangeInt64(a, b, false, false)
+	}
 	return &Int64{
 		Values: []int64{a, b},
 		Valid:  true,
@@ -101,6 +188,41 @@ func BetweenInt64(a, b int64) *Int64 {
 	}
 }
 
+// RangeInt64 allocates Int64 object of type between with given values and bounds inclusivity.
+// Object is valid only if range is not empty, e.g. RangeInt64(1, 2, true, true) is not, as no integer is between 1 and 2.
+// Inclusive math.MinInt64 lower bound or math.MaxInt64 upper bound leaves that side unbounded,
+// in which case equivalent less (equal) or greater (equal) object is returned.
+// If both sides are unbounded, not null object is returned.
+func RangeInt64(from, to int64, lowerExclusive, upperExclusive bool) *Int64 {
+	switch {
+	case from == math.MinInt64 && !lowerExclusive && to == math.MaxInt64 && !upperExclusive:
+		return &Int64{
+			Valid:    true,
+			Negation: true,
+			Type:     QueryType_NULL,
+		}
+	case from == math.MinInt64 && !lowerExclusive:
+		return &Int64{
+			Values: []int64{to},
+			Valid:  nonEmpty(from, to, lowerExclusive, upperExclusive),
+			Type:   unboundedType(true, lowerExclusive, upperExclusive),
+		}
+	case to == math.MaxInt64 && !upperExclusive:
+		return &Int64{
+			Values: []int64{from},
+			Valid:  nonEmpty(from, to, lowerExclusive, upperExclusive),
+			Type:   unboundedType(false, lowerExclusive, upperExclusive),
+		}
+	}
+	return &Int64{
+		Values:         []int64{from, to},
+		Valid:          nonEmpty(from, to, lowerExclusive, upperExclusive),
+		Type:           QueryType_BETWEEN,
+		LowerExclusive: lowerExclusive,
+		UpperExclusive: upperExclusive,
+	}
+}
+
 // GreaterInt64 allocates valid Int64 object of type greater with given value.
 func GreaterInt64(i int64) *Int64 {
 	return &Int64{
@@ -146,6 +268,51 @@ func (i *Int64) Value() int64 {
 	return i.Values[0]
 }
 
+// BetweenUint64 allocates valid Uint64 object of type between with given values.
+// Passing math.MaxUint64 as b leaves upper side unbounded,
+// in which case equivalent greater equal object is returned instead of between object
+// holding the sentinel value, e.g. BetweenUint64(10, math.MaxUint64) returns greater equal object of 10.
+// If a is 0 as well, not null object, that matches any value, is returned.
+func BetweenUint64(a, b uint64) *Uint64 {
+	if b == math.MaxUint64 {
+		return RangeUint64(a, b, false, false)
+	}
+	return &Uint64{
+		Values: []uint64{a, b},
+		Valid:  true,
+		Type:   QueryType_BETWEEN,
+	}
+}
+
+// RangeUint64 allocates Uint64 object of type between with given values and bounds inclusivity.
+// Object is valid only if range is not empty, e.g. RangeUint64(1, 2, true, true) is not, as no integer is between 1 and 2.
+// Inclusive math.MaxUint64 upper bound leaves that side unbounded,
+// in which case equivalent greater (equal) object is returned.
+// If lower bound is inclusive 0 as well, not null object is returned.
+func RangeUint64(from, to uint64, lowerExclusive, upperExclusive bool) *Uint64 {
+	if from == 0 && !lowerExclusive && to == math.MaxUint64 && !upperExclusive {
+		return &Uint64{
+			Valid:    true,
+			Negation: true,
+			Type:     QueryType_NULL,
+		}
+	}
+	if to == math.MaxUint64 && !upperExclusive {
+		return &Uint64{
+			Values: []uint64{from},
+			Valid:  nonEmpty(from, to, lowerExclusive, upperExclusive),
+			Type:   unboundedType(false, lowerExclusive, upperExclusive),
+		}
+	}
+	return &Uint64{
+		Values:         []uint64{from, to},
+		Valid:          nonEmpty(from, to, lowerExclusive, upperExclusive),
+		Type:           QueryType_BETWEEN,
+		LowerExclusive: lowerExclusive,
+		UpperExclusive: upperExclusive,
+	}
+}
+
 // EqualFloat64 allocates valid Float64 object of type equal with given value.
 func EqualFloat64(i float64) *Float64 {
 	return &Float64{
@@ -156,6 +323,10 @@ func EqualFloat64(i float64) *Float64 {
 }
 
 // BetweenFloat64 allocates valid Float64 object if both numbers are not 0 and from is not greater than to.
+// Passing negative infinity as from or positive infinity as to leaves that side unbounded,
+// in which case equivalent less equal or greater equal object is returned instead of between object
+// holding the infinity, e.g. BetweenFloat64(math.Inf(-1), 10) returns less equal object of 10.
+// If both are passed, not null object, that matches any value, is returned.
 func BetweenFloat64(from, to float64) *Float64 {
 	if from == 0 && to == 0 {
 		return &Float64{}
@@ -163,6 +334,9 @@ func BetweenFloat64(from, to float64) *Float64 {
 	if from > to {
 		return &Float64{}
 	}
+	if math.IsInf(from, -1) || math.IsInf(to, 1) {
+		return RangeFloat64(from, to, false, false)
+	}
 	return &Float64{
 		Values: []float64{from, to},
 		Type:   QueryType_BETWEEN,
@@ -170,6 +344,40 @@ func BetweenFloat64(from, to float64) *Float64 {
 	}
 }
 
+// RangeFloat64 allocates Float64 object of type between with given values and bounds inclusivity.
+// Object is valid only if range is not empty.
+// Infinite bound leaves that side unbounded, in which case equivalent less (equal) or greater (equal) object is returned.
+// If both sides are unbounded, not null object is returned.
+func RangeFloat64(from, to float64, lowerExclusive, upperExclusive bool) *Float64 {
+	switch lower, upper := math.IsInf(from, -1), math.IsInf(to, 1); {
+	case lower && upper:
+		return &Float64{
+			Valid:    true,
+			Negation: true,
+			Type:     QueryType_NULL,
+		}
+	case lower:
+		return &Float64{
+			Values: []float64{to},
+			Valid:  !math.IsInf(to, -1),
+			Type:   unboundedType(true, lowerExclusive, upperExclusive),
+		}
+	case upper:
+		return &Float64{
+			Values: []float64{from},
+			Valid:  !math.IsInf(from, 1),
+			Type:   unboundedType(false, lowerExclusive, upperExclusive),
+		}
+	}
+	return &Float64{
+		Values:         []float64{from, to},
+		Valid:          from < to || (from == to && !lowerExclusive && !upperExclusive),
+		Type:           QueryType_BETWEEN,
+		LowerExclusive: lowerExclusive,
+		UpperExclusive: upperExclusive,
+	}
+}
+
 // Value returns first available value or 0 if none available.
 func (f *Float64) Value() float64 {
 	if len(f.Values) == 0 {
@@ -179,11 +387,12 @@ func (f *Float64) Value() float64 {
 	return f.Values[0]
 }
 
-// BetweenTimestamp allocates valid Timestamp object if both timestamps are not nil
-// and first is before the second.
+// BetweenTimestamp allocates valid Timestamp object if first timestamp is before the second.
+// Nil timestamp leaves that side unbounded, in which case equivalent less equal or greater equal object is returned.
+// If both are nil, not null object, that matches any value, is returned.
 func BetweenTimestamp(from, to *knowntimestamp.Timestamp) *Timestamp {
 	if from == nil || to == nil {
-		return &Timestamp{}
+		return RangeTimestamp(from, to, false, false)
 	}
 
 	v := true
@@ -200,6 +409,43 @@ func BetweenTimestamp(from, to *knowntimestamp.Timestamp) *Timestamp {
 	}
 }
 
+// RangeTimestamp allocates Timestamp object of type between with given values and bounds inclusivity.
+// Object is valid only if range is not empty.
+// For example RangeTimestamp(from, to, false, true) matches half-open range [from, to).
+// Nil timestamp leaves that side unbounded, in which case equivalent less (equal) or greater (equal) object is returned.
+// If both are nil, not null object is returned.
+func RangeTimestamp(from, to *knowntimestamp.Timestamp, lowerExclusive, upperExclusive bool) *Timestamp {
+	switch {
+	case from == nil && to == nil:
+		return &Timestamp{
+			Valid:    true,
+			Negation: true,
+			Type:     QueryType_NULL,
+		}
+	case from == nil:
+		return &Timestamp{
+			Values: []*knowntimestamp.Timestamp{to},
+			Valid:  true,
+			Type:   unboundedType(true, lowerExclusive, upperExclusive),
+		}
+	case to == nil:
+		return &Timestamp{
+			Values: []*knowntimestamp.Timestamp{from},
+			Valid:  true,
+			Type:   unboundedType(false, lowerExclusive, upperExclusive),
+		}
+	}
+
+	c := from.AsTime().Compare(to.AsTime())
+	return &Timestamp{
+		Values:         []*knowntimestamp.Timestamp{from, to},
+		Type:           QueryType_BETWEEN,
+		Valid:          c < 0 || (c == 0 && !lowerExclusive && !upperExclusive),
+		LowerExclusive: lowerExclusive,
+		UpperExclusive: upperExclusive,
+	}
+}
+
 // Value returns first value or nil if none.
 func (t *Timestamp) Value() *knowntimestamp.Timestamp {
 	if len(t.Values) == 0 {
@@ -208,3 +454,35 @@ func (t *Timestamp) Value() *knowntimestamp.Timestamp {
 
 	return t.Values[0]
 }
+
+// unboundedType returns type of comparison that is equivalent to between with one unbounded side.
+// nonEmpty reports whether any integer is within given bounds. Exclusive bounds are tightened first,
+// range that an exclusive bound of the minimum or maximum value leaves no room in is empty.
+func nonEmpty[T int64 | uint64](from, to T, lowerExclusive, upperExclusive bool) bool {
+	if lowerExclusive {
+		if from+1 < from {
+			return false
+		}
+		from++
+	}
+	if upperExclusive {
+		if to-1 > to {
+			return false
+		}
+		to--
+	}
+	return from <= to
+}
+
+func unboundedType(lowerUnbounded, lowerExclusive, upperExclusive bool) QueryType {
+	switch {
+	case lowerUnbounded && upperExclusive:
+		return QueryType_LESS
+	case lowerUnbounded:
+		return QueryType_LESS_EQUAL
+	case lowerExclusive:
+		return QueryType_GREATER
+	default:
+		return QueryType_GREATER_EQUAL
+	}
+}
@@ -45,6 +45,7 @@ const (
 	QueryType_HAS_ELEMENT      QueryType = 17
 	QueryType_HAS_ANY_ELEMENT  QueryType = 18
 	QueryType_HAS_ALL_ELEMENTS QueryType = 19
+	QueryType_WILDCARD         QueryType = 20
 )
 
 // Enum value maps for QueryType.
@@ -70,6 +71,7 @@ var (
 		17: "HAS_ELEMENT",
 		18: "HAS_ANY_ELEMENT",
 		19: "HAS_ALL_ELEMENTS",
+		20: "WILDCARD",
 	}
 	QueryType_value = map[string]int32{
 		"NULL":             0,
@@ -92,6 +94,7 @@ var (
 		"HAS_ELEMENT":      17,
 		"HAS_ANY_ELEMENT":  18,
 		"HAS_ALL_ELEMENTS": 19,
+		"WILDCARD":         20,
 	}
 )
 
@@ -212,6 +215,10 @@ type Int64 struct {
 	Valid    bool      `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
 	Negation bool      `protobuf:"varint,3,opt,name=negation,proto3" json:"negation,omitempty"`
 	Type     QueryType `protobuf:"varint,4,opt,name=type,proto3,enum=qtypes.QueryType" json:"type,omitempty"`
+	// lower_exclusive excludes first value from BETWEEN range.
+	LowerExclusive bool `protobuf:"varint,5,opt,name=lower_exclusive,json=lowerExclusive,proto3" json:"lower_exclusive,omitempty"`
+	// upper_exclusive excludes second value from BETWEEN range.
+	UpperExclusive bool `protobuf:"varint,6,opt,name=upper_exclusive,json=upperExclusive,proto3" json:"upper_exclusive,omitempty"`
 }
 
 func (x *Int64) Reset() {
@@ -274,6 +281,20 @@ func (x *Int64) GetType() QueryType {
 	return QueryType_NULL
 }
 
+func (x *Int64) GetLowerExclusive() bool {
+	if x != nil {
+		return x.LowerExclusive
+	}
+	return false
+}
+
+func (x *Int64) GetUpperExclusive() bool {
+	if x != nil {
+		return x.UpperExclusive
+	}
+	return false
+}
+
 // Uint64 ...
 type Uint64 struct {
 	state         protoimpl.MessageState
@@ -284,6 +305,10 @@ type Uint64 struct {
 	Valid    bool      `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
 	Negation bool      `protobuf:"varint,3,opt,name=negation,proto3" json:"negation,omitempty"`
 	Type     QueryType `protobuf:"varint,4,opt,name=type,proto3,enum=qtypes.QueryType" json:"type,omitempty"`
+	// lower_exclusive excludes first value from BETWEEN range.
+	LowerExclusive bool `protobuf:"varint,5,opt,name=lower_exclusive,json=lowerExclusive,proto3" json:"lower_exclusive,omitempty"`
+	// upper_exclusive excludes second value from BETWEEN range.
+	UpperExclusive bool `protobuf:"varint,6,opt,name=upper_exclusive,json=upperExclusive,proto3" json:"upper_exclusive,omitempty"`
 }
 
 func (x *Uint64) Reset() {
@@ -346,6 +371,20 @@ func (x *Uint64) GetType() QueryType {
 	return QueryType_NULL
 }
 
+func (x *Uint64) GetLowerExclusive() bool {
+	if x != nil {
+		return x.LowerExclusive
+	}
+	return false
+}
+
+func (x *Uint64) GetUpperExclusive() bool {
+	if x != nil {
+		return x.UpperExclusive
+	}
+	return false
+}
+
 // Float64 ...
 type Float64 struct {
 	state         protoimpl.MessageState
@@ -356,6 +395,10 @@ type Float64 struct {
 	Valid    bool      `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
 	Negation bool      `protobuf:"varint,3,opt,name=negation,proto3" json:"negation,omitempty"`
 	Type     QueryType `protobuf:"varint,4,opt,name=type,proto3,enum=qtypes.QueryType" json:"type,omitempty"`
+	// lower_exclusive excludes first value from BETWEEN range.
+	LowerExclusive bool `protobuf:"varint,5,opt,name=lower_exclusive,json=lowerExclusive,proto3" json:"lower_exclusive,omitempty"`
+	// upper_exclusive excludes second value from BETWEEN range.
+	UpperExclusive bool `protobuf:"varint,6,opt,name=upper_exclusive,json=upperExclusive,proto3" json:"upper_exclusive,omitempty"`
 }
 
 func (x *Float64) Reset() {
@@ -418,6 +461,20 @@ func (x *Float64) GetType() QueryType {
 	return QueryType_NULL
 }
 
+func (x *Float64) GetLowerExclusive() bool {
+	if x != nil {
+		return x.LowerExclusive
+	}
+	return false
+}
+
+func (x *Float64) GetUpperExclusive() bool {
+	if x != nil {
+		return x.UpperExclusive
+	}
+	return false
+}
+
 // Timestamp ...
 type Timestamp struct {
 	state         protoimpl.MessageState
@@ -428,6 +485,10 @@ type Timestamp struct {
 	Valid    bool                     `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
 	Negation bool                     `protobuf:"varint,3,opt,name=negation,proto3" json:"negation,omitempty"`
 	Type     QueryType                `protobuf:"varint,4,opt,name=type,proto3,enum=qtypes.QueryType" json:"type,omitempty"`
+	// lower_exclusive excludes first value from BETWEEN range.
+	LowerExclusive bool `protobuf:"varint,5,opt,name=lower_exclusive,json=lowerExclusive,proto3" json:"lower_exclusive,omitempty"`
+	// upper_exclusive excludes second value from BETWEEN range.
+	UpperExclusive bool `protobuf:"varint,6,opt,name=upper_exclusive,json=upperExclusive,proto3" json:"upper_exclusive,omitempty"`
 }
 
 func (x *Timestamp) Reset() {
@@ -490,6 +551,20 @@ func (x *Timestamp) GetType() QueryType {
 	return QueryType_NULL
 }
 
+func (x *Timestamp) GetLowerExclusive() bool {
+	if x != nil {
+		return x.LowerExclusive
+	}
+	return false
+}
+
+func (x *Timestamp) GetUpperExclusive() bool {
+	if x != nil {
+		return x.UpperExclusive
+	}
+	return false
+}
+
 var File_qtypes_proto protoreflect.FileDescriptor
 
 var file_qtypes_proto_rawDesc = []byte{
@@ -506,62 +581,83 @@ var file_qtypes_proto_rawDesc = []byte{
 	0x70, 0x65, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
 	0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69,
 	0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x65, 0x6e, 0x73,
-	0x69, 0x74, 0x69, 0x76, 0x65, 0x22, 0x78, 0x0a, 0x05, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x12, 0x16,
-	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08,
-	0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
-	0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x71, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22,
-	0x79, 0x0a, 0x06, 0x55, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x69, 0x74, 0x69, 0x76, 0x65, 0x22, 0xca, 0x01, 0x0a, 0x05, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x71, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73,
+	0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x65, 0x72,
+	0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x75, 0x70, 0x70,
+	0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x75, 0x70, 0x70, 0x65, 0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x76, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x06, 0x55, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x71, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27,
+	0x0a, 0x0f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x45, 0x78,
+	0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x75, 0x70, 0x70, 0x65, 0x72,
+	0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x75, 0x70, 0x70, 0x65, 0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65,
+	0x22, 0xcc, 0x01, 0x0a, 0x07, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x71, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x45, 0x78, 0x63,
+	0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x75, 0x70, 0x70, 0x65, 0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x22,
+	0xea, 0x01, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x32, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
 	0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
 	0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
 	0x0e, 0x32, 0x11, 0x2e, 0x71, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x7a, 0x0a, 0x07, 0x46, 0x6c,
-	0x6f, 0x61, 0x74, 0x36, 0x34, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e,
-	0x71, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x98, 0x01, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x12, 0x32, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x1a,
-	0x0a, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x71, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x2a, 0xb7, 0x02, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x08, 0x0a, 0x04, 0x4e, 0x55, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x51, 0x55,
-	0x41, 0x4c, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x10,
-	0x02, 0x12, 0x11, 0x0a, 0x0d, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f, 0x45, 0x51, 0x55,
-	0x41, 0x4c, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x45, 0x53, 0x53, 0x10, 0x04, 0x12, 0x0e,
-	0x0a, 0x0a, 0x4c, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x05, 0x12, 0x06,
-	0x0a, 0x02, 0x49, 0x4e, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x45, 0x54, 0x57, 0x45, 0x45,
-	0x4e, 0x10, 0x07, 0x12, 0x0e, 0x0a, 0x0a, 0x48, 0x41, 0x53, 0x5f, 0x50, 0x52, 0x45, 0x46, 0x49,
-	0x58, 0x10, 0x08, 0x12, 0x0e, 0x0a, 0x0a, 0x48, 0x41, 0x53, 0x5f, 0x53, 0x55, 0x46, 0x46, 0x49,
-	0x58, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x42, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47,
-	0x10, 0x0a, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e, 0x10, 0x0b, 0x12,
-	0x0e, 0x0a, 0x0a, 0x4d, 0x49, 0x4e, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x10, 0x0c, 0x12,
-	0x0e, 0x0a, 0x0a, 0x4d, 0x41, 0x58, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x10, 0x0d, 0x12,
-	0x0b, 0x0a, 0x07, 0x4f, 0x56, 0x45, 0x52, 0x4c, 0x41, 0x50, 0x10, 0x0e, 0x12, 0x0c, 0x0a, 0x08,
-	0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x0f, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x53,
-	0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x45, 0x44, 0x5f, 0x42, 0x59, 0x10, 0x10, 0x12,
-	0x0f, 0x0a, 0x0b, 0x48, 0x41, 0x53, 0x5f, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x11,
-	0x12, 0x13, 0x0a, 0x0f, 0x48, 0x41, 0x53, 0x5f, 0x41, 0x4e, 0x59, 0x5f, 0x45, 0x4c, 0x45, 0x4d,
-	0x45, 0x4e, 0x54, 0x10, 0x12, 0x12, 0x14, 0x0a, 0x10, 0x48, 0x41, 0x53, 0x5f, 0x41, 0x4c, 0x4c,
-	0x5f, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x53, 0x10, 0x13, 0x42, 0x22, 0x5a, 0x20, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x69, 0x6f, 0x74, 0x72, 0x6b,
-	0x6f, 0x77, 0x61, 0x6c, 0x63, 0x7a, 0x75, 0x6b, 0x2f, 0x71, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f,
+	0x77, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73,
+	0x69, 0x76, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x75, 0x70,
+	0x70, 0x65, 0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x2a, 0xc5, 0x02, 0x0a,
+	0x09, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x55,
+	0x4c, 0x4c, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x01, 0x12,
+	0x0b, 0x0a, 0x07, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d,
+	0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x03, 0x12,
+	0x08, 0x0a, 0x04, 0x4c, 0x45, 0x53, 0x53, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x45, 0x53,
+	0x53, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x05, 0x12, 0x06, 0x0a, 0x02, 0x49, 0x4e, 0x10,
+	0x06, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x45, 0x54, 0x57, 0x45, 0x45, 0x4e, 0x10, 0x07, 0x12, 0x0e,
+	0x0a, 0x0a, 0x48, 0x41, 0x53, 0x5f, 0x50, 0x52, 0x45, 0x46, 0x49, 0x58, 0x10, 0x08, 0x12, 0x0e,
+	0x0a, 0x0a, 0x48, 0x41, 0x53, 0x5f, 0x53, 0x55, 0x46, 0x46, 0x49, 0x58, 0x10, 0x09, 0x12, 0x0d,
+	0x0a, 0x09, 0x53, 0x55, 0x42, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x0a, 0x12, 0x0b, 0x0a,
+	0x07, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e, 0x10, 0x0b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x49,
+	0x4e, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x41,
+	0x58, 0x5f, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x10, 0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x56,
+	0x45, 0x52, 0x4c, 0x41, 0x50, 0x10, 0x0e, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x54, 0x41,
+	0x49, 0x4e, 0x53, 0x10, 0x0f, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x54,
+	0x41, 0x49, 0x4e, 0x45, 0x44, 0x5f, 0x42, 0x59, 0x10, 0x10, 0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x41,
+	0x53, 0x5f, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x11, 0x12, 0x13, 0x0a, 0x0f, 0x48,
+	0x41, 0x53, 0x5f, 0x41, 0x4e, 0x59, 0x5f, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x12,
+	0x12, 0x14, 0x0a, 0x10, 0x48, 0x41, 0x53, 0x5f, 0x41, 0x4c, 0x4c, 0x5f, 0x45, 0x4c, 0x45, 0x4d,
+	0x45, 0x4e, 0x54, 0x53, 0x10, 0x13, 0x12, 0x0c, 0x0a, 0x08, 0x57, 0x49, 0x4c, 0x44, 0x43, 0x41,
+	0x52, 0x44, 0x10, 0x14, 0x42, 0x22, 0x5a, 0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x70, 0x69, 0x6f, 0x74, 0x72, 0x6b, 0x6f, 0x77, 0x61, 0x6c, 0x63, 0x7a, 0x75,
+	0x6b, 0x2f, 0x71, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
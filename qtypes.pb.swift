@@ -42,6 +42,7 @@ public enum Qtypes_QueryType: SwiftProtobuf.Enum, Swift.CaseIterable {
   case hasElement // = 17
   case hasAnyElement // = 18
   case hasAllElements // = 19
+  case wildcard // = 20
   case UNRECOGNIZED(Int)
 
   public init() {
@@ -70,6 +71,7 @@ public enum Qtypes_QueryType: SwiftProtobuf.Enum, Swift.CaseIterable {
     case 17: self = .hasElement
     case 18: self = .hasAnyElement
     case 19: self = .hasAllElements
+    case 20: self = .wildcard
     default: self = .UNRECOGNIZED(rawValue)
     }
   }
@@ -96,6 +98,7 @@ public enum Qtypes_QueryType: SwiftProtobuf.Enum, Swift.CaseIterable {
     case .hasElement: return 17
     case .hasAnyElement: return 18
     case .hasAllElements: return 19
+    case .wildcard: return 20
     case .UNRECOGNIZED(let i): return i
     }
   }
@@ -122,6 +125,7 @@ public enum Qtypes_QueryType: SwiftProtobuf.Enum, Swift.CaseIterable {
     .hasElement,
     .hasAnyElement,
     .hasAllElements,
+    .wildcard,
   ]
 
 }
@@ -161,6 +165,12 @@ public struct Qtypes_Int64: Sendable {
 
   public var type: Qtypes_QueryType = .null
 
+  /// lower_exclusive excludes first value from BETWEEN range.
+  public var lowerExclusive: Bool = false
+
+  /// upper_exclusive excludes second value from BETWEEN range.
+  public var upperExclusive: Bool = false
+
   public var unknownFields = SwiftProtobuf.UnknownStorage()
 
   public init() {}
@@ -180,6 +190,12 @@ public struct Qtypes_Uint64: Sendable {
 
   public var type: Qtypes_QueryType = .null
 
+  /// lower_exclusive excludes first value from BETWEEN range.
+  public var lowerExclusive: Bool = false
+
+  /// upper_exclusive excludes second value from BETWEEN range.
+  public var upperExclusive: Bool = false
+
   public var unknownFields = SwiftProtobuf.UnknownStorage()
 
   public init() {}
@@ -199,6 +215,12 @@ public struct Qtypes_Float64: Sendable {
 
   public var type: Qtypes_QueryType = .null
 
+  /// lower_exclusive excludes first value from BETWEEN range.
+  public var lowerExclusive: Bool = false
+
+  /// upper_exclusive excludes second value from BETWEEN range.
+  public var upperExclusive: Bool = false
+
   public var unknownFields = SwiftProtobuf.UnknownStorage()
 
   public init() {}
@@ -218,6 +240,12 @@ public struct Qtypes_Timestamp: Sendable {
 
   public var type: Qtypes_QueryType = .null
 
+  /// lower_exclusive excludes first value from BETWEEN range.
+  public var lowerExclusive: Bool = false
+
+  /// upper_exclusive excludes second value from BETWEEN range.
+  public var upperExclusive: Bool = false
+
   public var unknownFields = SwiftProtobuf.UnknownStorage()
 
   public init() {}
@@ -249,6 +277,7 @@ extension Qtypes_QueryType: SwiftProtobuf._ProtoNameProviding {
     17: .same(proto: "HAS_ELEMENT"),
     18: .same(proto: "HAS_ANY_ELEMENT"),
     19: .same(proto: "HAS_ALL_ELEMENTS"),
+    20: .same(proto: "WILDCARD"),
   ]
 }
 
@@ -315,6 +344,8 @@ extension Qtypes_Int64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplementat
     2: .same(proto: "valid"),
     3: .same(proto: "negation"),
     4: .same(proto: "type"),
+    5: .standard(proto: "lower_exclusive"),
+    6: .standard(proto: "upper_exclusive"),
   ]
 
   public mutating func decodeMessage<D: SwiftProtobuf.Decoder>(decoder: inout D) throws {
@@ -327,6 +358,8 @@ extension Qtypes_Int64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplementat
       case 2: try { try decoder.decodeSingularBoolField(value: &self.valid) }()
       case 3: try { try decoder.decodeSingularBoolField(value: &self.negation) }()
       case 4: try { try decoder.decodeSingularEnumField(value: &self.type) }()
+      case 5: try { try decoder.decodeSingularBoolField(value: &self.lowerExclusive) }()
+      case 6: try { try decoder.decodeSingularBoolField(value: &self.upperExclusive) }()
       default: break
       }
     }
@@ -345,6 +378,12 @@ extension Qtypes_Int64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplementat
     if self.type != .null {
       try visitor.visitSingularEnumField(value: self.type, fieldNumber: 4)
     }
+    if self.lowerExclusive != false {
+      try visitor.visitSingularBoolField(value: self.lowerExclusive, fieldNumber: 5)
+    }
+    if self.upperExclusive != false {
+      try visitor.visitSingularBoolField(value: self.upperExclusive, fieldNumber: 6)
+    }
     try unknownFields.traverse(visitor: &visitor)
   }
 
@@ -353,6 +392,8 @@ extension Qtypes_Int64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplementat
     if lhs.valid != rhs.valid {return false}
     if lhs.negation != rhs.negation {return false}
     if lhs.type != rhs.type {return false}
+    if lhs.lowerExclusive != rhs.lowerExclusive {return false}
+    if lhs.upperExclusive != rhs.upperExclusive {return false}
     if lhs.unknownFields != rhs.unknownFields {return false}
     return true
   }
@@ -365,6 +406,8 @@ extension Qtypes_Uint64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplementa
     2: .same(proto: "valid"),
     3: .same(proto: "negation"),
     4: .same(proto: "type"),
+    5: .standard(proto: "lower_exclusive"),
+    6: .standard(proto: "upper_exclusive"),
   ]
 
   public mutating func decodeMessage<D: SwiftProtobuf.Decoder>(decoder: inout D) throws {
@@ -377,6 +420,8 @@ extension Qtypes_Uint64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplementa
       case 2: try { try decoder.decodeSingularBoolField(value: &self.valid) }()
       case 3: try { try decoder.decodeSingularBoolField(value: &self.negation) }()
       case 4: try { try decoder.decodeSingularEnumField(value: &self.type) }()
+      case 5: try { try decoder.decodeSingularBoolField(value: &self.lowerExclusive) }()
+      case 6: try { try decoder.decodeSingularBoolField(value: &self.upperExclusive) }()
       default: break
       }
     }
@@ -395,6 +440,12 @@ extension Qtypes_Uint64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplementa
     if self.type != .null {
       try visitor.visitSingularEnumField(value: self.type, fieldNumber: 4)
     }
+    if self.lowerExclusive != false {
+      try visitor.visitSingularBoolField(value: self.lowerExclusive, fieldNumber: 5)
+    }
+    if self.upperExclusive != false {
+      try visitor.visitSingularBoolField(value: self.upperExclusive, fieldNumber: 6)
+    }
     try unknownFields.traverse(visitor: &visitor)
   }
 
@@ -403,6 +454,8 @@ extension Qtypes_Uint64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplementa
     if lhs.valid != rhs.valid {return false}
     if lhs.negation != rhs.negation {return false}
     if lhs.type != rhs.type {return false}
+    if lhs.lowerExclusive != rhs.lowerExclusive {return false}
+    if lhs.upperExclusive != rhs.upperExclusive {return false}
     if lhs.unknownFields != rhs.unknownFields {return false}
     return true
   }
@@ -415,6 +468,8 @@ extension Qtypes_Float64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplement
     2: .same(proto: "valid"),
     3: .same(proto: "negation"),
     4: .same(proto: "type"),
+    5: .standard(proto: "lower_exclusive"),
+    6: .standard(proto: "upper_exclusive"),
   ]
 
   public mutating func decodeMessage<D: SwiftProtobuf.Decoder>(decoder: inout D) throws {
@@ -427,6 +482,8 @@ extension Qtypes_Float64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplement
       case 2: try { try decoder.decodeSingularBoolField(value: &self.valid) }()
       case 3: try { try decoder.decodeSingularBoolField(value: &self.negation) }()
       case 4: try { try decoder.decodeSingularEnumField(value: &self.type) }()
+      case 5: try { try decoder.decodeSingularBoolField(value: &self.lowerExclusive) }()
+      case 6: try { try decoder.decodeSingularBoolField(value: &self.upperExclusive) }()
       default: break
       }
     }
@@ -445,6 +502,12 @@ extension Qtypes_Float64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplement
     if self.type != .null {
       try visitor.visitSingularEnumField(value: self.type, fieldNumber: 4)
     }
+    if self.lowerExclusive != false {
+      try visitor.visitSingularBoolField(value: self.lowerExclusive, fieldNumber: 5)
+    }
+    if self.upperExclusive != false {
+      try visitor.visitSingularBoolField(value: self.upperExclusive, fieldNumber: 6)
+    }
     try unknownFields.traverse(visitor: &visitor)
   }
 
@@ -453,6 +516,8 @@ extension Qtypes_Float64: SwiftProtobuf.Message, SwiftProtobuf._MessageImplement
     if lhs.valid != rhs.valid {return false}
     if lhs.negation != rhs.negation {return false}
     if lhs.type != rhs.type {return false}
+    if lhs.lowerExclusive != rhs.lowerExclusive {return false}
+    if lhs.upperExclusive != rhs.upperExclusive {return false}
     if lhs.unknownFields != rhs.unknownFields {return false}
     return true
   }
@@ -465,6 +530,8 @@ extension Qtypes_Timestamp: SwiftProtobuf.Message, SwiftProtobuf._MessageImpleme
     2: .same(proto: "valid"),
     3: .same(proto: "negation"),
     4: .same(proto: "type"),
+    5: .standard(proto: "lower_exclusive"),
+    6: .standard(proto: "upper_exclusive"),
   ]
 
   public mutating func decodeMessage<D: SwiftProtobuf.Decoder>(decoder: inout D) throws {
@@ -477,6 +544,8 @@ extension Qtypes_Timestamp: SwiftProtobuf.Message, SwiftProtobuf._MessageImpleme
       case 2: try { try decoder.decodeSingularBoolField(value: &self.valid) }()
       case 3: try { try decoder.decodeSingularBoolField(value: &self.negation) }()
       case 4: try { try decoder.decodeSingularEnumField(value: &self.type) }()
+      case 5: try { try decoder.decodeSingularBoolField(value: &self.lowerExclusive) }()
+      case 6: try { try decoder.decodeSingularBoolField(value: &self.upperExclusive) }()
       default: break
       }
     }
@@ -495,6 +564,12 @@ extension Qtypes_Timestamp: SwiftProtobuf.Message, SwiftProtobuf._MessageImpleme
     if self.type != .null {
       try visitor.visitSingularEnumField(value: self.type, fieldNumber: 4)
     }
+    if self.lowerExclusive != false {
+      try visitor.visitSingularBoolField(value: self.lowerExclusive, fieldNumber: 5)
+    }
+    if self.upperExclusive != false {
+      try visitor.visitSingularBoolField(value: self.upperExclusive, fieldNumber: 6)
+    }
     try unknownFields.traverse(visitor: &visitor)
   }
 
@@ -503,6 +578,8 @@ extension Qtypes_Timestamp: SwiftProtobuf.Message, SwiftProtobuf._MessageImpleme
     if lhs.valid != rhs.valid {return false}
     if lhs.negation != rhs.negation {return false}
     if lhs.type != rhs.type {return false}
+    if lhs.lowerExclusive != rhs.lowerExclusive {return false}
+    if lhs.upperExclusive != rhs.upperExclusive {return false}
     if lhs.unknownFields != rhs.unknownFields {return false}
     return true
   }
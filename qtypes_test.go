@@ -2,6 +2,7 @@ package qtypes
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 
@@ -59,6 +60,24 @@ func TestEqualString(t *testing.T) {
 	testString(t, EqualString(values[0]), false, true, QueryType_EQUAL, values...)
 }
 
+func TestEqualStringInsensitive(t *testing.T) {
+	values := []string{"John@example.com"}
+	s := EqualStringInsensitive(values[0])
+	testString(t, s, false, true, QueryType_EQUAL, values...)
+	if !s.Insensitive {
+		t.Error("expected insensitive")
+	}
+}
+
+func TestInStringInsensitive(t *testing.T) {
+	values := []string{"john", "Jane"}
+	s := InStringInsensitive(values...)
+	testString(t, s, false, true, QueryType_IN, values...)
+	if !s.Insensitive {
+		t.Error("expected insensitive")
+	}
+}
+
 func TestSubString(t *testing.T) {
 	values := []string{"a"}
 	testString(t, SubString(values[0]), false, true, QueryType_SUBSTRING, values...)
@@ -74,6 +93,36 @@ func TestHasSuffixString(t *testing.T) {
 	testString(t, HasSuffixString(values[0]), false, true, QueryType_HAS_SUFFIX, values...)
 }
 
+func TestGreaterString(t *testing.T) {
+	values := []string{"a"}
+	testString(t, GreaterString(values[0]), false, true, QueryType_GREATER, values...)
+}
+
+func TestGreaterEqualString(t *testing.T) {
+	values := []string{"a"}
+	testString(t, GreaterEqualString(values[0]), false, true, QueryType_GREATER_EQUAL, values...)
+}
+
+func TestLessString(t *testing.T) {
+	values := []string{"a"}
+	testString(t, LessString(values[0]), false, true, QueryType_LESS, values...)
+}
+
+func TestLessEqualString(t *testing.T) {
+	values := []string{"a"}
+	testString(t, LessEqualString(values[0]), false, true, QueryType_LESS_EQUAL, values...)
+}
+
+func TestBetweenString(t *testing.T) {
+	values := []string{"a", "f"}
+	testString(t, BetweenString(values[0], values[1]), false, true, QueryType_BETWEEN, values...)
+}
+
+func TestWildcardString(t *testing.T) {
+	values := []string{"jo*n?"}
+	testString(t, WildcardString(values[0]), false, true, QueryType_WILDCARD, values...)
+}
+
 func TestNullString(t *testing.T) {
 	testString(t, NullString(), false, true, QueryType_NULL)
 }
@@ -151,17 +200,29 @@ func TestBetweenTimestamp(t *testing.T) {
 		"nil-arguments": {
 			from:     nil,
 			to:       nil,
-			expected: Timestamp{},
+			expected: Timestamp{Valid: true, Negation: true, Type: QueryType_NULL},
 		},
 		"nil-argument-first": {
-			from:     nil,
-			to:       &knowntimestamp.Timestamp{Seconds: 0, Nanos: 1},
-			expected: Timestamp{},
+			from: nil,
+			to:   &knowntimestamp.Timestamp{Seconds: 0, Nanos: 1},
+			expected: Timestamp{
+				Valid: true,
+				Type:  QueryType_LESS_EQUAL,
+				Values: []*knowntimestamp.Timestamp{
+					{Seconds: 0, Nanos: 1},
+				},
+			},
 		},
 		"nil-argument-second": {
-			from:     &knowntimestamp.Timestamp{Seconds: 0, Nanos: 1},
-			to:       nil,
-			expected: Timestamp{},
+			from: &knowntimestamp.Timestamp{Seconds: 0, Nanos: 1},
+			to:   nil,
+			expected: Timestamp{
+				Valid: true,
+				Type:  QueryType_GREATER_EQUAL,
+				Values: []*knowntimestamp.Timestamp{
+					{Seconds: 0, Nanos: 1},
+				},
+			},
 		},
 	}
 
@@ -173,6 +234,31 @@ func TestBetweenTimestamp(t *testing.T) {
 	}
 }
 
+func TestRangeTimestamp(t *testing.T) {
+	from := &knowntimestamp.Timestamp{Seconds: 1}
+	to := &knowntimestamp.Timestamp{Seconds: 2}
+
+	got := RangeTimestamp(from, to, false, true)
+	if !got.Valid || got.Type != QueryType_BETWEEN || got.LowerExclusive || !got.UpperExclusive {
+		t.Errorf("unexpected output: %v", got)
+	}
+	if got := RangeTimestamp(from, from, false, true); got.Valid {
+		t.Error("expected empty range to be invalid")
+	}
+	if got := RangeTimestamp(from, from, false, false); !got.Valid {
+		t.Error("expected closed range with single value to be valid")
+	}
+	if got := RangeTimestamp(nil, to, false, true); !got.Valid || got.Type != QueryType_LESS {
+		t.Errorf("expected range without lower bound to be valid less condition, got: %v", got)
+	}
+	if got := RangeTimestamp(from, nil, true, false); !got.Valid || got.Type != QueryType_GREATER {
+		t.Errorf("expected range without upper bound to be valid greater condition, got: %v", got)
+	}
+	if got := RangeTimestamp(nil, nil, true, true); !got.Valid || !got.Negation || got.Type != QueryType_NULL {
+		t.Errorf("expected range without bounds to be valid not null condition, got: %v", got)
+	}
+}
+
 func TestInt64_Value(t *testing.T) {
 	cases := map[string]struct {
 		given    Int64
@@ -239,6 +325,49 @@ func TestBetweenInt64(t *testing.T) {
 	testInt64(t, BetweenInt64(values[0], values[1]), false, true, QueryType_BETWEEN, values...)
 }
 
+func TestRangeInt64(t *testing.T) {
+	cases := map[string]struct {
+		from, to                       int64
+		lowerExclusive, upperExclusive bool
+		valid                          bool
+	}{
+		"closed":              {from: 1, to: 2, valid: true},
+		"half-open":           {from: 1, to: 2, upperExclusive: true, valid: true},
+		"open":                {from: 1, to: 3, lowerExclusive: true, upperExclusive: true, valid: true},
+		"open-adjacent":       {from: 1, to: 2, lowerExclusive: true, upperExclusive: true, valid: false},
+		"open-maximum":        {from: math.MaxInt64, to: math.MaxInt64, lowerExclusive: true, upperExclusive: true, valid: false},
+		"open-minimum":        {from: math.MinInt64 + 1, to: math.MinInt64 + 1, lowerExclusive: true, upperExclusive: true, valid: false},
+		"closed-single-value": {from: 1, to: 1, valid: true},
+		"open-single-value":   {from: 1, to: 1, lowerExclusive: true, valid: false},
+		"reversed":            {from: 2, to: 1, valid: false},
+	}
+
+	for hint, c := range cases {
+		got := RangeInt64(c.from, c.to, c.lowerExclusive, c.upperExclusive)
+		if got.Valid != c.valid {
+			t.Errorf("%s: expected valid to be %t", hint, c.valid)
+		}
+		if got.LowerExclusive != c.lowerExclusive || got.UpperExclusive != c.upperExclusive {
+			t.Errorf("%s: wrong bounds, expected %t, %t but got %t, %t", hint, c.lowerExclusive, c.upperExclusive, got.LowerExclusive, got.UpperExclusive)
+		}
+		testInt64(t, got, false, c.valid, QueryType_BETWEEN, c.from, c.to)
+	}
+}
+
+func TestBetweenInt64_unbounded(t *testing.T) {
+	testInt64(t, BetweenInt64(math.MinInt64, 10), false, true, QueryType_LESS_EQUAL, 10)
+	testInt64(t, BetweenInt64(10, math.MaxInt64), false, true, QueryType_GREATER_EQUAL, 10)
+	testInt64(t, BetweenInt64(math.MinInt64, math.MaxInt64), true, true, QueryType_NULL)
+	testInt64(t, RangeInt64(math.MinInt64, math.MaxInt64, false, false), true, true, QueryType_NULL)
+	testInt64(t, RangeInt64(math.MinInt64, math.MaxInt64, false, true), false, true, QueryType_LESS, math.MaxInt64)
+	testInt64(t, BetweenInt64(math.MinInt64+1, math.MaxInt64-1), false, true, QueryType_BETWEEN, math.MinInt64+1, math.MaxInt64-1)
+	testInt64(t, RangeInt64(math.MinInt64, 10, false, true), false, true, QueryType_LESS, 10)
+	testInt64(t, RangeInt64(10, math.MaxInt64, true, false), false, true, QueryType_GREATER, 10)
+	testInt64(t, RangeInt64(math.MinInt64, 10, true, false), false, true, QueryType_BETWEEN, math.MinInt64, 10)
+	testInt64(t, RangeInt64(math.MinInt64, math.MinInt64, false, true), false, false, QueryType_LESS, math.MinInt64)
+	testInt64(t, RangeInt64(math.MaxInt64, math.MaxInt64, true, false), false, false, QueryType_GREATER, math.MaxInt64)
+}
+
 func TestLessInt64(t *testing.T) {
 	value := int64(1111)
 	testInt64(t, LessInt64(value), false, true, QueryType_LESS, value)
@@ -286,6 +415,56 @@ func TestBetweenFloat64(t *testing.T) {
 	testFloat64(t, BetweenFloat64(values[0], values[1]), false, true, QueryType_BETWEEN, values...)
 }
 
+func TestRangeFloat64(t *testing.T) {
+	values := []float64{1111, 2222}
+	testFloat64(t, RangeFloat64(values[0], values[1], true, false), false, true, QueryType_BETWEEN, values...)
+	testFloat64(t, RangeFloat64(values[0], values[0], false, true), false, false, QueryType_BETWEEN, values[0], values[0])
+}
+
+func TestBetweenFloat64_unbounded(t *testing.T) {
+	testFloat64(t, BetweenFloat64(math.Inf(-1), 10), false, true, QueryType_LESS_EQUAL, 10)
+	testFloat64(t, BetweenFloat64(10, math.Inf(1)), false, true, QueryType_GREATER_EQUAL, 10)
+	testFloat64(t, BetweenFloat64(math.Inf(-1), math.Inf(1)), true, true, QueryType_NULL)
+	testFloat64(t, BetweenFloat64(-math.MaxFloat64, math.MaxFloat64), false, true, QueryType_BETWEEN, -math.MaxFloat64, math.MaxFloat64)
+	testFloat64(t, RangeFloat64(math.Inf(-1), 10, false, true), false, true, QueryType_LESS, 10)
+	testFloat64(t, RangeFloat64(10, math.Inf(1), true, false), false, true, QueryType_GREATER, 10)
+	testFloat64(t, RangeFloat64(math.Inf(-1), math.Inf(1), true, true), true, true, QueryType_NULL)
+}
+
+func TestRangeUint64(t *testing.T) {
+	got := RangeUint64(1, 2, false, true)
+	if !got.Valid || got.Type != QueryType_BETWEEN || got.LowerExclusive || !got.UpperExclusive {
+		t.Errorf("unexpected output: %v", got)
+	}
+	if got := RangeUint64(2, 2, true, true); got.Valid {
+		t.Error("expected empty range to be invalid")
+	}
+	if got := RangeUint64(1, 2, true, true); got.Valid {
+		t.Error("expected open range of adjacent integers to be invalid")
+	}
+	if got := RangeUint64(1, 3, true, true); !got.Valid {
+		t.Error("expected open range of integers with one in between to be valid")
+	}
+	if got := RangeUint64(0, 0, false, true); got.Valid {
+		t.Error("expected range below zero to be invalid")
+	}
+	if got := RangeUint64(math.MaxUint64, math.MaxUint64, true, true); got.Valid {
+		t.Error("expected range above maximum to be invalid")
+	}
+	if got := RangeUint64(math.MaxUint64, math.MaxUint64, true, false); got.Valid || got.Type != QueryType_GREATER {
+		t.Errorf("expected range without upper bound above maximum to be invalid greater condition, got: %v", got)
+	}
+	if got := BetweenUint64(1, math.MaxUint64); !got.Valid || got.Type != QueryType_GREATER_EQUAL || len(got.Values) != 1 {
+		t.Errorf("expected range without upper bound to be valid greater equal condition, got: %v", got)
+	}
+	if got := BetweenUint64(0, math.MaxUint64); !got.Valid || !got.Negation || got.Type != QueryType_NULL {
+		t.Errorf("expected range without bounds to be valid not null condition, got: %v", got)
+	}
+	if got := BetweenUint64(0, math.MaxUint64-1); !got.Valid || got.Type != QueryType_BETWEEN || len(got.Values) != 2 {
+		t.Errorf("expected range with upper bound to be valid between condition, got: %v", got)
+	}
+}
+
 func testFloat64(t *testing.T, f *Float64, n, v bool, tp QueryType, values ...float64) {
 	if f.Negation != n {
 		t.Errorf("wrong negation, exiected %t but got %t", n, f.Negation)
@@ -0,0 +1,90 @@
+// Package qtypesaip parses AIP-160 filters, e.g. age >= 18 AND name:"jo*", into conditions keyed by field name,
+// and formats them back. See https://google.aip.dev/160.
+//
+// Conditions on the same field have to be satisfied all together, so parts of a filter that cannot be expressed
+// as such are reported as unsupported, e.g. functions, traversal of fields that the schema does not list,
+// global restrictions or OR between restrictions on different fields.
+// OR between equality comparisons on the same field is supported, and results in IN,
+// or HAS_ANY_ELEMENT for repeated fields.
+//
+// String values of =, != and : comparisons are star patterns, see qtypes.StarString,
+// so "jo*" results in HAS_PREFIX. Presence test field:* results in negated NULL.
+package qtypesaip
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Kind is a type of conditions that a field holds.
+type Kind int
+
+const (
+	// KindInt64 is a field of qtypes.Int64 conditions.
+	KindInt64 Kind = iota + 1
+	// KindUint64 is a field of qtypes.Uint64 conditions.
+	KindUint64
+	// KindFloat64 is a field of qtypes.Float64 conditions.
+	KindFloat64
+	// KindTimestamp is a field of qtypes.Timestamp conditions, its values are RFC 3339 timestamps.
+	KindTimestamp
+	// KindString is a field of qtypes.String conditions.
+	KindString
+)
+
+// Field describes a field that a filter can use.
+type Field struct {
+	Kind Kind
+	// Repeated fields support only the has operator, e.g. tags:"a", that results in HAS_ELEMENT.
+	Repeated bool
+}
+
+// Schema lists fields that a filter can use. Names of nested fields are paths, e.g. "author.name".
+type Schema map[string]Field
+
+// Filter is a parsed filter, conditions are keyed by field name.
+// Decoded conditions are of type that the schema declares for the field, e.g. *qtypes.Int64 for KindInt64.
+type Filter map[string][]qtypes.Condition
+
+var (
+	// ErrInvalidFilter is wrapped by errors returned when a filter is not a valid AIP-160 filter.
+	ErrInvalidFilter = errors.New("qtypes: invalid aip-160 filter")
+	// ErrUnsupportedFilter is wrapped by errors returned when a valid filter cannot be expressed by conditions.
+	ErrUnsupportedFilter = errors.New("qtypes: unsupported aip-160 filter")
+)
+
+// ParseError is returned when a filter is not valid. It wraps ErrInvalidFilter.
+type ParseError struct {
+	// Pos is a byte offset in the filter at which the problem is.
+	Pos    int
+	Reason string
+}
+
+// Error implements error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("qtypes: aip-160 parsing error at position %d: %s", e.Pos, e.Reason)
+}
+
+// Unwrap returns ErrInvalidFilter.
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidFilter
+}
+
+// UnsupportedError is returned when a valid filter cannot be expressed by conditions. It wraps ErrUnsupportedFilter.
+type UnsupportedError struct {
+	// Pos is a byte offset in the filter at which the unsupported part starts.
+	Pos    int
+	Reason string
+}
+
+// Error implements error interface.
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("qtypes: aip-160 filter is not supported at position %d: %s", e.Pos, e.Reason)
+}
+
+// Unwrap returns ErrUnsupportedFilter.
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupportedFilter
+}
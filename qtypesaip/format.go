@@ -0,0 +1,185 @@
+package qtypesaip
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Format returns AIP-160 representation of given filter, that Schema.Parse understands.
+// Invalid conditions are skipped. Conditions that AIP-160 cannot express, e.g. PATTERN or case insensitive ones,
+// cause an error.
+func Format(f Filter) (string, error) {
+	var parts []string
+	for _, field := range slices.Sorted(maps.Keys(f)) {
+		for i, c := range f[field] {
+			s, err := format(field, c)
+			if err != nil {
+				return "", fmt.Errorf("qtypes: aip-160 formatting error for condition %d of field %q: %w", i, field, err)
+			}
+			if s != "" {
+				parts = append(parts, s)
+			}
+		}
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+func format(field string, c qtypes.Condition) (string, error) {
+	if c == nil || !c.GetValid() {
+		return "", nil
+	}
+
+	var (
+		values []string
+		le, ue bool
+		s      *qtypes.String
+	)
+	switch v := c.(type) {
+	case *qtypes.Int64:
+		for _, n := range v.Values {
+			values = append(values, strconv.FormatInt(n, 10))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Uint64:
+		for _, n := range v.Values {
+			values = append(values, strconv.FormatUint(n, 10))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Float64:
+		for _, n := range v.Values {
+			values = append(values, strconv.FormatFloat(n, 'f', -1, 64))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Timestamp:
+		for i, t := range v.Values {
+			if t == nil {
+				return "", fmt.Errorf("nil timestamp at position %d", i)
+			}
+			values = append(values, t.AsTime().UTC().Format(time.RFC3339Nano))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.String:
+		if v.Insensitive {
+			return "", fmt.Errorf("case insensitive %s cannot be expressed", v.Type)
+		}
+		s = v
+		for _, str := range v.Values {
+			values = append(values, quote(escape(str, `*\`)))
+		}
+	default:
+		return "", fmt.Errorf("unsupported condition type %T", c)
+	}
+
+	t, negation := c.GetType(), c.GetNegation()
+	not := func(s string) string {
+		if negation {
+			return "NOT " + s
+		}
+		return s
+	}
+	count := func(n int) error {
+		if len(values) != n {
+			return fmt.Errorf("%s requires %d value(s) but got %d", t, n, len(values))
+		}
+		return nil
+	}
+	join := func(comparator, sep string) (string, error) {
+		if len(values) == 0 {
+			return "", fmt.Errorf("%s requires at least one value", t)
+		}
+		parts := make([]string, 0, len(values))
+		for _, v := range values {
+			parts = append(parts, field+comparator+v)
+		}
+		if len(parts) == 1 {
+			return not(parts[0]), nil
+		}
+		return not("(" + strings.Join(parts, sep) + ")"), nil
+	}
+
+	switch t {
+	case qtypes.QueryType_NULL:
+		if negation {
+			return field + ":*", nil
+		}
+		return "NOT " + field + ":*", nil
+	case qtypes.QueryType_EQUAL, qtypes.QueryType_HAS_PREFIX, qtypes.QueryType_HAS_SUFFIX, qtypes.QueryType_SUBSTRING, qtypes.QueryType_WILDCARD:
+		if err := count(1); err != nil {
+			return "", err
+		}
+		v := values[0]
+		if s != nil {
+			pattern, ok := qtypes.StarPattern(s)
+			if !ok {
+				return "", fmt.Errorf("%s of %q cannot be expressed", t, s.Values[0])
+			}
+			v = quote(pattern)
+		} else if t != qtypes.QueryType_EQUAL {
+			return "", fmt.Errorf("%s is supported only for strings", t)
+		}
+		if negation {
+			return field + " != " + v, nil
+		}
+		return field + " = " + v, nil
+	case qtypes.QueryType_GREATER, qtypes.QueryType_GREATER_EQUAL, qtypes.QueryType_LESS, qtypes.QueryType_LESS_EQUAL:
+		if err := count(1); err != nil {
+			return "", err
+		}
+		return not(field + " " + comparators[t] + " " + values[0]), nil
+	case qtypes.QueryType_BETWEEN:
+		if err := count(2); err != nil {
+			return "", err
+		}
+		lower, upper := qtypes.QueryType_GREATER_EQUAL, qtypes.QueryType_LESS_EQUAL
+		if le {
+			lower = qtypes.QueryType_GREATER
+		}
+		if ue {
+			upper = qtypes.QueryType_LESS
+		}
+		return not("(" + field + " " + comparators[lower] + " " + values[0] + " AND " + field + " " + comparators[upper] + " " + values[1] + ")"), nil
+	case qtypes.QueryType_IN:
+		return join(" = ", " OR ")
+	case qtypes.QueryType_HAS_ELEMENT:
+		if err := count(1); err != nil {
+			return "", err
+		}
+		return not(field + ":" + values[0]), nil
+	case qtypes.QueryType_HAS_ANY_ELEMENT, qtypes.QueryType_OVERLAP:
+		return join(":", " OR ")
+	case qtypes.QueryType_HAS_ALL_ELEMENTS, qtypes.QueryType_CONTAINS:
+		return join(":", " AND ")
+	default:
+		return "", fmt.Errorf("%s cannot be expressed", t)
+	}
+}
+
+var comparators = map[qtypes.QueryType]string{
+	qtypes.QueryType_GREATER:       ">",
+	qtypes.QueryType_GREATER_EQUAL: ">=",
+	qtypes.QueryType_LESS:          "<",
+	qtypes.QueryType_LESS_EQUAL:    "<=",
+}
+
+// escape prefixes given characters with a backslash.
+func escape(s, chars string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(chars, s[i]) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// quote wraps given escaped string in double quotes.
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
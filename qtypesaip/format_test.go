@@ -0,0 +1,148 @@
+package qtypesaip_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesaip"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFormat(t *testing.T) {
+	from := knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC))
+	to := knowntimestamp.New(time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		given    qtypesaip.Filter
+		expected string
+	}{
+		"example": {
+			given:    qtypesaip.Filter{"age": {qtypes.GreaterEqualInt64(18)}, "name": {qtypes.HasPrefixString("jo")}},
+			expected: `age >= 18 AND name = "jo*"`,
+		},
+		"empty": {
+			given:    qtypesaip.Filter{},
+			expected: "",
+		},
+		"invalid": {
+			given:    qtypesaip.Filter{"age": {&qtypes.Int64{Values: []int64{1}}, nil, qtypes.EqualInt64(2)}},
+			expected: "age = 2",
+		},
+		"null": {
+			given:    qtypesaip.Filter{"age": {qtypes.NullInt64()}, "name": {&qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}}},
+			expected: "NOT age:* AND name:*",
+		},
+		"negated-comparison": {
+			given:    qtypesaip.Filter{"score": {&qtypes.Float64{Values: []float64{0.5}, Valid: true, Negation: true, Type: qtypes.QueryType_GREATER}}},
+			expected: "NOT score > 0.5",
+		},
+		"not-equal": {
+			given:    qtypesaip.Filter{"age": {qtypes.NotEqualInt64(-1)}},
+			expected: "age != -1",
+		},
+		"between": {
+			given:    qtypesaip.Filter{"create_time": {qtypes.RangeTimestamp(from, to, false, true)}},
+			expected: "(create_time >= 2024-05-01T00:00:00Z AND create_time < 2024-05-02T00:00:00Z)",
+		},
+		"not-in": {
+			given:    qtypesaip.Filter{"name": {&qtypes.String{Values: []string{"a*", `say "hi"`}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}}},
+			expected: `NOT (name = "a\*" OR name = "say \"hi\"")`,
+		},
+		"suffix": {
+			given:    qtypesaip.Filter{"name": {&qtypes.String{Values: []string{".com"}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_SUFFIX}}},
+			expected: `name != "*.com"`,
+		},
+		"wildcard": {
+			given:    qtypesaip.Filter{"name": {qtypes.WildcardString(`j*n\?`)}},
+			expected: `name = "j*n?"`,
+		},
+		"elements": {
+			given: qtypesaip.Filter{
+				"tags": {
+					&qtypes.String{Values: []string{"a"}, Valid: true, Type: qtypes.QueryType_HAS_ELEMENT},
+					&qtypes.String{Values: []string{"b", "c"}, Valid: true, Type: qtypes.QueryType_OVERLAP},
+					&qtypes.String{Values: []string{"d", "e"}, Valid: true, Negation: true, Type: qtypes.QueryType_CONTAINS},
+				},
+			},
+			expected: `tags:"a" AND (tags:"b" OR tags:"c") AND NOT (tags:"d" AND tags:"e")`,
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypesaip.Format(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestFormat_error(t *testing.T) {
+	cases := map[string]qtypesaip.Filter{
+		"insensitive":          {"name": {qtypes.EqualStringInsensitive("john")}},
+		"pattern":              {"name": {&qtypes.String{Values: []string{"^j"}, Valid: true, Type: qtypes.QueryType_PATTERN}}},
+		"wildcard-question":    {"name": {qtypes.WildcardString("jo?")}},
+		"prefix-of-int":        {"age": {&qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_HAS_PREFIX}}},
+		"is-contained-by":      {"ids": {&qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_IS_CONTAINED_BY}}},
+		"between-single-value": {"age": {&qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_BETWEEN}}},
+		"empty-in":             {"age": {&qtypes.Int64{Valid: true, Type: qtypes.QueryType_IN}}},
+		"nil-timestamp":        {"create_time": {&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{nil}, Valid: true, Type: qtypes.QueryType_EQUAL}}},
+	}
+
+	for hint, given := range cases {
+		if _, err := qtypesaip.Format(given); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
+
+func TestFormat_roundTrip(t *testing.T) {
+	from := knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 500, time.UTC))
+	to := knowntimestamp.New(time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC))
+
+	cases := map[string]qtypesaip.Filter{
+		"numbers": {
+			"age":   {qtypes.RangeInt64(1, 5, true, false), qtypes.NotEqualInt64(3)},
+			"id":    {&qtypes.Uint64{Values: []uint64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}},
+			"score": {&qtypes.Float64{Values: []float64{0.5, 1.5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}},
+		},
+		"timestamps": {
+			"create_time": {qtypes.RangeTimestamp(from, to, true, true)},
+		},
+		"strings": {
+			"name": {
+				qtypes.EqualString(`a*b\c "d"`),
+				qtypes.HasPrefixString("jo"),
+				qtypes.SubString("oh"),
+				qtypes.WildcardString("j*n*"),
+				qtypes.BetweenString("a", "f"),
+				&qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL},
+			},
+		},
+		"elements": {
+			"tags": {
+				&qtypes.String{Values: []string{"a*"}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_ELEMENT},
+				&qtypes.String{Values: []string{"b", "c"}, Valid: true, Type: qtypes.QueryType_HAS_ANY_ELEMENT},
+			},
+			"ids": {&qtypes.Int64{Values: []int64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_ALL_ELEMENTS}},
+		},
+	}
+
+	for hint, given := range cases {
+		s, err := qtypesaip.Format(given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		got, err := schema.Parse(s)
+		if err != nil {
+			t.Errorf("%s: unexpected error for %q: %s", hint, s, err.Error())
+			continue
+		}
+		assertFilter(t, hint, given, got)
+	}
+}
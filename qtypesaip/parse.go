@@ -0,0 +1,479 @@
+package qtypesaip
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Parse parses given filter. Fields that the schema does not list are rejected.
+// Errors are either of type *ParseError or *UnsupportedError, that point at the problem.
+// An empty filter results in an empty Filter.
+func (s Schema) Parse(filter string) (Filter, error) {
+	p := &parser{schema: s, src: filter}
+	res := Filter{}
+
+	p.space()
+	if p.pos == len(p.src) {
+		return res, nil
+	}
+	rs, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	p.space()
+	if p.pos < len(p.src) {
+		return nil, p.errorf(p.pos, "unexpected %q", p.src[p.pos])
+	}
+
+	for _, r := range rs {
+		res[r.field] = append(res[r.field], r.condition())
+	}
+	return res, nil
+}
+
+type parser struct {
+	schema Schema
+	src    string
+	pos    int
+}
+
+// restriction is a condition on a field, with values as written in the filter.
+type restriction struct {
+	field          string
+	kind           Kind
+	t              qtypes.QueryType
+	negation       bool
+	lowerExclusive bool
+	upperExclusive bool
+	values         []string
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	return &ParseError{Pos: pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) unsupported(pos int, format string, args ...interface{}) error {
+	return &UnsupportedError{Pos: pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// space skips white space and reports whether there was any.
+func (p *parser) space() bool {
+	start := p.pos
+	for p.pos < len(p.src) && isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// at reports whether given keyword, followed by white space, a parenthesis or the end, is at the current position.
+func (p *parser) at(keyword string) bool {
+	if !strings.HasPrefix(p.src[p.pos:], keyword) {
+		return false
+	}
+	end := p.pos + len(keyword)
+	return end == len(p.src) || isSpace(p.src[end]) || p.src[end] == '('
+}
+
+// keyword consumes given keyword preceded by white space, and returns its position or -1.
+func (p *parser) keyword(keyword string) int {
+	start := p.pos
+	if p.space() && p.at(keyword) {
+		pos := p.pos
+		p.pos += len(keyword)
+		p.space()
+		return pos
+	}
+	p.pos = start
+	return -1
+}
+
+func (p *parser) expression() ([]restriction, error) {
+	rs, err := p.sequence()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") >= 0 {
+		more, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, more...)
+	}
+	return rs, nil
+}
+
+// sequence parses factors separated by white space only, that are combined using AND.
+func (p *parser) sequence() ([]restriction, error) {
+	rs, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		start := p.pos
+		if !p.space() || p.pos == len(p.src) || p.src[p.pos] == ')' || p.at("AND") || p.at("OR") {
+			p.pos = start
+			return rs, nil
+		}
+		more, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, more...)
+	}
+}
+
+// factor parses terms separated by OR. It binds stronger than AND, as AIP-160 defines.
+func (p *parser) factor() ([]restriction, error) {
+	rs, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	orPos := -1
+	single := len(rs) == 1
+	for {
+		pos := p.keyword("OR")
+		if pos < 0 {
+			break
+		}
+		if orPos < 0 {
+			orPos = pos
+		}
+		more, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		single = single && len(more) == 1
+		rs = append(rs, more...)
+	}
+	if orPos < 0 {
+		return rs, nil
+	}
+	r, ok := disjunction(rs)
+	if !single || !ok {
+		return nil, p.unsupported(orPos, "OR is supported only between equality comparisons on the same field")
+	}
+	return []restriction{r}, nil
+}
+
+// disjunction combines equality comparisons on the same field into a single restriction.
+func disjunction(rs []restriction) (restriction, bool) {
+	first := rs[0]
+	for _, r := range rs {
+		if r.field != first.field || r.t != first.t || r.negation || len(r.values) != 1 {
+			return restriction{}, false
+		}
+	}
+	res := restriction{field: first.field, kind: first.kind}
+	switch first.t {
+	case qtypes.QueryType_EQUAL:
+		res.t = qtypes.QueryType_IN
+	case qtypes.QueryType_HAS_ELEMENT:
+		res.t = qtypes.QueryType_HAS_ANY_ELEMENT
+	default:
+		return restriction{}, false
+	}
+	for _, r := range rs {
+		res.values = append(res.values, r.values...)
+	}
+	return res, true
+}
+
+func (p *parser) term() ([]restriction, error) {
+	start := p.pos
+	negated := false
+	switch {
+	case p.at("NOT"):
+		p.pos += len("NOT")
+		p.space()
+		negated = true
+	case p.pos+1 < len(p.src) && p.src[p.pos] == '-' && !isSpace(p.src[p.pos+1]):
+		p.pos++
+		negated = true
+	}
+
+	rs, err := p.simple()
+	if err != nil {
+		return nil, err
+	}
+	if !negated {
+		return rs, nil
+	}
+	if len(rs) != 1 {
+		return nil, p.unsupported(start, "negation is supported only for a single condition")
+	}
+	rs[0].negation = !rs[0].negation
+	return rs, nil
+}
+
+func (p *parser) simple() ([]restriction, error) {
+	if p.pos == len(p.src) || p.src[p.pos] != '(' {
+		r, err := p.restriction()
+		if err != nil {
+			return nil, err
+		}
+		return []restriction{r}, nil
+	}
+
+	start := p.pos
+	p.pos++
+	p.space()
+	rs, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	p.space()
+	if p.pos == len(p.src) || p.src[p.pos] != ')' {
+		return nil, p.errorf(start, "unclosed parenthesis")
+	}
+	p.pos++
+	if r, ok := conjunction(rs); ok {
+		return []restriction{r}, nil
+	}
+	return rs, nil
+}
+
+// conjunction combines a range, or has comparisons of a repeated field, into a single restriction.
+func conjunction(rs []restriction) (restriction, bool) {
+	if len(rs) < 2 {
+		return restriction{}, false
+	}
+	first := rs[0]
+	for _, r := range rs {
+		if r.field != first.field || r.negation || len(r.values) != 1 {
+			return restriction{}, false
+		}
+	}
+
+	if len(rs) == 2 {
+		lo, hi := rs[0], rs[1]
+		if lo.t == qtypes.QueryType_LESS || lo.t == qtypes.QueryType_LESS_EQUAL {
+			lo, hi = hi, lo
+		}
+		if (lo.t == qtypes.QueryType_GREATER || lo.t == qtypes.QueryType_GREATER_EQUAL) &&
+			(hi.t == qtypes.QueryType_LESS || hi.t == qtypes.QueryType_LESS_EQUAL) {
+			res := restriction{
+				field:          first.field,
+				kind:           first.kind,
+				t:              qtypes.QueryType_BETWEEN,
+				lowerExclusive: lo.t == qtypes.QueryType_GREATER,
+				upperExclusive: hi.t == qtypes.QueryType_LESS,
+				values:         []string{lo.values[0], hi.values[0]},
+			}
+			// String conditions have no exclusive bounds.
+			if first.kind != KindString || (!res.lowerExclusive && !res.upperExclusive) {
+				return res, true
+			}
+		}
+	}
+
+	res := restriction{field: first.field, kind: first.kind, t: qtypes.QueryType_HAS_ALL_ELEMENTS}
+	for _, r := range rs {
+		if r.t != qtypes.QueryType_HAS_ELEMENT {
+			return restriction{}, false
+		}
+		res.values = append(res.values, r.values[0])
+	}
+	return res, true
+}
+
+// nameTerminators lists characters that end a field name.
+const nameTerminators = " \t\r\n()<>=!:\"',"
+
+func (p *parser) restriction() (restriction, error) {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte(nameTerminators, p.src[p.pos]) < 0 {
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+	if name == "" {
+		if p.pos < len(p.src) && (p.src[p.pos] == '"' || p.src[p.pos] == '\'') {
+			return restriction{}, p.unsupported(start, "global restrictions are not supported")
+		}
+		return restriction{}, p.errorf(start, "expected restriction")
+	}
+	if p.pos < len(p.src) && p.src[p.pos] == '(' {
+		return restriction{}, p.unsupported(start, "function %s is not supported", name)
+	}
+
+	p.space()
+	opPos := p.pos
+	comparator := p.comparator()
+	if comparator == "" {
+		return restriction{}, p.unsupported(start, "global restrictions are not supported")
+	}
+	field, ok := p.schema[name]
+	if !ok {
+		if strings.Contains(name, ".") {
+			return restriction{}, p.unsupported(start, "traversal %s is not supported", name)
+		}
+		return restriction{}, p.errorf(start, "unknown field %q", name)
+	}
+
+	p.space()
+	argPos := p.pos
+	if p.pos < len(p.src) && p.src[p.pos] == '(' {
+		return restriction{}, p.unsupported(argPos, "composite arguments are not supported")
+	}
+	arg, quoted, err := p.argument()
+	if err != nil {
+		return restriction{}, err
+	}
+
+	r := restriction{field: name, kind: field.Kind}
+	switch {
+	case comparator == ":" && arg == "*" && !quoted:
+		r.t, r.negation = qtypes.QueryType_NULL, true
+		return r, nil
+	case field.Repeated && comparator != ":":
+		return restriction{}, p.unsupported(opPos, "repeated field %s supports only : comparator", name)
+	case field.Repeated:
+		r.t, r.values = qtypes.QueryType_HAS_ELEMENT, []string{unescape(arg)}
+	case comparator == "=" || comparator == "!=" || comparator == ":":
+		r.t, r.negation, r.values = qtypes.QueryType_EQUAL, comparator == "!=", []string{unescape(arg)}
+		if field.Kind == KindString {
+			s := qtypes.StarString(arg)
+			r.t, r.values = s.Type, s.Values
+		}
+	default:
+		r.t = map[string]qtypes.QueryType{
+			"<":  qtypes.QueryType_LESS,
+			"<=": qtypes.QueryType_LESS_EQUAL,
+			">":  qtypes.QueryType_GREATER,
+			">=": qtypes.QueryType_GREATER_EQUAL,
+		}[comparator]
+		r.values = []string{unescape(arg)}
+	}
+
+	if err := validate(field.Kind, r.values[0]); err != nil {
+		return restriction{}, p.errorf(argPos, "invalid argument %q: %s", arg, err.Error())
+	}
+	return r, nil
+}
+
+func (p *parser) comparator() string {
+	rest := p.src[p.pos:]
+	for _, c := range []string{"<=", ">=", "!=", "<", ">", "=", ":"} {
+		if strings.HasPrefix(rest, c) {
+			p.pos += len(c)
+			return c
+		}
+	}
+	return ""
+}
+
+// argument returns the argument, with escapes of * and \ preserved, and reports whether it was quoted.
+func (p *parser) argument() (string, bool, error) {
+	start := p.pos
+	if p.pos == len(p.src) || (p.src[p.pos] != '"' && p.src[p.pos] != '\'') {
+		for p.pos < len(p.src) && !isSpace(p.src[p.pos]) && strings.IndexByte("()\"'", p.src[p.pos]) < 0 {
+			p.pos++
+		}
+		if p.pos == start {
+			return "", false, p.errorf(start, "expected argument")
+		}
+		return p.src[start:p.pos], false, nil
+	}
+
+	quote := p.src[p.pos]
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.pos == len(p.src) {
+			return "", false, p.errorf(start, "unterminated string")
+		}
+		c := p.src[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return b.String(), true, nil
+		case c == '\\':
+			if p.pos == len(p.src) {
+				return "", false, p.errorf(start, "unterminated string")
+			}
+			c = p.src[p.pos]
+			p.pos++
+			if c == '*' || c == '\\' {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteByte(c)
+	}
+}
+
+// unescape removes escapes of * and \, that arguments preserve.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func validate(k Kind, v string) error {
+	var err error
+	switch k {
+	case KindInt64:
+		_, err = strconv.ParseInt(v, 10, 64)
+	case KindUint64:
+		_, err = strconv.ParseUint(v, 10, 64)
+	case KindFloat64:
+		_, err = strconv.ParseFloat(v, 64)
+	case KindTimestamp:
+		_, err = time.Parse(time.RFC3339Nano, v)
+	case KindString:
+	default:
+		err = fmt.Errorf("unknown kind %d", k)
+	}
+	return err
+}
+
+// condition converts restriction to a condition, values are already validated.
+func (r restriction) condition() qtypes.Condition {
+	switch r.kind {
+	case KindInt64:
+		values := make([]int64, 0, len(r.values))
+		for _, v := range r.values {
+			n, _ := strconv.ParseInt(v, 10, 64)
+			values = append(values, n)
+		}
+		return &qtypes.Int64{Values: values, Valid: true, Negation: r.negation, Type: r.t, LowerExclusive: r.lowerExclusive, UpperExclusive: r.upperExclusive}
+	case KindUint64:
+		values := make([]uint64, 0, len(r.values))
+		for _, v := range r.values {
+			n, _ := strconv.ParseUint(v, 10, 64)
+			values = append(values, n)
+		}
+		return &qtypes.Uint64{Values: values, Valid: true, Negation: r.negation, Type: r.t, LowerExclusive: r.lowerExclusive, UpperExclusive: r.upperExclusive}
+	case KindFloat64:
+		values := make([]float64, 0, len(r.values))
+		for _, v := range r.values {
+			f, _ := strconv.ParseFloat(v, 64)
+			values = append(values, f)
+		}
+		return &qtypes.Float64{Values: values, Valid: true, Negation: r.negation, Type: r.t, LowerExclusive: r.lowerExclusive, UpperExclusive: r.upperExclusive}
+	case KindTimestamp:
+		values := make([]*knowntimestamp.Timestamp, 0, len(r.values))
+		for _, v := range r.values {
+			t, _ := time.Parse(time.RFC3339Nano, v)
+			values = append(values, knowntimestamp.New(t))
+		}
+		return &qtypes.Timestamp{Values: values, Valid: true, Negation: r.negation, Type: r.t, LowerExclusive: r.lowerExclusive, UpperExclusive: r.upperExclusive}
+	default:
+		return &qtypes.String{Values: r.values, Valid: true, Negation: r.negation, Type: r.t}
+	}
+}
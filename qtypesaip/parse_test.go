@@ -0,0 +1,203 @@
+package qtypesaip_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesaip"
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var schema = qtypesaip.Schema{
+	"age":         {Kind: qtypesaip.KindInt64},
+	"id":          {Kind: qtypesaip.KindUint64},
+	"score":       {Kind: qtypesaip.KindFloat64},
+	"create_time": {Kind: qtypesaip.KindTimestamp},
+	"name":        {Kind: qtypesaip.KindString},
+	"author.name": {Kind: qtypesaip.KindString},
+	"tags":        {Kind: qtypesaip.KindString, Repeated: true},
+	"ids":         {Kind: qtypesaip.KindInt64, Repeated: true},
+}
+
+func assertFilter(t *testing.T, hint string, expected, got qtypesaip.Filter) {
+	t.Helper()
+
+	if len(expected) != len(got) {
+		t.Errorf("%s: wrong number of fields, expected %d but got %d: %v", hint, len(expected), len(got), got)
+		return
+	}
+	for field, conditions := range expected {
+		if len(conditions) != len(got[field]) {
+			t.Errorf("%s: wrong number of conditions of field %q, expected %d but got %d: %v", hint, field, len(conditions), len(got[field]), got[field])
+			continue
+		}
+		for i, c := range conditions {
+			if !proto.Equal(c, got[field][i]) {
+				t.Errorf("%s: wrong condition %d of field %q,\nexpected:\n	%v\nbut got:\n	%v\n", hint, i, field, c, got[field][i])
+			}
+		}
+	}
+}
+
+func TestSchema_Parse(t *testing.T) {
+	created := knowntimestamp.New(time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		given    string
+		expected qtypesaip.Filter
+	}{
+		"example": {
+			given:    `age >= 18 AND name:"jo*"`,
+			expected: qtypesaip.Filter{"age": {qtypes.GreaterEqualInt64(18)}, "name": {qtypes.HasPrefixString("jo")}},
+		},
+		"empty": {
+			given:    "  ",
+			expected: qtypesaip.Filter{},
+		},
+		"sequence": {
+			given:    `age>18 name="john"`,
+			expected: qtypesaip.Filter{"age": {qtypes.GreaterInt64(18)}, "name": {qtypes.EqualString("john")}},
+		},
+		"not-equal": {
+			given:    `id != 18446744073709551615`,
+			expected: qtypesaip.Filter{"id": {&qtypes.Uint64{Values: []uint64{18446744073709551615}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL}}},
+		},
+		"negation": {
+			given: `NOT age < 18 AND -name = "*.com"`,
+			expected: qtypesaip.Filter{
+				"age":  {&qtypes.Int64{Values: []int64{18}, Valid: true, Negation: true, Type: qtypes.QueryType_LESS}},
+				"name": {&qtypes.String{Values: []string{".com"}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_SUFFIX}},
+			},
+		},
+		"in": {
+			given:    `name = "a" OR name = 'b' OR name = "c\*"`,
+			expected: qtypesaip.Filter{"name": {&qtypes.String{Values: []string{"a", "b", "c*"}, Valid: true, Type: qtypes.QueryType_IN}}},
+		},
+		"or-binds-stronger": {
+			given: `age = 1 OR age = 2 AND score > 0.5`,
+			expected: qtypesaip.Filter{
+				"age":   {qtypes.InInt64(1, 2)},
+				"score": {&qtypes.Float64{Values: []float64{0.5}, Valid: true, Type: qtypes.QueryType_GREATER}},
+			},
+		},
+		"not-in": {
+			given:    `NOT (age = 1 OR age = 2)`,
+			expected: qtypesaip.Filter{"age": {&qtypes.Int64{Values: []int64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}}},
+		},
+		"between": {
+			given: `(age > 1 AND age <= 5) AND -(score >= 0.5 AND score <= 1.5)`,
+			expected: qtypesaip.Filter{
+				"age":   {qtypes.RangeInt64(1, 5, true, false)},
+				"score": {&qtypes.Float64{Values: []float64{0.5, 1.5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}},
+			},
+		},
+		"range-without-parentheses": {
+			given:    `age > 1 AND age <= 5`,
+			expected: qtypesaip.Filter{"age": {qtypes.GreaterInt64(1), qtypes.LessEqualInt64(5)}},
+		},
+		"string-exclusive-range": {
+			given:    `(name > "a" AND name < "f")`,
+			expected: qtypesaip.Filter{"name": {qtypes.GreaterString("a"), qtypes.LessString("f")}},
+		},
+		"timestamp": {
+			given: `create_time < 2024-05-01T12:00:00Z AND create_time >= "2024-05-01T12:00:00Z"`,
+			expected: qtypesaip.Filter{"create_time": {
+				&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{created}, Valid: true, Type: qtypes.QueryType_LESS},
+				&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{created}, Valid: true, Type: qtypes.QueryType_GREATER_EQUAL},
+			}},
+		},
+		"presence": {
+			given:    `name:* AND NOT age:*`,
+			expected: qtypesaip.Filter{"name": {&qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}}, "age": {qtypes.NullInt64()}},
+		},
+		"nested-field": {
+			given:    `author.name = "*oh*"`,
+			expected: qtypesaip.Filter{"author.name": {qtypes.SubString("oh")}},
+		},
+		"wildcard": {
+			given:    `name = "j*n?"`,
+			expected: qtypesaip.Filter{"name": {qtypes.WildcardString(`j*n\?`)}},
+		},
+		"repeated": {
+			given: `tags:"a*" AND (tags:x OR tags:y) AND (ids:1 AND ids:2) AND NOT ids:3`,
+			expected: qtypesaip.Filter{
+				"tags": {
+					&qtypes.String{Values: []string{"a*"}, Valid: true, Type: qtypes.QueryType_HAS_ELEMENT},
+					&qtypes.String{Values: []string{"x", "y"}, Valid: true, Type: qtypes.QueryType_HAS_ANY_ELEMENT},
+				},
+				"ids": {
+					&qtypes.Int64{Values: []int64{1, 2}, Valid: true, Type: qtypes.QueryType_HAS_ALL_ELEMENTS},
+					&qtypes.Int64{Values: []int64{3}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_ELEMENT},
+				},
+			},
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := schema.Parse(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		assertFilter(t, hint, c.expected, got)
+	}
+}
+
+func TestSchema_Parse_error(t *testing.T) {
+	cases := map[string]struct {
+		given       string
+		pos         int
+		unsupported bool
+	}{
+		"unknown-field":        {given: "age = 1 AND email = 1", pos: 12},
+		"missing-argument":     {given: "age =", pos: 5},
+		"invalid-value":        {given: "age = x", pos: 6},
+		"invalid-timestamp":    {given: `create_time > "yesterday"`, pos: 14},
+		"unterminated-string":  {given: `name = "abc`, pos: 7},
+		"unclosed-parenthesis": {given: "(age = 1", pos: 0},
+		"trailing":             {given: "age = 1)", pos: 7},
+		"dangling-and":         {given: "age = 1 AND", pos: 11},
+		"empty-parentheses":    {given: "()", pos: 1},
+		"function":             {given: `regex(name, "^j")`, pos: 0, unsupported: true},
+		"traversal":            {given: `author.email = "a"`, pos: 0, unsupported: true},
+		"global-restriction":   {given: `age = 1 "john"`, pos: 8, unsupported: true},
+		"bare-word":            {given: `john`, pos: 0, unsupported: true},
+		"or-of-fields":         {given: "age = 1 OR score = 1", pos: 8, unsupported: true},
+		"or-of-ranges":         {given: "age < 1 OR age > 5", pos: 8, unsupported: true},
+		"or-of-and":            {given: "age = 1 OR (age = 2 AND age = 3)", pos: 8, unsupported: true},
+		"or-of-sequence":       {given: "age = 1 OR (age = 2 age = 3)", pos: 8, unsupported: true},
+		"not-of-many":          {given: "NOT (age = 1 AND score = 1)", pos: 0, unsupported: true},
+		"composite-argument":   {given: "age = (1 OR 2)", pos: 6, unsupported: true},
+		"repeated-equality":    {given: "tags = a", pos: 5, unsupported: true},
+	}
+
+	for hint, c := range cases {
+		_, err := schema.Parse(c.given)
+		if err == nil {
+			t.Errorf("%s: expected error", hint)
+			continue
+		}
+		var pos int
+		if c.unsupported {
+			var ue *qtypesaip.UnsupportedError
+			if !errors.As(err, &ue) || !errors.Is(err, qtypesaip.ErrUnsupportedFilter) {
+				t.Errorf("%s: expected *UnsupportedError, got: %s", hint, err.Error())
+				continue
+			}
+			pos = ue.Pos
+		} else {
+			var pe *qtypesaip.ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, qtypesaip.ErrInvalidFilter) {
+				t.Errorf("%s: expected *ParseError, got: %s", hint, err.Error())
+				continue
+			}
+			pos = pe.Pos
+		}
+		if pos != c.pos {
+			t.Errorf("%s: wrong position, expected %d but got %d: %s", hint, c.pos, pos, err.Error())
+		}
+	}
+}
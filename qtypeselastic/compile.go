@@ -0,0 +1,240 @@
+package qtypeselastic
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// cond is a condition of any kind, with values ready to be encoded as JSON.
+type cond struct {
+	t              qtypes.QueryType
+	negation       bool
+	insensitive    bool
+	lowerExclusive bool
+	upperExclusive bool
+	values         []interface{}
+	// texts are values of string conditions, they are nil for other types.
+	texts []string
+}
+
+// Compile returns query that matches documents, whose given field satisfies given condition.
+// It returns nil if the condition is not valid.
+//
+// PATTERN values have to be part of the portable subset, see qtypes.ValidatePattern. They are translated
+// to Lucene regular expressions, that have to match the whole value, so anchors ^ and $ are supported
+// only where they can be dropped, e.g. in ^ab|c$, but not in a(^b).
+func Compile(field string, c qtypes.Condition) (map[string]interface{}, error) {
+	if c == nil || !c.GetValid() {
+		return nil, nil
+	}
+
+	var cc cond
+	switch v := c.(type) {
+	case *qtypes.Int64:
+		cc = cond{lowerExclusive: v.LowerExclusive, upperExclusive: v.UpperExclusive}
+		for _, n := range v.Values {
+			cc.values = append(cc.values, n)
+		}
+	case *qtypes.Uint64:
+		cc = cond{lowerExclusive: v.LowerExclusive, upperExclusive: v.UpperExclusive}
+		for _, n := range v.Values {
+			cc.values = append(cc.values, n)
+		}
+	case *qtypes.Float64:
+		cc = cond{lowerExclusive: v.LowerExclusive, upperExclusive: v.UpperExclusive}
+		for i, f := range v.Values {
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("value %v at position %d cannot be expressed", f, i)
+			}
+			cc.values = append(cc.values, f)
+		}
+	case *qtypes.Timestamp:
+		cc = cond{lowerExclusive: v.LowerExclusive, upperExclusive: v.UpperExclusive}
+		for i, t := range v.Values {
+			if t == nil {
+				return nil, fmt.Errorf("nil timestamp at position %d", i)
+			}
+			cc.values = append(cc.values, t.AsTime().UTC().Format(time.RFC3339Nano))
+		}
+	case *qtypes.String:
+		cc = cond{insensitive: v.Insensitive, texts: v.Values}
+		if cc.texts == nil {
+			cc.texts = []string{}
+		}
+		for _, s := range v.Values {
+			cc.values = append(cc.values, s)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported condition type %T", c)
+	}
+	cc.t, cc.negation = c.GetType(), c.GetNegation()
+	return compile(field, cc)
+}
+
+func compile(field string, c cond) (map[string]interface{}, error) {
+	count := func(n int) error {
+		if len(c.values) != n {
+			return fmt.Errorf("%s condition requires %d value(s) but got %d", c.t, n, len(c.values))
+		}
+		return nil
+	}
+	textual := func() error {
+		if c.texts == nil {
+			return fmt.Errorf("%s condition is supported only for strings", c.t)
+		}
+		return count(1)
+	}
+	if c.insensitive {
+		switch c.t {
+		case qtypes.QueryType_GREATER, qtypes.QueryType_GREATER_EQUAL, qtypes.QueryType_LESS, qtypes.QueryType_LESS_EQUAL, qtypes.QueryType_BETWEEN:
+			return nil, fmt.Errorf("case insensitive %s cannot be expressed", c.t)
+		}
+	}
+
+	var q map[string]interface{}
+	switch c.t {
+	case qtypes.QueryType_NULL:
+		q = map[string]interface{}{"exists": map[string]interface{}{"field": field}}
+		if c.negation {
+			return q, nil
+		}
+		return boolQuery("must_not", q), nil
+	case qtypes.QueryType_EQUAL, qtypes.QueryType_HAS_ELEMENT:
+		if err := count(1); err != nil {
+			return nil, err
+		}
+		q = leaf("term", field, c.values[0], c.insensitive)
+	case qtypes.QueryType_IN, qtypes.QueryType_HAS_ANY_ELEMENT, qtypes.QueryType_OVERLAP:
+		q = terms(field, c.values, c.insensitive)
+	case qtypes.QueryType_CONTAINS, qtypes.QueryType_HAS_ALL_ELEMENTS:
+		if len(c.values) == 0 {
+			return nil, fmt.Errorf("%s condition requires at least one value", c.t)
+		}
+		clauses := make([]interface{}, 0, len(c.values))
+		for _, v := range c.values {
+			clauses = append(clauses, leaf("term", field, v, c.insensitive))
+		}
+		q = boolQuery("filter", clauses...)
+	case qtypes.QueryType_GREATER, qtypes.QueryType_GREATER_EQUAL, qtypes.QueryType_LESS, qtypes.QueryType_LESS_EQUAL:
+		if err := count(1); err != nil {
+			return nil, err
+		}
+		op := map[qtypes.QueryType]string{
+			qtypes.QueryType_GREATER:       "gt",
+			qtypes.QueryType_GREATER_EQUAL: "gte",
+			qtypes.QueryType_LESS:          "lt",
+			qtypes.QueryType_LESS_EQUAL:    "lte",
+		}[c.t]
+		q = rangeQuery(field, map[string]interface{}{op: c.values[0]})
+	case qtypes.QueryType_BETWEEN:
+		if err := count(2); err != nil {
+			return nil, err
+		}
+		lower, upper := "gte", "lte"
+		if c.lowerExclusive {
+			lower = "gt"
+		}
+		if c.upperExclusive {
+			upper = "lt"
+		}
+		q = rangeQuery(field, map[string]interface{}{lower: c.values[0], upper: c.values[1]})
+	case qtypes.QueryType_HAS_PREFIX:
+		if err := textual(); err != nil {
+			return nil, err
+		}
+		q = leaf("prefix", field, c.texts[0], c.insensitive)
+	case qtypes.QueryType_HAS_SUFFIX, qtypes.QueryType_SUBSTRING, qtypes.QueryType_WILDCARD:
+		if err := textual(); err != nil {
+			return nil, err
+		}
+		pattern := c.texts[0]
+		switch c.t {
+		case qtypes.QueryType_HAS_SUFFIX:
+			pattern = "*" + escapeWildcard(pattern)
+		case qtypes.QueryType_SUBSTRING:
+			pattern = "*" + escapeWildcard(pattern) + "*"
+		default:
+			// Trailing backslash matches itself, see qtypes.MatchWildcard.
+			if trailing := len(pattern) - len(strings.TrimRight(pattern, `\`)); trailing%2 == 1 {
+				pattern += `\`
+			}
+		}
+		q = leaf("wildcard", field, pattern, c.insensitive)
+	case qtypes.QueryType_PATTERN:
+		if err := textual(); err != nil {
+			return nil, err
+		}
+		expr, err := translatePattern(c.texts[0])
+		if err != nil {
+			return nil, err
+		}
+		q = leaf("regexp", field, expr, c.insensitive)
+	case qtypes.QueryType_MIN_LENGTH, qtypes.QueryType_MAX_LENGTH:
+		if err := textual(); err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseUint(c.texts[0], 10, 63)
+		if err != nil {
+			return nil, fmt.Errorf("%s condition requires a number: %w", c.t, err)
+		}
+		expr := fmt.Sprintf(".{%d,}", n)
+		if c.t == qtypes.QueryType_MAX_LENGTH {
+			expr = fmt.Sprintf(".{0,%d}", n)
+		}
+		q = leaf("regexp", field, expr, false)
+	default:
+		return nil, fmt.Errorf("%s cannot be expressed", c.t)
+	}
+
+	if c.negation {
+		return boolQuery("must_not", q), nil
+	}
+	return q, nil
+}
+
+// leaf returns term level query of given kind, e.g. {"term": {"field": {"value": "v"}}}.
+func leaf(kind, field string, value interface{}, insensitive bool) map[string]interface{} {
+	params := map[string]interface{}{"value": value}
+	if insensitive {
+		params["case_insensitive"] = true
+	}
+	return map[string]interface{}{kind: map[string]interface{}{field: params}}
+}
+
+// terms returns terms query, or bool query of case insensitive term queries, as terms query does not support them.
+func terms(field string, values []interface{}, insensitive bool) map[string]interface{} {
+	if values == nil {
+		values = []interface{}{}
+	}
+	if !insensitive {
+		return map[string]interface{}{"terms": map[string]interface{}{field: values}}
+	}
+	clauses := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		clauses = append(clauses, leaf("term", field, v, true))
+	}
+	q := boolQuery("should", clauses...)
+	q["bool"].(map[string]interface{})["minimum_should_match"] = 1
+	return q
+}
+
+func rangeQuery(field string, params map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"range": map[string]interface{}{field: params}}
+}
+
+// escapeWildcard escapes characters that are special in wildcard query.
+func escapeWildcard(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(`*?\`, s[i]) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
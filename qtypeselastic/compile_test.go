@@ -0,0 +1,96 @@
+package qtypeselastic_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeselastic"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCompile(t *testing.T) {
+	cases := map[string]struct {
+		given    qtypes.Condition
+		expected string
+	}{
+		"null":              {given: qtypes.NullString(), expected: `{"bool":{"must_not":[{"exists":{"field":"f"}}]}}`},
+		"not-null":          {given: &qtypes.Int64{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}, expected: `{"exists":{"field":"f"}}`},
+		"equal":             {given: qtypes.EqualInt64(1), expected: `{"term":{"f":{"value":1}}}`},
+		"not-equal":         {given: qtypes.NotEqualInt64(1), expected: `{"bool":{"must_not":[{"term":{"f":{"value":1}}}]}}`},
+		"equal-insensitive": {given: qtypes.EqualStringInsensitive("Jo"), expected: `{"term":{"f":{"case_insensitive":true,"value":"Jo"}}}`},
+		"uint64":            {given: &qtypes.Uint64{Values: []uint64{math.MaxUint64}, Valid: true, Type: qtypes.QueryType_HAS_ELEMENT}, expected: `{"term":{"f":{"value":18446744073709551615}}}`},
+		"in":                {given: qtypes.InInt64(1, 2), expected: `{"terms":{"f":[1,2]}}`},
+		"in-empty":          {given: &qtypes.String{Valid: true, Type: qtypes.QueryType_IN}, expected: `{"terms":{"f":[]}}`},
+		"in-insensitive":    {given: qtypes.InStringInsensitive("a", "b"), expected: `{"bool":{"minimum_should_match":1,"should":[{"term":{"f":{"case_insensitive":true,"value":"a"}}},{"term":{"f":{"case_insensitive":true,"value":"b"}}}]}}`},
+		"contains":          {given: &qtypes.String{Values: []string{"a", "b"}, Valid: true, Type: qtypes.QueryType_CONTAINS}, expected: `{"bool":{"filter":[{"term":{"f":{"value":"a"}}},{"term":{"f":{"value":"b"}}}]}}`},
+		"greater":           {given: qtypes.GreaterInt64(1), expected: `{"range":{"f":{"gt":1}}}`},
+		"less-equal":        {given: &qtypes.Float64{Values: []float64{1.5}, Valid: true, Type: qtypes.QueryType_LESS_EQUAL}, expected: `{"range":{"f":{"lte":1.5}}}`},
+		"between":           {given: qtypes.BetweenString("a", "f"), expected: `{"range":{"f":{"gte":"a","lte":"f"}}}`},
+		"between-exclusive": {given: qtypes.RangeInt64(1, 5, true, false), expected: `{"range":{"f":{"gt":1,"lte":5}}}`},
+		"prefix":            {given: qtypes.HasPrefixString("jo"), expected: `{"prefix":{"f":{"value":"jo"}}}`},
+		"suffix":            {given: qtypes.HasSuffixString("*.com"), expected: `{"wildcard":{"f":{"value":"*\\*.com"}}}`},
+		"substring":         {given: qtypes.SubString(`a?\`), expected: `{"wildcard":{"f":{"value":"*a\\?\\\\*"}}}`},
+		"wildcard":          {given: qtypes.WildcardString(`jo*n?\`), expected: `{"wildcard":{"f":{"value":"jo*n?\\\\"}}}`},
+		"wildcard-escaped":  {given: qtypes.WildcardString(`a\\`), expected: `{"wildcard":{"f":{"value":"a\\\\"}}}`},
+		"pattern":           {given: &qtypes.String{Values: []string{"^jo"}, Valid: true, Negation: true, Type: qtypes.QueryType_PATTERN, Insensitive: true}, expected: `{"bool":{"must_not":[{"regexp":{"f":{"case_insensitive":true,"value":"jo.*"}}}]}}`},
+		"min-length":        {given: &qtypes.String{Values: []string{"2"}, Valid: true, Type: qtypes.QueryType_MIN_LENGTH}, expected: `{"regexp":{"f":{"value":".{2,}"}}}`},
+		"max-length":        {given: &qtypes.String{Values: []string{"10"}, Valid: true, Type: qtypes.QueryType_MAX_LENGTH}, expected: `{"regexp":{"f":{"value":".{0,10}"}}}`},
+	}
+
+	for hint, c := range cases {
+		q, err := qtypeselastic.Compile("f", c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		got, err := json.Marshal(q)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if string(got) != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%s\nbut got:\n	%s\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestCompile_invalid(t *testing.T) {
+	for hint, given := range map[string]qtypes.Condition{
+		"nil":                 nil,
+		"nil-pointer":         (*qtypes.String)(nil),
+		"invalid":             &qtypes.Int64{Values: []int64{1}, Type: qtypes.QueryType_EQUAL},
+		"invalid-unsupported": &qtypes.Int64{Type: qtypes.QueryType_IS_CONTAINED_BY},
+	} {
+		q, err := qtypeselastic.Compile("f", given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if q != nil {
+			t.Errorf("%s: expected nil query but got: %v", hint, q)
+		}
+	}
+}
+
+func TestCompile_error(t *testing.T) {
+	cases := map[string]qtypes.Condition{
+		"is-contained-by":     &qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_IS_CONTAINED_BY},
+		"prefix-of-int":       &qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_HAS_PREFIX},
+		"equal-of-many":       &qtypes.Int64{Values: []int64{1, 2}, Valid: true, Type: qtypes.QueryType_EQUAL},
+		"between-of-one":      &qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_BETWEEN},
+		"contains-empty":      &qtypes.String{Valid: true, Type: qtypes.QueryType_CONTAINS},
+		"range-insensitive":   &qtypes.String{Values: []string{"a"}, Valid: true, Type: qtypes.QueryType_GREATER, Insensitive: true},
+		"nan":                 &qtypes.Float64{Values: []float64{math.NaN()}, Valid: true, Type: qtypes.QueryType_EQUAL},
+		"nil-timestamp":       &qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{nil}, Valid: true, Type: qtypes.QueryType_EQUAL},
+		"min-length-not-int":  &qtypes.String{Values: []string{"a"}, Valid: true, Type: qtypes.QueryType_MIN_LENGTH},
+		"unsupported-pattern": &qtypes.String{Values: []string{`\bword`}, Valid: true, Type: qtypes.QueryType_PATTERN},
+	}
+
+	for hint, given := range cases {
+		if _, err := qtypeselastic.Compile("f", given); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Package qtypeselastic compiles conditions into Elasticsearch and OpenSearch query DSL,
+// e.g. {"bool": {"filter": [{"range": {"age": {"gte": 18}}}, {"prefix": {"name": {"value": "jo"}}}]}}.
+//
+// Conditions map to queries as follows:
+//   - NULL to exists, in must_not unless negated,
+//   - EQUAL and HAS_ELEMENT to term, IN, HAS_ANY_ELEMENT and OVERLAP to terms,
+//   - CONTAINS and HAS_ALL_ELEMENTS to term per value,
+//   - GREATER, GREATER_EQUAL, LESS, LESS_EQUAL and BETWEEN to range,
+//   - HAS_PREFIX to prefix, HAS_SUFFIX, SUBSTRING and WILDCARD to wildcard,
+//   - PATTERN, MIN_LENGTH and MAX_LENGTH to regexp, see Compile for limitations of PATTERN.
+//
+// Negated conditions are wrapped in bool must_not. Like in Elasticsearch, they are satisfied by documents
+// that do not have the field. Case insensitive conditions rely on case_insensitive parameter,
+// which requires Elasticsearch 7.10 or OpenSearch. IS_CONTAINED_BY cannot be expressed.
+//
+// Queries are plain maps, ready to be encoded as JSON. Timestamps are formatted in RFC3339 format, in UTC.
+package qtypeselastic
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Filter is a set of conditions keyed by field name, that have to be satisfied all together.
+type Filter map[string][]qtypes.Condition
+
+// Query returns query that matches documents that satisfy all conditions of the filter. Invalid conditions are skipped.
+// Multiple queries are combined in filter context of bool query, as they do not contribute to the score.
+// If there is nothing to filter by, match_all query is returned.
+func (f Filter) Query() (map[string]interface{}, error) {
+	var clauses []interface{}
+	for _, field := range slices.Sorted(maps.Keys(f)) {
+		for i, c := range f[field] {
+			q, err := Compile(field, c)
+			if err != nil {
+				return nil, fmt.Errorf("qtypes: query compilation error for condition %d of field %q: %w", i, field, err)
+			}
+			if q != nil {
+				clauses = append(clauses, q)
+			}
+		}
+	}
+
+	switch len(clauses) {
+	case 0:
+		return map[string]interface{}{"match_all": map[string]interface{}{}}, nil
+	case 1:
+		return clauses[0].(map[string]interface{}), nil
+	default:
+		return boolQuery("filter", clauses...), nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface, it encodes the query that Query returns.
+func (f Filter) MarshalJSON() ([]byte, error) {
+	q, err := f.Query()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(q)
+}
+
+func boolQuery(occur string, clauses ...interface{}) map[string]interface{} {
+	return map[string]interface{}{"bool": map[string]interface{}{occur: clauses}}
+}
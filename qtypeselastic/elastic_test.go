@@ -0,0 +1,80 @@
+package qtypeselastic_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeselastic"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+func TestFilter_MarshalJSON(t *testing.T) {
+	created := knowntimestamp.New(time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC))
+
+	cases := map[string]qtypeselastic.Filter{
+		"empty": {},
+		"single": {
+			"age": {qtypes.GreaterEqualInt64(18)},
+		},
+		"combined": {
+			"age":        {qtypes.RangeInt64(18, 65, false, true)},
+			"name":       {qtypes.HasPrefixString("jo"), &qtypes.String{Values: []string{"john"}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL}},
+			"email":      {&qtypes.String{Values: []string{`@example\.(com|org)$`}, Valid: true, Type: qtypes.QueryType_PATTERN, Insensitive: true}},
+			"created_at": {&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{created}, Valid: true, Type: qtypes.QueryType_LESS}},
+			"deleted_at": {qtypes.NullString()},
+			"tags":       {&qtypes.String{Values: []string{"go", "search"}, Valid: true, Type: qtypes.QueryType_HAS_ANY_ELEMENT}},
+			"skipped":    {&qtypes.Int64{Values: []int64{1}}},
+		},
+		"negated": {
+			"status": {&qtypes.Uint64{Values: []uint64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}},
+			"title":  {&qtypes.String{Values: []string{"draft"}, Valid: true, Negation: true, Type: qtypes.QueryType_SUBSTRING, Insensitive: true}},
+			"score":  {&qtypes.Float64{Values: []float64{0.5, 1}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}},
+		},
+	}
+
+	for hint, given := range cases {
+		b, err := json.MarshalIndent(given, "", "  ")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		b = append(b, '\n')
+
+		golden := filepath.Join("testdata", hint+".golden")
+		if *update {
+			if err := os.WriteFile(golden, b, 0o644); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			continue
+		}
+		expected, err := os.ReadFile(golden)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if !bytes.Equal(b, expected) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%s\nbut got:\n	%s\n", hint, expected, b)
+		}
+	}
+}
+
+func TestFilter_Query_error(t *testing.T) {
+	given := qtypeselastic.Filter{
+		"age":  {qtypes.GreaterInt64(1)},
+		"tags": {&qtypes.String{Values: []string{"a"}, Valid: true, Type: qtypes.QueryType_IS_CONTAINED_BY}},
+	}
+	if _, err := given.Query(); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := json.Marshal(given); err == nil {
+		t.Fatal("expected error")
+	}
+}
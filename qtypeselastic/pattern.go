@@ -0,0 +1,256 @@
+package qtypeselastic
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// translatePattern returns Lucene regular expression, that matches whole values that given PATTERN value matches any part of.
+func translatePattern(pattern string) (string, error) {
+	if err := qtypes.ValidatePattern(pattern); err != nil {
+		return "", err
+	}
+	// Any character matches a new line as well, see qtypes.ValidatePattern.
+	re, err := syntax.Parse(pattern, syntax.Perl|syntax.DotNL)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := unanchored(&b, re, edgeFree, edgeFree); err != nil {
+		return "", &qtypes.PatternError{Pattern: pattern, Reason: err.Error()}
+	}
+	return b.String(), nil
+}
+
+// edge describes what is next to an expression on one of its sides.
+type edge int
+
+const (
+	// edgeFree means that anything can be next to the expression, unless it is anchored on that side.
+	edgeFree edge = iota
+	// edgeText means that the expression is at the beginning or at the end of the text.
+	edgeText
+	// edgeExpr means that another expression is next to the expression.
+	edgeExpr
+)
+
+// unanchored writes expression that matches what given expression does, with anything before or after it
+// on free sides. Anchors on free sides are dropped, as they stop the expression from being extended.
+func unanchored(b *strings.Builder, re *syntax.Regexp, left, right edge) error {
+	switch re.Op {
+	case syntax.OpBeginText:
+		if left == edgeExpr {
+			return errAnchor
+		}
+		pad(b, right == edgeFree)
+		return nil
+	case syntax.OpEndText:
+		if right == edgeExpr {
+			return errAnchor
+		}
+		pad(b, left == edgeFree)
+		return nil
+	case syntax.OpEmptyMatch:
+		pad(b, left == edgeFree || right == edgeFree)
+		return nil
+	case syntax.OpAlternate:
+		for i, sub := range re.Sub {
+			if i > 0 {
+				b.WriteByte('|')
+			}
+			if err := unanchored(b, sub, left, right); err != nil {
+				return err
+			}
+		}
+		return nil
+	case syntax.OpCapture:
+		b.WriteByte('(')
+		if err := unanchored(b, re.Sub[0], left, right); err != nil {
+			return err
+		}
+		b.WriteByte(')')
+		return nil
+	case syntax.OpConcat:
+		subs := re.Sub
+		for len(subs) > 1 && subs[0].Op == syntax.OpBeginText && left != edgeExpr {
+			subs, left = subs[1:], edgeText
+		}
+		for len(subs) > 1 && subs[len(subs)-1].Op == syntax.OpEndText && right != edgeExpr {
+			subs, right = subs[:len(subs)-1], edgeText
+		}
+		if len(subs) == 1 {
+			return unanchored(b, subs[0], left, right)
+		}
+		first, middle, last := subs[0], subs[1:len(subs)-1], subs[len(subs)-1]
+		if err := grouped(b, first, func(b *strings.Builder) error {
+			return unanchored(b, first, left, edgeExpr)
+		}); err != nil {
+			return err
+		}
+		for _, sub := range middle {
+			if err := grouped(b, sub, func(b *strings.Builder) error {
+				return lucene(b, sub)
+			}); err != nil {
+				return err
+			}
+		}
+		return grouped(b, last, func(b *strings.Builder) error {
+			return unanchored(b, last, edgeExpr, right)
+		})
+	default:
+		pad(b, left == edgeFree)
+		if err := lucene(b, re); err != nil {
+			return err
+		}
+		pad(b, right == edgeFree)
+		return nil
+	}
+}
+
+var errAnchor = errors.New("anchors are supported only at the beginning and at the end")
+
+// lucene writes given expression in Lucene syntax. Expression cannot contain anchors.
+func lucene(b *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		b.WriteString("()")
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			literal(b, r)
+		}
+	case syntax.OpCharClass:
+		class(b, re.Rune)
+	case syntax.OpAnyChar:
+		b.WriteByte('.')
+	case syntax.OpCapture:
+		b.WriteByte('(')
+		if err := lucene(b, re.Sub[0]); err != nil {
+			return err
+		}
+		b.WriteByte(')')
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		if err := atom(b, re.Sub[0]); err != nil {
+			return err
+		}
+		switch re.Op {
+		case syntax.OpStar:
+			b.WriteByte('*')
+		case syntax.OpPlus:
+			b.WriteByte('+')
+		case syntax.OpQuest:
+			b.WriteByte('?')
+		default:
+			switch {
+			case re.Max == re.Min:
+				fmt.Fprintf(b, "{%d}", re.Min)
+			case re.Max < 0:
+				fmt.Fprintf(b, "{%d,}", re.Min)
+			default:
+				fmt.Fprintf(b, "{%d,%d}", re.Min, re.Max)
+			}
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := grouped(b, sub, func(b *strings.Builder) error {
+				return lucene(b, sub)
+			}); err != nil {
+				return err
+			}
+		}
+	case syntax.OpAlternate:
+		for i, sub := range re.Sub {
+			if i > 0 {
+				b.WriteByte('|')
+			}
+			if err := lucene(b, sub); err != nil {
+				return err
+			}
+		}
+	case syntax.OpBeginText, syntax.OpEndText, syntax.OpBeginLine, syntax.OpEndLine:
+		return errAnchor
+	default:
+		return fmt.Errorf("%s cannot be expressed", re)
+	}
+	return nil
+}
+
+// atom writes given expression so that a repetition operator can follow it.
+func atom(b *strings.Builder, re *syntax.Regexp) error {
+	switch {
+	case re.Op == syntax.OpLiteral && len(re.Rune) == 1, re.Op == syntax.OpCharClass, re.Op == syntax.OpAnyChar, re.Op == syntax.OpCapture:
+		return lucene(b, re)
+	default:
+		b.WriteByte('(')
+		if err := lucene(b, re); err != nil {
+			return err
+		}
+		b.WriteByte(')')
+		return nil
+	}
+}
+
+// grouped calls fn and wraps its output in parentheses if given expression is an alternation,
+// so that it can be concatenated with other expressions.
+func grouped(b *strings.Builder, re *syntax.Regexp, fn func(*strings.Builder) error) error {
+	if re.Op != syntax.OpAlternate {
+		return fn(b)
+	}
+	b.WriteByte('(')
+	if err := fn(b); err != nil {
+		return err
+	}
+	b.WriteByte(')')
+	return nil
+}
+
+// pad writes expression that matches anything if ok is true.
+func pad(b *strings.Builder, ok bool) {
+	if ok {
+		b.WriteString(".*")
+	}
+}
+
+// luceneSpecial lists characters that have to be escaped in Lucene syntax, with all optional operators enabled.
+const luceneSpecial = `.?+*|{}[]()"\#@&<>~^$-`
+
+// literal writes given character, escaped if it is special in Lucene syntax.
+func literal(b *strings.Builder, r rune) {
+	if r < unicode.MaxASCII && strings.ContainsRune(luceneSpecial, r) {
+		b.WriteByte('\\')
+	}
+	b.WriteRune(r)
+}
+
+// class writes character class of given pairs of ranges. Classes that include the last character are complemented.
+func class(b *strings.Builder, ranges []rune) {
+	if len(ranges) == 2 && ranges[0] == 0 && ranges[1] == unicode.MaxRune {
+		b.WriteByte('.')
+		return
+	}
+	b.WriteByte('[')
+	if len(ranges) > 0 && ranges[len(ranges)-1] == unicode.MaxRune {
+		b.WriteByte('^')
+		var complement []rune
+		next := rune(0)
+		for i := 0; i < len(ranges); i += 2 {
+			if ranges[i] > next {
+				complement = append(complement, next, ranges[i]-1)
+			}
+			next = ranges[i+1] + 1
+		}
+		ranges = complement
+	}
+	for i := 0; i < len(ranges); i += 2 {
+		literal(b, ranges[i])
+		if ranges[i+1] != ranges[i] {
+			b.WriteByte('-')
+			literal(b, ranges[i+1])
+		}
+	}
+	b.WriteByte(']')
+}
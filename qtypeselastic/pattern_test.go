@@ -0,0 +1,92 @@
+package qtypeselastic_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeselastic"
+)
+
+func regexpValue(t *testing.T, q map[string]interface{}) string {
+	t.Helper()
+
+	return q["regexp"].(map[string]interface{})["name"].(map[string]interface{})["value"].(string)
+}
+
+func TestCompile_pattern(t *testing.T) {
+	cases := map[string]struct {
+		given    string
+		expected string
+	}{
+		"unanchored":    {given: "jo", expected: ".*jo.*"},
+		"prefix":        {given: "^jo", expected: "jo.*"},
+		"suffix":        {given: `\.com$`, expected: `.*\.com`},
+		"exact":         {given: "^a+b?$", expected: "a+b?"},
+		"empty":         {given: "", expected: ".*"},
+		"only-anchors":  {given: "^$", expected: ""},
+		"alternation":   {given: "^ab|c$", expected: "ab.*|.*c"},
+		"grouped":       {given: "^(ab|cd)e", expected: "(ab|cd)e.*"},
+		"grouped-start": {given: "(^ab|cd)e", expected: "(ab|.*cd)e.*"},
+		"begin-twice":   {given: "^^a$$", expected: "a"},
+		"classes":       {given: `[a-z_]\d[^0-9]\s`, expected: ".*[_a-z][0-9][^0-9][\t-\n\f-\r ].*"},
+		"posix-class":   {given: "[[:upper:]]{2,3}x{2}y{1,}", expected: ".*[A-Z]{2,3}x{2}y{1,}.*"},
+		"special":       {given: `\#\@\&\<\>\~"-/_`, expected: `.*\#\@\&\<\>\~\"\-/_.*`},
+		"any":           {given: "a.b", expected: ".*a.b.*"},
+		"repeat-group":  {given: "(ab)*c", expected: ".*(ab)*c.*"},
+	}
+
+	for hint, c := range cases {
+		q, err := qtypeselastic.Compile("name", &qtypes.String{Values: []string{c.given}, Valid: true, Type: qtypes.QueryType_PATTERN})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got := regexpValue(t, q); got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestCompile_patternSemantics(t *testing.T) {
+	patterns := []string{"", "^", "$", "^$", "a", "^a", "a$", "^a$", "a|b", "^a|b$", "(^a|b)c", "^(a|b)$", `\$`, "[^a]+", `\w+@\w+\.com$`, "a*", "(ab)+|^c", "(^ab|cd)e", "^^a$$", "a(b|c$)"}
+	values := []string{"", "a", "b", "ab", "ba", "bab", "ac", "bc", "c", "$", "abab", "cab", "x@y.com", "x@y.comz", "abe", "cde", "xcde", "xabe", "aa\nb", "\na"}
+
+	for _, p := range patterns {
+		q, err := qtypeselastic.Compile("name", &qtypes.String{Values: []string{p}, Valid: true, Type: qtypes.QueryType_PATTERN})
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", p, err.Error())
+			continue
+		}
+		expr := regexpValue(t, q)
+		// Constructs that the translation produces have the same meaning in Go syntax.
+		full, err := regexp.Compile(`^(?s:` + expr + `)$`)
+		if err != nil {
+			t.Errorf("%q: unexpected error for %q: %s", p, expr, err.Error())
+			continue
+		}
+		re, _ := qtypes.CompilePattern(p, false)
+		for _, v := range values {
+			if expected := re.MatchString(v); full.MatchString(v) != expected {
+				t.Errorf("%q: wrong output for value %q of expression %q, expected %t", p, v, expr, expected)
+			}
+		}
+	}
+}
+
+func TestCompile_patternError(t *testing.T) {
+	cases := map[string]string{
+		"inner-begin":  "a(^b)",
+		"inner-end":    "(a$)b",
+		"repeated":     "(^a)*",
+		"non-portable": "a(?:b)",
+	}
+
+	for hint, given := range cases {
+		_, err := qtypeselastic.Compile("name", &qtypes.String{Values: []string{given}, Valid: true, Type: qtypes.QueryType_PATTERN})
+		if !errors.Is(err, qtypes.ErrUnsupportedPattern) {
+			t.Errorf("%s: expected unsupported pattern error but got: %v", hint, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package qtypeshttp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+type operator struct {
+	t           qtypes.QueryType
+	negation    bool
+	insensitive bool
+}
+
+var operators = func() map[operator]string {
+	res := make(map[operator]string, len(prefixes))
+	for c := range prefixes {
+		t, n, i := queryType(c)
+		res[operator{t: t, negation: n, insensitive: i}] = c
+	}
+	return res
+}()
+
+// FormatInt64 returns textual representation of given Int64 object, that ParseInt64 understands.
+func FormatInt64(i *qtypes.Int64) (string, error) {
+	if i == nil || !i.Valid {
+		return "", nil
+	}
+	values := make([]string, 0, len(i.Values))
+	for _, v := range i.Values {
+		values = append(values, strconv.FormatInt(v, 10))
+	}
+	return format(operator{t: i.Type, negation: i.Negation}, values, i.LowerExclusive, i.UpperExclusive)
+}
+
+// FormatUint64 returns textual representation of given Uint64 object, that ParseUint64 understands.
+func FormatUint64(u *qtypes.Uint64) (string, error) {
+	if u == nil || !u.Valid {
+		return "", nil
+	}
+	values := make([]string, 0, len(u.Values))
+	for _, v := range u.Values {
+		values = append(values, strconv.FormatUint(v, 10))
+	}
+	return format(operator{t: u.Type, negation: u.Negation}, values, u.LowerExclusive, u.UpperExclusive)
+}
+
+// FormatFloat64 returns textual representation of given Float64 object, that ParseFloat64 understands.
+func FormatFloat64(f *qtypes.Float64) (string, error) {
+	if f == nil || !f.Valid {
+		return "", nil
+	}
+	values := make([]string, 0, len(f.Values))
+	for _, v := range f.Values {
+		values = append(values, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return format(operator{t: f.Type, negation: f.Negation}, values, f.LowerExclusive, f.UpperExclusive)
+}
+
+// FormatTimestamp returns textual representation of given Timestamp object, that ParseTimestamp understands.
+// Values are formatted in RFC3339 format, in UTC.
+func FormatTimestamp(t *qtypes.Timestamp) (string, error) {
+	if t == nil || !t.Valid {
+		return "", nil
+	}
+	values := make([]string, 0, len(t.Values))
+	for i, v := range t.Values {
+		if v == nil {
+			return "", fmt.Errorf("qtypes: query timestamp formatting error for value %d: nil timestamp", i)
+		}
+		values = append(values, v.AsTime().Format(time.RFC3339Nano))
+	}
+	return format(operator{t: t.Type, negation: t.Negation}, values, t.LowerExclusive, t.UpperExclusive)
+}
+
+// FormatString returns textual representation of given String object, that ParseString understands.
+// Values that contain array separator cannot be represented and cause an error.
+func FormatString(s *qtypes.String) (string, error) {
+	if s == nil || !s.Valid {
+		return "", nil
+	}
+	for i, v := range s.Values {
+		if strings.Contains(v, arraySeparator) {
+			return "", fmt.Errorf("qtypes: query string formatting error for value %d: value contains %q", i, arraySeparator)
+		}
+	}
+	return format(operator{t: s.Type, negation: s.Negation, insensitive: s.Insensitive}, s.Values, false, false)
+}
+
+func format(op operator, values []string, le, ue bool) (string, error) {
+	c, ok := operators[op]
+	if !ok {
+		return "", fmt.Errorf("qtypes: query formatting error: unsupported combination of type %s, negation %t and insensitivity %t", op.t, op.negation, op.insensitive)
+	}
+	if op.t == qtypes.QueryType_NULL {
+		return prefixes[c], nil
+	}
+	if op.t == qtypes.QueryType_BETWEEN {
+		if len(values) != 2 {
+			return "", fmt.Errorf("qtypes: query formatting error: between expects 2 values but got %d", len(values))
+		}
+		if le || ue {
+			return prefixes[c] + bracket(le, "(", "[") + values[0] + arraySeparator + values[1] + bracket(ue, ")", "]"), nil
+		}
+	}
+	return prefixes[c] + strings.Join(values, arraySeparator), nil
+}
+
+func bracket(exclusive bool, open, closed string) string {
+	if exclusive {
+		return open
+	}
+	return closed
+}
@@ -0,0 +1,211 @@
+package qtypeshttp_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeshttp"
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFormatInt64(t *testing.T) {
+	cases := map[string]struct {
+		given    *qtypes.Int64
+		expected string
+	}{
+		"nil": {
+			given:    nil,
+			expected: "",
+		},
+		"invalid": {
+			given:    &qtypes.Int64{Values: []int64{1}},
+			expected: "",
+		},
+		"null": {
+			given:    qtypes.NullInt64(),
+			expected: "null:",
+		},
+		"equal": {
+			given:    qtypes.EqualInt64(1),
+			expected: "eq:1",
+		},
+		"not-equal": {
+			given:    qtypes.NotEqualInt64(-1),
+			expected: "neq:-1",
+		},
+		"in": {
+			given:    qtypes.InInt64(1, 2, 3),
+			expected: "in:1,2,3",
+		},
+		"between": {
+			given:    qtypes.BetweenInt64(1, 5),
+			expected: "bw:1,5",
+		},
+		"between-half-open": {
+			given:    qtypes.RangeInt64(1, 5, false, true),
+			expected: "bw:[1,5)",
+		},
+		"between-open": {
+			given: &qtypes.Int64{
+				Values:         []int64{1, 5},
+				Valid:          true,
+				Negation:       true,
+				Type:           qtypes.QueryType_BETWEEN,
+				LowerExclusive: true,
+				UpperExclusive: true,
+			},
+			expected: "nbw:(1,5)",
+		},
+		"between-without-lower-bound": {
+			given:    qtypes.BetweenInt64(math.MinInt64, 5),
+			expected: "lte:5",
+		},
+		"greater": {
+			given:    qtypes.GreaterInt64(5),
+			expected: "gt:5",
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypeshttp.FormatInt64(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestFormatInt64_error(t *testing.T) {
+	cases := map[string]*qtypes.Int64{
+		"between-single-value": {
+			Values: []int64{1},
+			Valid:  true,
+			Type:   qtypes.QueryType_BETWEEN,
+		},
+		"negated-contains": {
+			Values:   []int64{1},
+			Valid:    true,
+			Negation: true,
+			Type:     qtypes.QueryType_CONTAINS,
+		},
+	}
+
+	for hint, given := range cases {
+		if _, err := qtypeshttp.FormatInt64(given); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
+
+func TestFormat_roundTrip(t *testing.T) {
+	int64s := []string{"null:", "nnull:", "eq:1", "neq:1", "gt:1", "ngte:1", "lt:1", "lte:-5", "in:1,2", "nin:1,2", "bw:1,2", "nbw:[1,2)", "bw:(1,2]", "cts:1,2"}
+	for _, given := range int64s {
+		parsed, err := qtypeshttp.ParseInt64(given)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", given, err.Error())
+		}
+		got, err := qtypeshttp.FormatInt64(parsed)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", given, err.Error())
+		}
+		if got != given {
+			t.Errorf("int64: wrong output, expected %q but got %q", given, got)
+		}
+	}
+
+	uint64s := []string{"eq:18446744073709551615", "bw:[1,2)", "gte:0"}
+	for _, given := range uint64s {
+		parsed, err := qtypeshttp.ParseUint64(given)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", given, err.Error())
+		}
+		got, err := qtypeshttp.FormatUint64(parsed)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", given, err.Error())
+		}
+		if got != given {
+			t.Errorf("uint64: wrong output, expected %q but got %q", given, got)
+		}
+	}
+
+	float64s := []string{"eq:1.5", "bw:(0.1,0.2)", "nin:-1,2.25", "gt:1000000"}
+	for _, given := range float64s {
+		parsed, err := qtypeshttp.ParseFloat64(given)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", given, err.Error())
+		}
+		got, err := qtypeshttp.FormatFloat64(parsed)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", given, err.Error())
+		}
+		if got != given {
+			t.Errorf("float64: wrong output, expected %q but got %q", given, got)
+		}
+	}
+
+	strs := []string{"null:", "eq:John", "neq:John", "hp:Jo", "hpi:Jo", "sub:oh", "subi:oh", "in:a,b", "eqi:John", "neqi:John", "ini:a,B", "nini:a,B", "rgx:^J.*n$", "glob:Jo*n?", "globi:jo*",
+		"nhp:test_", "nhpi:test_", "nhs:.tmp", "nhsi:.TMP", "nsub:oh", "nsubi:oh", "nrgx:^J.*n$", "nglob:Jo*", "nglobi:jo*", "minl:2"}
+	for _, given := range strs {
+		got, err := qtypeshttp.FormatString(qtypeshttp.ParseString(given))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", given, err.Error())
+		}
+		if got != given {
+			t.Errorf("string: wrong output, expected %q but got %q", given, got)
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	from := knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC))
+	to := knowntimestamp.New(time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		given    *qtypes.Timestamp
+		expected string
+	}{
+		"half-open": {
+			given:    qtypes.RangeTimestamp(from, to, false, true),
+			expected: "bw:[2024-05-01T00:00:00Z,2024-05-02T00:00:00Z)",
+		},
+		"without-upper-bound": {
+			given:    qtypes.BetweenTimestamp(from, nil),
+			expected: "gte:2024-05-01T00:00:00Z",
+		},
+		"without-lower-bound": {
+			given:    qtypes.RangeTimestamp(nil, to, false, true),
+			expected: "lt:2024-05-02T00:00:00Z",
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypeshttp.FormatTimestamp(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+		parsed, err := qtypeshttp.ParseTimestamp(got)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if !proto.Equal(c.given, parsed) {
+			t.Errorf("%s: wrong round trip output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.given, parsed)
+		}
+	}
+}
+
+func TestFormatString_separator(t *testing.T) {
+	if _, err := qtypeshttp.FormatString(qtypes.EqualString("a,b")); err == nil {
+		t.Fatal("expected error")
+	}
+}
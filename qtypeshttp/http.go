@@ -20,6 +20,10 @@ const (
 	Equal = "eq"
 	// NotEqual ...
 	NotEqual = "neq"
+	// EqualInsensitive ...
+	EqualInsensitive = "eqi"
+	// NotEqualInsensitive ...
+	NotEqualInsensitive = "neqi"
 	// GreaterThan ...
 	GreaterThan = "gt"
 	// NotGreaterThan ...
@@ -42,16 +46,28 @@ const (
 	NotBetween = "nbw"
 	// HasPrefix ...
 	HasPrefix = "hp"
+	// NotHasPrefix ...
+	NotHasPrefix = "nhp"
 	// HasPrefixInsensitive ...
 	HasPrefixInsensitive = "hpi"
+	// NotHasPrefixInsensitive ...
+	NotHasPrefixInsensitive = "nhpi"
 	// HasSuffix ...
 	HasSuffix = "hs"
+	// NotHasSuffix ...
+	NotHasSuffix = "nhs"
 	// HasSuffixInsensitive ...
 	HasSuffixInsensitive = "hsi"
+	// NotHasSuffixInsensitive ...
+	NotHasSuffixInsensitive = "nhsi"
 	// Substring ...
 	Substring = "sub"
+	// NotSubstring ...
+	NotSubstring = "nsub"
 	// SubstringInsensitive` ...
 	SubstringInsensitive = "subi"
+	// NotSubstringInsensitive ...
+	NotSubstringInsensitive = "nsubi"
 	// HasElement ...
 	HasElement = "he"
 	// HasAnyElement ...
@@ -62,8 +78,14 @@ const (
 	In = "in"
 	// NotIn ...
 	NotIn = "nin"
+	// InInsensitive ...
+	InInsensitive = "ini"
+	// NotInInsensitive ...
+	NotInInsensitive = "nini"
 	// Pattern ...
 	Pattern = "rgx"
+	// NotPattern ...
+	NotPattern = "nrgx"
 	// MinLength ...
 	MinLength = "minl"
 	// MaxLength ...
@@ -74,41 +96,73 @@ const (
 	IsContainedBy = "icb"
 	// Overlap ...
 	Overlap = "ovl"
+	// Wildcard ...
+	Wildcard = "glob"
+	// NotWildcard ...
+	NotWildcard = "nglob"
+	// WildcardInsensitive ...
+	WildcardInsensitive = "globi"
+	// NotWildcardInsensitive ...
+	NotWildcardInsensitive = "nglobi"
 )
 
 var (
+	granularLayouts = []struct {
+		layout      string
+		granularity qtypes.Granularity
+	}{
+		{layout: "2006", granularity: qtypes.GranularityYear},
+		{layout: "2006-01", granularity: qtypes.GranularityMonth},
+		{layout: "2006-01-02", granularity: qtypes.GranularityDay},
+		{layout: "2006-01-02T15", granularity: qtypes.GranularityHour},
+	}
 	prefixes = map[string]string{
-		Null:                  Null + ":",
-		NotNull:               NotNull + ":",
-		Equal:                 Equal + ":",
-		NotEqual:              NotEqual + ":",
-		GreaterThan:           GreaterThan + ":",
-		NotGreaterThan:        NotGreaterThan + ":",
-		GreaterThanOrEqual:    GreaterThanOrEqual + ":",
-		NotGreaterThanOrEqual: NotGreaterThanOrEqual + ":",
-		LessThan:              LessThan + ":",
-		NotLessThan:           NotLessThan + ":",
-		LessThanOrEqual:       LessThanOrEqual + ":",
-		NotLessThanOrEqual:    NotLessThanOrEqual + ":",
-		Between:               Between + ":",
-		NotBetween:            NotBetween + ":",
-		HasPrefix:             HasPrefix + ":",
-		HasPrefixInsensitive:  HasPrefixInsensitive + ":",
-		HasSuffix:             HasSuffix + ":",
-		HasSuffixInsensitive:  HasSuffixInsensitive + ":",
-		In:                    In + ":",
-		NotIn:                 NotIn + ":",
-		Substring:             Substring + ":",
-		SubstringInsensitive:  SubstringInsensitive + ":",
-		Pattern:               Pattern + ":",
-		MinLength:             MinLength + ":",
-		MaxLength:             MaxLength + ":",
-		Contains:              Contains + ":",
-		IsContainedBy:         IsContainedBy + ":",
-		Overlap:               Overlap + ":",
-		HasElement:            HasElement + ":",
-		HasAnyElement:         HasAnyElement + ":",
-		HasAllElements:        HasAllElements + ":",
+		Null:                    Null + ":",
+		NotNull:                 NotNull + ":",
+		Equal:                   Equal + ":",
+		NotEqual:                NotEqual + ":",
+		EqualInsensitive:        EqualInsensitive + ":",
+		NotEqualInsensitive:     NotEqualInsensitive + ":",
+		GreaterThan:             GreaterThan + ":",
+		NotGreaterThan:          NotGreaterThan + ":",
+		GreaterThanOrEqual:      GreaterThanOrEqual + ":",
+		NotGreaterThanOrEqual:   NotGreaterThanOrEqual + ":",
+		LessThan:                LessThan + ":",
+		NotLessThan:             NotLessThan + ":",
+		LessThanOrEqual:         LessThanOrEqual + ":",
+		NotLessThanOrEqual:      NotLessThanOrEqual + ":",
+		Between:                 Between + ":",
+		NotBetween:              NotBetween + ":",
+		HasPrefix:               HasPrefix + ":",
+		NotHasPrefix:            NotHasPrefix + ":",
+		HasPrefixInsensitive:    HasPrefixInsensitive + ":",
+		NotHasPrefixInsensitive: NotHasPrefixInsensitive + ":",
+		HasSuffix:               HasSuffix + ":",
+		NotHasSuffix:            NotHasSuffix + ":",
+		HasSuffixInsensitive:    HasSuffixInsensitive + ":",
+		NotHasSuffixInsensitive: NotHasSuffixInsensitive + ":",
+		In:                      In + ":",
+		NotIn:                   NotIn + ":",
+		InInsensitive:           InInsensitive + ":",
+		NotInInsensitive:        NotInInsensitive + ":",
+		Substring:               Substring + ":",
+		NotSubstring:            NotSubstring + ":",
+		SubstringInsensitive:    SubstringInsensitive + ":",
+		NotSubstringInsensitive: NotSubstringInsensitive + ":",
+		Pattern:                 Pattern + ":",
+		NotPattern:              NotPattern + ":",
+		MinLength:               MinLength + ":",
+		MaxLength:               MaxLength + ":",
+		Contains:                Contains + ":",
+		IsContainedBy:           IsContainedBy + ":",
+		Overlap:                 Overlap + ":",
+		HasElement:              HasElement + ":",
+		HasAnyElement:           HasAnyElement + ":",
+		HasAllElements:          HasAllElements + ":",
+		Wildcard:                Wildcard + ":",
+		NotWildcard:             NotWildcard + ":",
+		WildcardInsensitive:     WildcardInsensitive + ":",
+		NotWildcardInsensitive:  NotWildcardInsensitive + ":",
 	}
 )
 
@@ -118,6 +172,10 @@ func ParseInt64(s string) (*qtypes.Int64, error) {
 		return &qtypes.Int64{}, nil
 	}
 	incoming, t, n, _ := handleNumericPrefix(s)
+	incoming, t, le, ue, err := handleBetween(incoming, t)
+	if err != nil {
+		return nil, err
+	}
 	outgoing := make([]int64, 0, len(incoming))
 	for i, v := range incoming {
 		if v == "" {
@@ -130,10 +188,43 @@ func ParseInt64(s string) (*qtypes.Int64, error) {
 		outgoing = append(outgoing, vv)
 	}
 	return &qtypes.Int64{
-		Values:   outgoing,
-		Type:     t,
-		Negation: n,
-		Valid:    true,
+		Values:         outgoing,
+		Type:           t,
+		Negation:       n,
+		Valid:          true,
+		LowerExclusive: le,
+		UpperExclusive: ue,
+	}, nil
+}
+
+// ParseUint64 ...
+func ParseUint64(s string) (*qtypes.Uint64, error) {
+	if s == "" {
+		return &qtypes.Uint64{}, nil
+	}
+	incoming, t, n, _ := handleNumericPrefix(s)
+	incoming, t, le, ue, err := handleBetween(incoming, t)
+	if err != nil {
+		return nil, err
+	}
+	outgoing := make([]uint64, 0, len(incoming))
+	for i, v := range incoming {
+		if v == "" {
+			break
+		}
+		vv, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("qtypes: query uint64 parsing error for value %d: %s", i, err.Error())
+		}
+		outgoing = append(outgoing, vv)
+	}
+	return &qtypes.Uint64{
+		Values:         outgoing,
+		Type:           t,
+		Negation:       n,
+		Valid:          true,
+		LowerExclusive: le,
+		UpperExclusive: ue,
 	}, nil
 }
 
@@ -143,6 +234,10 @@ func ParseFloat64(s string) (*qtypes.Float64, error) {
 		return &qtypes.Float64{}, nil
 	}
 	incoming, t, n, _ := handleNumericPrefix(s)
+	incoming, t, le, ue, err := handleBetween(incoming, t)
+	if err != nil {
+		return nil, err
+	}
 
 	outgoing := make([]float64, 0, len(incoming))
 	for i, v := range incoming {
@@ -156,10 +251,12 @@ func ParseFloat64(s string) (*qtypes.Float64, error) {
 		outgoing = append(outgoing, vv)
 	}
 	return &qtypes.Float64{
-		Values:   outgoing,
-		Type:     t,
-		Negation: n,
-		Valid:    true,
+		Values:         outgoing,
+		Type:           t,
+		Negation:       n,
+		Valid:          true,
+		LowerExclusive: le,
+		UpperExclusive: ue,
 	}, nil
 }
 
@@ -190,19 +287,55 @@ func ParseString(s string) *qtypes.String {
 	}
 }
 
-// ParseTimestamp ...
+// ParseTimestamp works like ParseTimestampInLocation, calendar granular values are interpreted in UTC.
 func ParseTimestamp(s string) (*qtypes.Timestamp, error) {
+	return ParseTimestampInLocation(s, time.UTC)
+}
+
+// ParseTimestampInLocation allocates new Timestamp object based on given string.
+// Values are expected to be in RFC3339 format. Equal and in conditions
+// accept calendar granular values as well (e.g. 'eq:2024-05-01' or 'in:2024-05,2024-06').
+// Such values are interpreted in given location and expanded into between condition
+// that covers every instant of given year, month, day or hour.
+// Periods of all values have to form a single contiguous range, e.g. 'in:2024-05-01,2024-05-02'.
+// Values of disjoint periods, e.g. 'in:2024-05-01,2024-05-03', cannot be expressed by a single condition
+// and cause an error that wraps qtypes.ErrDisjointPeriods.
+func ParseTimestampInLocation(s string, loc *time.Location) (*qtypes.Timestamp, error) {
 	if s == "" {
 		return &qtypes.Timestamp{}, nil
 	}
 
 	incoming, t, n, _ := handleNumericPrefix(s)
+	incoming, t, le, ue, err := handleBetween(incoming, t)
+	if err != nil {
+		return nil, err
+	}
 
+	granular := t == qtypes.QueryType_EQUAL || t == qtypes.QueryType_IN
+	granularity := qtypes.GranularityNone
 	outgoing := make([]*knowntimestamp.Timestamp, 0, len(incoming))
 	for i, v := range incoming {
 		if v == "" {
 			break
 		}
+		if granular {
+			if pt, g, ok := parseGranularTime(v); ok {
+				if i > 0 && g != granularity {
+					return nil, fmt.Errorf("qtypes: query timestamp parsing error for value %d: mixed calendar granularity", i)
+				}
+				granularity = g
+
+				from, to := g.Period(pt, loc)
+				if !from.Before(to) {
+					return nil, fmt.Errorf("qtypes: query timestamp parsing error for value %d: %s does not exist in %s", i, v, loc)
+				}
+				outgoing = append(outgoing, knowntimestamp.New(from))
+				continue
+			}
+			if granularity != qtypes.GranularityNone {
+				return nil, fmt.Errorf("qtypes: query timestamp parsing error for value %d: mixed calendar granularity", i)
+			}
+		}
 		t, err := time.Parse(time.RFC3339Nano, v)
 		if err != nil {
 			return nil, fmt.Errorf("qtypes: query timestamp parsing error for value %d: %s", i, err.Error())
@@ -210,12 +343,28 @@ func ParseTimestamp(s string) (*qtypes.Timestamp, error) {
 
 		outgoing = append(outgoing, knowntimestamp.New(t))
 	}
-	return &qtypes.Timestamp{
-		Values:   outgoing,
-		Type:     t,
-		Negation: n,
-		Valid:    true,
-	}, nil
+
+	return qtypes.ExpandTimestamp(&qtypes.Timestamp{
+		Values:         outgoing,
+		Type:           t,
+		Negation:       n,
+		Valid:          true,
+		LowerExclusive: le,
+		UpperExclusive: ue,
+	}, granularity, loc)
+}
+
+// parseGranularTime parses wall clock reading of calendar granular value.
+func parseGranularTime(s string) (time.Time, qtypes.Granularity, bool) {
+	for _, l := range granularLayouts {
+		if len(s) != len(l.layout) {
+			continue
+		}
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return t, l.granularity, true
+		}
+	}
+	return time.Time{}, qtypes.GranularityNone, false
 }
 
 func queryType(p string) (t qtypes.QueryType, n bool, i bool) {
@@ -230,6 +379,13 @@ func queryType(p string) (t qtypes.QueryType, n bool, i bool) {
 	case NotEqual:
 		t = qtypes.QueryType_EQUAL
 		n = true
+	case EqualInsensitive:
+		t = qtypes.QueryType_EQUAL
+		i = true
+	case NotEqualInsensitive:
+		t = qtypes.QueryType_EQUAL
+		n = true
+		i = true
 	case GreaterThan:
 		t = qtypes.QueryType_GREATER
 	case NotGreaterThan:
@@ -263,21 +419,45 @@ func queryType(p string) (t qtypes.QueryType, n bool, i bool) {
 		t = qtypes.QueryType_HAS_ANY_ELEMENT
 	case HasPrefix:
 		t = qtypes.QueryType_HAS_PREFIX
+	case NotHasPrefix:
+		t = qtypes.QueryType_HAS_PREFIX
+		n = true
 	case HasPrefixInsensitive:
 		t = qtypes.QueryType_HAS_PREFIX
 		i = true
+	case NotHasPrefixInsensitive:
+		t = qtypes.QueryType_HAS_PREFIX
+		n = true
+		i = true
 	case HasSuffix:
 		t = qtypes.QueryType_HAS_SUFFIX
+	case NotHasSuffix:
+		t = qtypes.QueryType_HAS_SUFFIX
+		n = true
 	case HasSuffixInsensitive:
 		t = qtypes.QueryType_HAS_SUFFIX
 		i = true
+	case NotHasSuffixInsensitive:
+		t = qtypes.QueryType_HAS_SUFFIX
+		n = true
+		i = true
 	case Substring:
 		t = qtypes.QueryType_SUBSTRING
+	case NotSubstring:
+		t = qtypes.QueryType_SUBSTRING
+		n = true
 	case SubstringInsensitive:
 		t = qtypes.QueryType_SUBSTRING
 		i = true
+	case NotSubstringInsensitive:
+		t = qtypes.QueryType_SUBSTRING
+		n = true
+		i = true
 	case Pattern:
 		t = qtypes.QueryType_PATTERN
+	case NotPattern:
+		t = qtypes.QueryType_PATTERN
+		n = true
 	case MinLength:
 		t = qtypes.QueryType_MIN_LENGTH
 	case MaxLength:
@@ -287,12 +467,31 @@ func queryType(p string) (t qtypes.QueryType, n bool, i bool) {
 	case NotIn:
 		t = qtypes.QueryType_IN
 		n = true
+	case InInsensitive:
+		t = qtypes.QueryType_IN
+		i = true
+	case NotInInsensitive:
+		t = qtypes.QueryType_IN
+		n = true
+		i = true
 	case Contains:
 		t = qtypes.QueryType_CONTAINS
 	case IsContainedBy:
 		t = qtypes.QueryType_IS_CONTAINED_BY
 	case Overlap:
 		t = qtypes.QueryType_OVERLAP
+	case Wildcard:
+		t = qtypes.QueryType_WILDCARD
+	case NotWildcard:
+		t = qtypes.QueryType_WILDCARD
+		n = true
+	case WildcardInsensitive:
+		t = qtypes.QueryType_WILDCARD
+		i = true
+	case NotWildcardInsensitive:
+		t = qtypes.QueryType_WILDCARD
+		n = true
+		i = true
 	}
 	return
 }
@@ -313,3 +512,42 @@ func handleNumericPrefix(s string) (incoming []string, t qtypes.QueryType, n, i
 
 	return
 }
+
+// handleBetween strips interval notation from between values, e.g. '[1,5)' or '(1,5]'.
+// Square bracket means that bound is inclusive, round bracket that it is exclusive.
+// Values without brackets form closed range. Empty value leaves that side unbounded (e.g. 'bw:,5' or 'bw:(1,'),
+// in which case equivalent less (equal) or greater (equal) type is returned.
+func handleBetween(incoming []string, t qtypes.QueryType) (outgoing []string, tt qtypes.QueryType, le, ue bool, err error) {
+	if t != qtypes.QueryType_BETWEEN || len(incoming) == 0 {
+		return incoming, t, false, false, nil
+	}
+
+	outgoing = incoming
+	first, last := incoming[0], incoming[len(incoming)-1]
+	opening := strings.HasPrefix(first, "[") || strings.HasPrefix(first, "(")
+	closing := strings.HasSuffix(last, "]") || strings.HasSuffix(last, ")")
+	if opening || closing {
+		if !opening || !closing || len(incoming) != 2 {
+			return nil, t, false, false, fmt.Errorf("qtypes: query between parsing error: malformed interval notation")
+		}
+		outgoing = []string{first[1:], last[:len(last)-1]}
+		le, ue = first[0] == '(', last[len(last)-1] == ')'
+	}
+	if len(outgoing) != 2 {
+		return outgoing, t, le, ue, nil
+	}
+
+	switch {
+	case outgoing[0] == "" && outgoing[1] == "":
+		return nil, t, false, false, fmt.Errorf("qtypes: query between parsing error: both bounds are missing")
+	case outgoing[0] == "" && ue:
+		return outgoing[1:], qtypes.QueryType_LESS, false, false, nil
+	case outgoing[0] == "":
+		return outgoing[1:], qtypes.QueryType_LESS_EQUAL, false, false, nil
+	case outgoing[1] == "" && le:
+		return outgoing[:1], qtypes.QueryType_GREATER, false, false, nil
+	case outgoing[1] == "":
+		return outgoing[:1], qtypes.QueryType_GREATER_EQUAL, false, false, nil
+	}
+	return outgoing, t, le, ue, nil
+}
@@ -1,12 +1,14 @@
 package qtypeshttp_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/piotrkowalczuk/qtypes"
 	"github.com/piotrkowalczuk/qtypes/qtypeshttp"
+	"google.golang.org/protobuf/proto"
 	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -91,6 +93,134 @@ func TestParseString(t *testing.T) {
 				Insensitive: true,
 			},
 		},
+		"not-has-prefix": {
+			given: "nhp:test_",
+			expected: qtypes.String{
+				Values:   []string{"test_"},
+				Type:     qtypes.QueryType_HAS_PREFIX,
+				Valid:    true,
+				Negation: true,
+			},
+		},
+		"not-has-prefix-insensitive": {
+			given: "nhpi:Test_",
+			expected: qtypes.String{
+				Values:      []string{"Test_"},
+				Type:        qtypes.QueryType_HAS_PREFIX,
+				Valid:       true,
+				Negation:    true,
+				Insensitive: true,
+			},
+		},
+		"not-has-suffix-insensitive": {
+			given: "nhsi:.TMP",
+			expected: qtypes.String{
+				Values:      []string{".TMP"},
+				Type:        qtypes.QueryType_HAS_SUFFIX,
+				Valid:       true,
+				Negation:    true,
+				Insensitive: true,
+			},
+		},
+		"not-substring": {
+			given: "nsub:draft",
+			expected: qtypes.String{
+				Values:   []string{"draft"},
+				Type:     qtypes.QueryType_SUBSTRING,
+				Valid:    true,
+				Negation: true,
+			},
+		},
+		"not-pattern": {
+			given: "nrgx:^a",
+			expected: qtypes.String{
+				Values:   []string{"^a"},
+				Type:     qtypes.QueryType_PATTERN,
+				Valid:    true,
+				Negation: true,
+			},
+		},
+		"not-wildcard-insensitive": {
+			given: "nglobi:jo*",
+			expected: qtypes.String{
+				Values:      []string{"jo*"},
+				Type:        qtypes.QueryType_WILDCARD,
+				Valid:       true,
+				Negation:    true,
+				Insensitive: true,
+			},
+		},
+		"equal-insensitive": {
+			given: "eqi:John@example.com",
+			expected: qtypes.String{
+				Values:      []string{"John@example.com"},
+				Type:        qtypes.QueryType_EQUAL,
+				Valid:       true,
+				Insensitive: true,
+			},
+		},
+		"not-equal-insensitive": {
+			given: "neqi:john",
+			expected: qtypes.String{
+				Values:      []string{"john"},
+				Type:        qtypes.QueryType_EQUAL,
+				Valid:       true,
+				Negation:    true,
+				Insensitive: true,
+			},
+		},
+		"in-insensitive": {
+			given: "ini:john,JANE",
+			expected: qtypes.String{
+				Values:      []string{"john", "JANE"},
+				Type:        qtypes.QueryType_IN,
+				Valid:       true,
+				Insensitive: true,
+			},
+		},
+		"not-in-insensitive": {
+			given: "nini:john,JANE",
+			expected: qtypes.String{
+				Values:      []string{"john", "JANE"},
+				Type:        qtypes.QueryType_IN,
+				Valid:       true,
+				Negation:    true,
+				Insensitive: true,
+			},
+		},
+		"greater": {
+			given: "gt:john",
+			expected: qtypes.String{
+				Values: []string{"john"},
+				Type:   qtypes.QueryType_GREATER,
+				Valid:  true,
+			},
+		},
+		"between": {
+			given: "bw:a,f",
+			expected: qtypes.String{
+				Values: []string{"a", "f"},
+				Type:   qtypes.QueryType_BETWEEN,
+				Valid:  true,
+			},
+		},
+		"wildcard": {
+			given: "glob:jo*n?",
+			expected: qtypes.String{
+				Values: []string{"jo*n?"},
+				Type:   qtypes.QueryType_WILDCARD,
+				Valid:  true,
+			},
+		},
+		"wildcard-insensitive": {
+			given: "globi:Jo*",
+			expected: qtypes.String{
+				Values:      []string{"Jo*"},
+				Type:        qtypes.QueryType_WILDCARD,
+				Valid:       true,
+				Insensitive: true,
+			},
+		},
 		"pattern": {
 			given: "rgx:.*",
 			expected: qtypes.String{
@@ -291,6 +421,23 @@ func TestParseFloat64(t *testing.T) {
 				Valid:  true,
 			},
 		},
+		"between-half-open": {
+			given: "bw:(111.666,222.666]",
+			expected: qtypes.Float64{
+				Values:         []float64{111.666, 222.666},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				LowerExclusive: true,
+			},
+		},
+		"between-without-lower-bound": {
+			given: "bw:,222.666",
+			expected: qtypes.Float64{
+				Values: []float64{222.666},
+				Type:   qtypes.QueryType_LESS_EQUAL,
+				Valid:  true,
+			},
+		},
 		"not-between": {
 			given: "nbw:111.666,222",
 			expected: qtypes.Float64{
@@ -473,6 +620,28 @@ func TestParseTimestamp(t *testing.T) {
 				Valid: true,
 			},
 		},
+		"between-half-open": {
+			given: "bw:[2009-11-10T23:00:00Z,2009-12-10T23:00:00Z)",
+			expected: qtypes.Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					parseTimestamp(t, "2009-11-10T23:00:00Z"),
+					parseTimestamp(t, "2009-12-10T23:00:00Z"),
+				},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				UpperExclusive: true,
+			},
+		},
+		"between-without-upper-bound": {
+			given: "bw:2009-11-10T23:00:00Z,",
+			expected: qtypes.Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					parseTimestamp(t, "2009-11-10T23:00:00Z"),
+				},
+				Type:  qtypes.QueryType_GREATER_EQUAL,
+				Valid: true,
+			},
+		},
 		"in": {
 			given: "in:2009-10-10T23:00:00Z,2009-11-10T23:00:00Z,2009-12-10T23:00:00Z",
 			expected: qtypes.Timestamp{
@@ -485,6 +654,55 @@ func TestParseTimestamp(t *testing.T) {
 				Valid: true,
 			},
 		},
+		"equal-day": {
+			given: "eq:2009-11-10",
+			expected: qtypes.Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					parseTimestamp(t, "2009-11-10T00:00:00Z"),
+					parseTimestamp(t, "2009-11-11T00:00:00Z"),
+				},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				UpperExclusive: true,
+			},
+		},
+		"equal-hour-without-condition": {
+			given: "2009-11-10T23",
+			expected: qtypes.Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					parseTimestamp(t, "2009-11-10T23:00:00Z"),
+					parseTimestamp(t, "2009-11-11T00:00:00Z"),
+				},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				UpperExclusive: true,
+			},
+		},
+		"not-equal-year": {
+			given: "neq:2009",
+			expected: qtypes.Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					parseTimestamp(t, "2009-01-01T00:00:00Z"),
+					parseTimestamp(t, "2010-01-01T00:00:00Z"),
+				},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				Negation:       true,
+				UpperExclusive: true,
+			},
+		},
+		"in-month": {
+			given: "in:2009-12,2009-11",
+			expected: qtypes.Timestamp{
+				Values: []*knowntimestamp.Timestamp{
+					parseTimestamp(t, "2009-11-01T00:00:00Z"),
+					parseTimestamp(t, "2010-01-01T00:00:00Z"),
+				},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				UpperExclusive: true,
+			},
+		},
 	}
 
 	for hint, c := range cases {
@@ -603,6 +821,67 @@ func TestParseInt64(t *testing.T) {
 				Negation: true,
 			},
 		},
+		"between-closed": {
+			given: "bw:[111,222]",
+			expected: qtypes.Int64{
+				Values: []int64{111, 222},
+				Type:   qtypes.QueryType_BETWEEN,
+				Valid:  true,
+			},
+		},
+		"between-half-open": {
+			given: "bw:[111,222)",
+			expected: qtypes.Int64{
+				Values:         []int64{111, 222},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				UpperExclusive: true,
+			},
+		},
+		"between-without-lower-bound": {
+			given: "bw:,222",
+			expected: qtypes.Int64{
+				Values: []int64{222},
+				Type:   qtypes.QueryType_LESS_EQUAL,
+				Valid:  true,
+			},
+		},
+		"between-without-upper-bound": {
+			given: "bw:111,",
+			expected: qtypes.Int64{
+				Values: []int64{111},
+				Type:   qtypes.QueryType_GREATER_EQUAL,
+				Valid:  true,
+			},
+		},
+		"between-without-lower-bound-exclusive": {
+			given: "bw:[,222)",
+			expected: qtypes.Int64{
+				Values: []int64{222},
+				Type:   qtypes.QueryType_LESS,
+				Valid:  true,
+			},
+		},
+		"not-between-without-upper-bound-exclusive": {
+			given: "nbw:(111,]",
+			expected: qtypes.Int64{
+				Values:   []int64{111},
+				Type:     qtypes.QueryType_GREATER,
+				Valid:    true,
+				Negation: true,
+			},
+		},
+		"not-between-open": {
+			given: "nbw:(111,222)",
+			expected: qtypes.Int64{
+				Values:         []int64{111, 222},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				Negation:       true,
+				LowerExclusive: true,
+				UpperExclusive: true,
+			},
+		},
 	}
 
 CasesLoop:
@@ -622,6 +901,66 @@ CasesLoop:
 	}
 }
 
+func TestParseUint64(t *testing.T) {
+	cases := map[string]struct {
+		given    string
+		expected *qtypes.Uint64
+	}{
+		"empty": {
+			given:    "",
+			expected: &qtypes.Uint64{},
+		},
+		"number": {
+			given: "15",
+			expected: &qtypes.Uint64{
+				Values: []uint64{15},
+				Type:   qtypes.QueryType_EQUAL,
+				Valid:  true,
+			},
+		},
+		"in": {
+			given: "in:1,2,3",
+			expected: &qtypes.Uint64{
+				Values: []uint64{1, 2, 3},
+				Type:   qtypes.QueryType_IN,
+				Valid:  true,
+			},
+		},
+		"between-half-open": {
+			given: "bw:[18446744073709551614,18446744073709551615)",
+			expected: &qtypes.Uint64{
+				Values:         []uint64{18446744073709551614, 18446744073709551615},
+				Type:           qtypes.QueryType_BETWEEN,
+				Valid:          true,
+				UpperExclusive: true,
+			},
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypeshttp.ParseUint64(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if !proto.Equal(c.expected, got) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+
+	if _, err := qtypeshttp.ParseUint64("eq:-1"); err == nil {
+		t.Error("expected error for negative number")
+	}
+}
+
+func TestParseInt64_malformedInterval(t *testing.T) {
+	for _, given := range []string{"bw:[1,2", "bw:1,2)", "bw:[1,2,3]", "bw:,", "bw:[,)"} {
+		if _, err := qtypeshttp.ParseInt64(given); err == nil {
+			t.Errorf("%s: expected error", given)
+		}
+	}
+}
+
 func TestParseInt64_text(t *testing.T) {
 	got, err := qtypeshttp.ParseInt64("ne:long-text")
 	if err == nil {
@@ -631,3 +970,50 @@ func TestParseInt64_text(t *testing.T) {
 		t.Fatal("expected nil")
 	}
 }
+
+func TestParseTimestampInLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %s", err.Error())
+	}
+
+	got, err := qtypeshttp.ParseTimestampInLocation("eq:2024-11-03", newYork)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got.Type != qtypes.QueryType_BETWEEN {
+		t.Fatalf("wrong type, expected %s but got %s", qtypes.QueryType_BETWEEN, got.Type)
+	}
+	if d := got.Values[1].AsTime().Sub(got.Values[0].AsTime()); d != 25*time.Hour {
+		t.Errorf("wrong duration, day of transition should last 25 hours but got %s", d)
+	}
+
+	if _, err = qtypeshttp.ParseTimestampInLocation("eq:2024-03-10T02", newYork); err == nil {
+		t.Error("expected error for skipped hour")
+	}
+	if _, err = qtypeshttp.ParseTimestampInLocation("in:2024-03-10,2024-03-12", newYork); !errors.Is(err, qtypes.ErrDisjointPeriods) {
+		t.Errorf("expected disjoint periods error for disjoint days, got %v", err)
+	}
+	if _, err = qtypeshttp.ParseTimestampInLocation("in:2024-03-10,2024-03", newYork); err == nil {
+		t.Error("expected error for mixed granularity")
+	}
+}
+
+func TestParseTimestamp_granularIn(t *testing.T) {
+	got, err := qtypeshttp.ParseTimestamp("in:2024-05-01,2024-05-02")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := qtypes.RangeTimestamp(
+		knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)),
+		knowntimestamp.New(time.Date(2024, time.May, 3, 0, 0, 0, 0, time.UTC)),
+		false, true,
+	)
+	if !proto.Equal(got, expected) {
+		t.Errorf("wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", expected, got)
+	}
+
+	if _, err := qtypeshttp.ParseTimestamp("in:2024-05-01,2024-05-03"); !errors.Is(err, qtypes.ErrDisjointPeriods) {
+		t.Errorf("expected disjoint periods error, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package qtypeshttp
+
+import (
+	"strings"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Parser works like package level parse functions, but additionally checks every parsed condition
+// against its configuration. The field name identifies the condition within the configuration.
+//
+// PATTERN values are validated against the portable subset, see qtypes.ValidatePattern.
+//
+// Parser counts valid conditions it has parsed to enforce Limits.MaxConditions,
+// so it is meant to be created per request and it is not safe for concurrent use.
+type Parser struct {
+	// Policy, if not nil, restricts conditions that are allowed per field, see qtypes.Policy.
+	Policy qtypes.Policy
+	// Limits restricts size of conditions, see qtypes.Limits.
+	Limits qtypes.Limits
+
+	count int
+}
+
+// ParseInt64 works like package level ParseInt64.
+func (p *Parser) ParseInt64(field, s string) (*qtypes.Int64, error) {
+	if err := p.precheck(field, s); err != nil {
+		return nil, err
+	}
+	i, err := ParseInt64(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.check(field, i); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// ParseUint64 works like package level ParseUint64.
+func (p *Parser) ParseUint64(field, s string) (*qtypes.Uint64, error) {
+	if err := p.precheck(field, s); err != nil {
+		return nil, err
+	}
+	u, err := ParseUint64(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.check(field, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// ParseFloat64 works like package level ParseFloat64.
+func (p *Parser) ParseFloat64(field, s string) (*qtypes.Float64, error) {
+	if err := p.precheck(field, s); err != nil {
+		return nil, err
+	}
+	f, err := ParseFloat64(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.check(field, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ParseTimestamp works like package level ParseTimestamp.
+func (p *Parser) ParseTimestamp(field, s string) (*qtypes.Timestamp, error) {
+	if err := p.precheck(field, s); err != nil {
+		return nil, err
+	}
+	t, err := ParseTimestamp(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.check(field, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ParseString works like package level ParseString.
+func (p *Parser) ParseString(field, s string) (*qtypes.String, error) {
+	if err := p.precheck(field, s); err != nil {
+		return nil, err
+	}
+	str := ParseString(s)
+	if err := p.check(field, str); err != nil {
+		return nil, err
+	}
+	return str, nil
+}
+
+// precheck rejects input with too many values before it gets split.
+func (p *Parser) precheck(field, s string) error {
+	if p.Limits.MaxValues > 0 {
+		return p.Limits.CheckValues(field, strings.Count(s, arraySeparator)+1)
+	}
+	return nil
+}
+
+func (p *Parser) check(field string, c qtypes.Condition) error {
+	if p.Policy != nil {
+		if err := p.Policy.Check(field, c); err != nil {
+			return err
+		}
+	}
+	// Limits go first, so that oversized patterns are rejected before they get parsed.
+	if err := p.Limits.Check(field, c); err != nil {
+		return err
+	}
+	if str, ok := c.(*qtypes.String); ok && str.GetValid() && str.GetType() == qtypes.QueryType_PATTERN {
+		for _, v := range str.Values {
+			if err := qtypes.ValidatePattern(v); err != nil {
+				return err
+			}
+		}
+	}
+	if c.GetValid() {
+		p.count++
+		if err := p.Limits.CheckCount(p.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package qtypeshttp_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeshttp"
+)
+
+func TestParser_policy(t *testing.T) {
+	p := &qtypeshttp.Parser{
+		Policy: qtypes.Policy{
+			"name": {Types: []qtypes.QueryType{qtypes.QueryType_EQUAL, qtypes.QueryType_HAS_PREFIX}, Insensitive: true},
+			"age":  {Types: []qtypes.QueryType{qtypes.QueryType_BETWEEN}, Negation: true},
+		},
+	}
+
+	cases := map[string]struct {
+		parse func() error
+		ok    bool
+	}{
+		"string-allowed": {
+			parse: func() error { _, err := p.ParseString("name", "hpi:ab"); return err },
+			ok:    true,
+		},
+		"string-empty": {
+			parse: func() error { _, err := p.ParseString("description", ""); return err },
+			ok:    true,
+		},
+		"string-pattern": {
+			parse: func() error { _, err := p.ParseString("name", "rgx:^a"); return err },
+		},
+		"string-substring": {
+			parse: func() error { _, err := p.ParseString("description", "sub:a"); return err },
+		},
+		"int64-allowed": {
+			parse: func() error { _, err := p.ParseInt64("age", "nbw:1,5"); return err },
+			ok:    true,
+		},
+		"int64-not-allowed": {
+			parse: func() error { _, err := p.ParseInt64("age", "gt:1"); return err },
+		},
+		"uint64-not-allowed": {
+			parse: func() error { _, err := p.ParseUint64("id", "1"); return err },
+		},
+		"float64-allowed": {
+			parse: func() error { _, err := p.ParseFloat64("age", "bw:1.5,2"); return err },
+			ok:    true,
+		},
+		"timestamp-not-allowed": {
+			parse: func() error { _, err := p.ParseTimestamp("created_at", "eq:2024-05-01"); return err },
+		},
+	}
+
+	for hint, c := range cases {
+		err := c.parse()
+		if c.ok {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			}
+			continue
+		}
+		if !errors.Is(err, qtypes.ErrNotAllowed) {
+			t.Errorf("%s: expected policy violation, got %v", hint, err)
+		}
+	}
+}
+
+func TestParser_withoutPolicy(t *testing.T) {
+	p := &qtypeshttp.Parser{}
+	if _, err := p.ParseString("name", "rgx:^a"); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if _, err := p.ParseInt64("age", "gt:a"); err == nil {
+		t.Error("expected parsing error")
+	}
+}
+
+func TestParser_limits(t *testing.T) {
+	p := &qtypeshttp.Parser{
+		Limits: qtypes.Limits{
+			MaxValues:            3,
+			MaxStringLength:      5,
+			MaxPatternComplexity: 20,
+			MaxConditions:        4,
+		},
+	}
+
+	cases := map[string]struct {
+		parse    func() error
+		expected qtypes.Limit
+		ok       bool
+	}{
+		"int64": {
+			parse: func() error { _, err := p.ParseInt64("age", "in:1,2,3"); return err },
+			ok:    true,
+		},
+		"int64-values": {
+			parse:    func() error { _, err := p.ParseInt64("age", "in:1,2,3,4"); return err },
+			expected: qtypes.LimitValues,
+		},
+		"values-before-parsing": {
+			parse:    func() error { _, err := p.ParseUint64("id", "in:1,2,3,a"); return err },
+			expected: qtypes.LimitValues,
+		},
+		"string-length": {
+			parse:    func() error { _, err := p.ParseString("name", "hp:abcdef"); return err },
+			expected: qtypes.LimitStringLength,
+		},
+		"pattern-complexity": {
+			parse:    func() error { _, err := p.ParseString("name", "rgx:a{19}"); return err },
+			expected: qtypes.LimitPatternComplexity,
+		},
+	}
+
+	for hint, c := range cases {
+		err := c.parse()
+		if c.ok {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			}
+			continue
+		}
+		var limitErr *qtypes.LimitError
+		if !errors.As(err, &limitErr) || limitErr.Limit != c.expected {
+			t.Errorf("%s: expected %s limit error, got %v", hint, c.expected, err)
+		}
+	}
+}
+
+func TestParser_limitsConditions(t *testing.T) {
+	p := &qtypeshttp.Parser{Limits: qtypes.Limits{MaxConditions: 2}}
+
+	for _, s := range []string{"1", "", "gt:2"} {
+		if _, err := p.ParseInt64("age", s); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+	_, err := p.ParseFloat64("score", "lt:3")
+	var limitErr *qtypes.LimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != qtypes.LimitConditions {
+		t.Errorf("expected conditions limit error, got %v", err)
+	}
+}
+
+func TestParser_pattern(t *testing.T) {
+	p := &qtypeshttp.Parser{}
+	if _, err := p.ParseString("name", "rgx:^[a-z]+$"); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if _, err := p.ParseString("name", "rgx:(?i)a"); !errors.Is(err, qtypes.ErrUnsupportedPattern) {
+		t.Errorf("expected pattern error, got %v", err)
+	}
+}
+
+func TestParser_limitsBeforeValidation(t *testing.T) {
+	p := &qtypeshttp.Parser{Limits: qtypes.Limits{MaxPatternLength: 6}}
+
+	for _, s := range []string{"rgx:(?<=a)b", "glob:a*b*c*d"} {
+		_, err := p.ParseString("name", s)
+		var limitErr *qtypes.LimitError
+		if !errors.As(err, &limitErr) || limitErr.Limit != qtypes.LimitPatternLength {
+			t.Errorf("%s: expected pattern length limit error, got %v", s, err)
+		}
+	}
+}
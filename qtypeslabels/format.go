@@ -0,0 +1,73 @@
+package qtypeslabels
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Format returns label selector representation of given selector, that Parse understands.
+// Invalid conditions are skipped. Conditions other than NULL, EQUAL and IN, case insensitive ones,
+// and invalid label names or values cause an error.
+func Format(s Selector) (string, error) {
+	var parts []string
+	for _, key := range slices.Sorted(maps.Keys(s)) {
+		for i, c := range s[key] {
+			r, err := format(key, c)
+			if err != nil {
+				return "", fmt.Errorf("qtypes: label selector formatting error for condition %d of label %q: %w", i, key, err)
+			}
+			if r != "" {
+				parts = append(parts, r)
+			}
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+func format(key string, c *qtypes.String) (string, error) {
+	if !c.GetValid() {
+		return "", nil
+	}
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if c.Insensitive {
+		return "", fmt.Errorf("case insensitive %s cannot be expressed", c.Type)
+	}
+	for _, v := range c.Values {
+		if err := validateValue(v); err != nil {
+			return "", err
+		}
+	}
+
+	switch c.Type {
+	case qtypes.QueryType_NULL:
+		if c.Negation {
+			return key, nil
+		}
+		return "!" + key, nil
+	case qtypes.QueryType_EQUAL:
+		if len(c.Values) != 1 {
+			return "", fmt.Errorf("%s requires 1 value but got %d", c.Type, len(c.Values))
+		}
+		if c.Negation {
+			return key + "!=" + c.Values[0], nil
+		}
+		return key + "=" + c.Values[0], nil
+	case qtypes.QueryType_IN:
+		if len(c.Values) == 0 {
+			return "", fmt.Errorf("%s requires at least one value", c.Type)
+		}
+		op := " in "
+		if c.Negation {
+			op = " notin "
+		}
+		return key + op + "(" + strings.Join(c.Values, ",") + ")", nil
+	default:
+		return "", fmt.Errorf("%s cannot be expressed", c.Type)
+	}
+}
@@ -0,0 +1,81 @@
+package qtypeslabels_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeslabels"
+)
+
+func TestFormat(t *testing.T) {
+	cases := map[string]struct {
+		given    qtypeslabels.Selector
+		expected string
+	}{
+		"example": {
+			given: qtypeslabels.Selector{
+				"env":    {&qtypes.String{Values: []string{"prod", "staging"}, Valid: true, Type: qtypes.QueryType_IN}},
+				"tier":   {&qtypes.String{Values: []string{"frontend"}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL}},
+				"legacy": {qtypes.NullString()},
+			},
+			expected: "env in (prod,staging),!legacy,tier!=frontend",
+		},
+		"empty": {
+			given:    qtypeslabels.Selector{},
+			expected: "",
+		},
+		"invalid": {
+			given:    qtypeslabels.Selector{"app": {{Values: []string{"web"}}, nil, qtypes.EqualString("web")}},
+			expected: "app=web",
+		},
+		"exists": {
+			given:    qtypeslabels.Selector{"example.com/canary": {&qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}}},
+			expected: "example.com/canary",
+		},
+		"not-in": {
+			given:    qtypeslabels.Selector{"env": {&qtypes.String{Values: []string{"dev", ""}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}}},
+			expected: "env notin (dev,)",
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypeslabels.Format(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+		parsed, err := qtypeslabels.Parse(got)
+		if err != nil {
+			t.Errorf("%s: unexpected error for %q: %s", hint, got, err.Error())
+			continue
+		}
+		again, err := qtypeslabels.Format(parsed)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if again != got {
+			t.Errorf("%s: wrong round trip output, expected %q but got %q", hint, got, again)
+		}
+	}
+}
+
+func TestFormat_error(t *testing.T) {
+	cases := map[string]qtypeslabels.Selector{
+		"invalid-name":  {"-app": {qtypes.EqualString("web")}},
+		"invalid-value": {"app": {qtypes.EqualString("a,b")}},
+		"insensitive":   {"app": {qtypes.EqualStringInsensitive("web")}},
+		"prefix":        {"app": {qtypes.HasPrefixString("w")}},
+		"empty-in":      {"app": {{Valid: true, Type: qtypes.QueryType_IN}}},
+		"equal-of-many": {"app": {{Values: []string{"a", "b"}, Valid: true, Type: qtypes.QueryType_EQUAL}}},
+	}
+
+	for hint, given := range cases {
+		if _, err := qtypeslabels.Format(given); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
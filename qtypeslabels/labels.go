@@ -0,0 +1,79 @@
+// Package qtypeslabels converts Kubernetes label selectors, e.g. env in (prod,staging),tier!=frontend,!legacy,
+// to and from string conditions keyed by label name, and matches labels against them.
+//
+// Requirements map to conditions as follows:
+//   - key=value and key==value to EQUAL, key!=value to negated EQUAL,
+//   - key in (a,b) to IN, key notin (a,b) to negated IN,
+//   - !key to NULL, as the label does not exist, and key to negated NULL.
+//
+// Like in Kubernetes, negated EQUAL and IN are satisfied by labels that do not exist.
+package qtypeslabels
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Selector is a label selector, conditions are keyed by label name and have to be satisfied all together.
+type Selector map[string][]*qtypes.String
+
+// ErrInvalidSelector is wrapped by errors returned when a selector cannot be parsed.
+var ErrInvalidSelector = errors.New("qtypes: invalid label selector")
+
+// ParseError is returned when a selector cannot be parsed. It wraps ErrInvalidSelector.
+type ParseError struct {
+	// Pos is a byte offset in the selector at which the problem is.
+	Pos    int
+	Reason string
+}
+
+// Error implements error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("qtypes: label selector parsing error at position %d: %s", e.Pos, e.Reason)
+}
+
+// Unwrap returns ErrInvalidSelector.
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidSelector
+}
+
+const (
+	maxNameLength   = 63
+	maxPrefixLength = 253
+)
+
+var (
+	nameExpr   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	prefixExpr = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// validateKey checks that given label name is valid, e.g. app or example.com/app.
+func validateKey(key string) error {
+	name := key
+	if i := strings.LastIndexByte(key, '/'); i >= 0 {
+		prefix := key[:i]
+		if len(prefix) > maxPrefixLength || !prefixExpr.MatchString(prefix) {
+			return fmt.Errorf("label name %q has invalid prefix, it has to be a DNS subdomain of at most %d characters", key, maxPrefixLength)
+		}
+		name = key[i+1:]
+	}
+	if len(name) > maxNameLength || !nameExpr.MatchString(name) {
+		return fmt.Errorf("label name %q is invalid, it has to consist of at most %d alphanumeric characters, '-', '_' or '.', and start and end with an alphanumeric character", key, maxNameLength)
+	}
+	return nil
+}
+
+// validateValue checks that given label value is valid. Empty value is valid.
+func validateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxNameLength || !nameExpr.MatchString(value) {
+		return fmt.Errorf("label value %q is invalid, it has to consist of at most %d alphanumeric characters, '-', '_' or '.', and start and end with an alphanumeric character", value, maxNameLength)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package qtypeslabels
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Match reports whether given labels satisfy the selector, see qtypes.MatchString.
+// Invalid conditions are satisfied by any labels. A label that does not exist satisfies only NULL
+// and negated conditions, e.g. tier!=frontend, like in Kubernetes.
+// Error is returned if a condition cannot be evaluated, labels are checked in order of their names.
+func (s Selector) Match(labels map[string]string) (bool, error) {
+	for _, key := range slices.Sorted(maps.Keys(s)) {
+		v, ok := labels[key]
+		for _, c := range s[key] {
+			if !c.GetValid() {
+				continue
+			}
+			if !ok {
+				if (c.Type == qtypes.QueryType_NULL) == c.Negation {
+					return false, nil
+				}
+				continue
+			}
+			matched, err := qtypes.MatchString(c, v)
+			if err != nil {
+				return false, fmt.Errorf("qtypes: label selector matching error for label %q: %w", key, err)
+			}
+			if !matched {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
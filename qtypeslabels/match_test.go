@@ -0,0 +1,92 @@
+package qtypeslabels_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeslabels"
+)
+
+func TestSelector_Match(t *testing.T) {
+	selector, err := qtypeslabels.Parse("env in (prod,staging),tier!=frontend,!legacy")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := map[string]struct {
+		given    map[string]string
+		expected bool
+	}{
+		"matching":           {given: map[string]string{"env": "prod", "tier": "backend"}, expected: true},
+		"without-tier":       {given: map[string]string{"env": "staging"}, expected: true},
+		"wrong-env":          {given: map[string]string{"env": "dev", "tier": "backend"}, expected: false},
+		"without-env":        {given: map[string]string{"tier": "backend"}, expected: false},
+		"excluded-tier":      {given: map[string]string{"env": "prod", "tier": "frontend"}, expected: false},
+		"legacy":             {given: map[string]string{"env": "prod", "legacy": ""}, expected: false},
+		"nil":                {given: nil, expected: false},
+		"case-sensitive-env": {given: map[string]string{"env": "Prod"}, expected: false},
+	}
+
+	for hint, c := range cases {
+		got, err := selector.Match(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %t but got %t", hint, c.expected, got)
+		}
+	}
+}
+
+func TestSelector_Match_other(t *testing.T) {
+	cases := map[string]struct {
+		selector qtypeslabels.Selector
+		labels   map[string]string
+		expected bool
+	}{
+		"empty": {
+			selector: qtypeslabels.Selector{},
+			labels:   map[string]string{"app": "web"},
+			expected: true,
+		},
+		"exists": {
+			selector: qtypeslabels.Selector{"app": {{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}}},
+			labels:   map[string]string{"app": ""},
+			expected: true,
+		},
+		"not-in-without-label": {
+			selector: qtypeslabels.Selector{"env": {{Values: []string{"dev"}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}}},
+			labels:   map[string]string{},
+			expected: true,
+		},
+		"invalid-condition": {
+			selector: qtypeslabels.Selector{"app": {{Values: []string{"web"}}}},
+			labels:   map[string]string{},
+			expected: true,
+		},
+		"insensitive": {
+			selector: qtypeslabels.Selector{"env": {qtypes.InStringInsensitive("prod")}},
+			labels:   map[string]string{"env": "PROD"},
+			expected: true,
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := c.selector.Match(c.labels)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %t but got %t", hint, c.expected, got)
+		}
+	}
+}
+
+func TestSelector_Match_error(t *testing.T) {
+	selector := qtypeslabels.Selector{"tags": {{Values: []string{"a"}, Valid: true, Type: qtypes.QueryType_CONTAINS}}}
+	if _, err := selector.Match(map[string]string{"tags": "a"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
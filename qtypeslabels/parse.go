@@ -0,0 +1,165 @@
+package qtypeslabels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Parse parses given label selector. Errors are of type *ParseError, that points at the problem.
+// An empty selector results in an empty Selector, that matches all labels.
+func Parse(selector string) (Selector, error) {
+	p := &parser{src: selector}
+	res := Selector{}
+
+	p.space()
+	if p.pos == len(p.src) {
+		return res, nil
+	}
+	for {
+		key, c, err := p.requirement()
+		if err != nil {
+			return nil, err
+		}
+		res[key] = append(res[key], c)
+
+		p.space()
+		if p.pos == len(p.src) {
+			return res, nil
+		}
+		if p.src[p.pos] != ',' {
+			return nil, p.errorf(p.pos, "unexpected %q, expected ,", p.src[p.pos])
+		}
+		p.pos++
+		p.space()
+	}
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	return &ParseError{Pos: pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) space() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// word consumes a label name, label value or keyword, that may be empty.
+func (p *parser) word() string {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n,()=!<>", p.src[p.pos]) < 0 {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *parser) key() (string, error) {
+	start := p.pos
+	key := p.word()
+	if key == "" {
+		return "", p.errorf(start, "expected label name")
+	}
+	if err := validateKey(key); err != nil {
+		return "", p.errorf(start, "%s", err.Error())
+	}
+	return key, nil
+}
+
+func (p *parser) value() (string, error) {
+	start := p.pos
+	value := p.word()
+	if err := validateValue(value); err != nil {
+		return "", p.errorf(start, "%s", err.Error())
+	}
+	return value, nil
+}
+
+func (p *parser) requirement() (string, *qtypes.String, error) {
+	if p.pos < len(p.src) && p.src[p.pos] == '!' {
+		p.pos++
+		p.space()
+		key, err := p.key()
+		if err != nil {
+			return "", nil, err
+		}
+		return key, qtypes.NullString(), nil
+	}
+
+	key, err := p.key()
+	if err != nil {
+		return "", nil, err
+	}
+	p.space()
+	if p.pos == len(p.src) || p.src[p.pos] == ',' {
+		return key, &qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}, nil
+	}
+
+	opPos := p.pos
+	for _, op := range []string{"==", "!=", "="} {
+		if !strings.HasPrefix(p.src[p.pos:], op) {
+			continue
+		}
+		p.pos += len(op)
+		p.space()
+		value, err := p.value()
+		if err != nil {
+			return "", nil, err
+		}
+		return key, &qtypes.String{Values: []string{value}, Valid: true, Negation: op == "!=", Type: qtypes.QueryType_EQUAL}, nil
+	}
+
+	switch op := p.word(); op {
+	case "in", "notin":
+		p.space()
+		values, err := p.set()
+		if err != nil {
+			return "", nil, err
+		}
+		return key, &qtypes.String{Values: values, Valid: true, Negation: op == "notin", Type: qtypes.QueryType_IN}, nil
+	default:
+		return "", nil, p.errorf(opPos, "expected one of =, ==, !=, in or notin")
+	}
+}
+
+// set parses a parenthesized set of values, e.g. (prod, staging).
+func (p *parser) set() ([]string, error) {
+	start := p.pos
+	if p.pos == len(p.src) || p.src[p.pos] != '(' {
+		return nil, p.errorf(start, "expected (")
+	}
+	p.pos++
+	p.space()
+	if p.pos < len(p.src) && p.src[p.pos] == ')' {
+		return nil, p.errorf(start, "set of values cannot be empty")
+	}
+
+	var values []string
+	for {
+		p.space()
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		p.space()
+		if p.pos == len(p.src) {
+			return nil, p.errorf(start, "unclosed parenthesis")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return values, nil
+		default:
+			return nil, p.errorf(p.pos, "unexpected %q in set of values", p.src[p.pos])
+		}
+	}
+}
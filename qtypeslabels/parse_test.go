@@ -0,0 +1,119 @@
+package qtypeslabels_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeslabels"
+	"google.golang.org/protobuf/proto"
+)
+
+func assertSelector(t *testing.T, hint string, expected, got qtypeslabels.Selector) {
+	t.Helper()
+
+	if len(expected) != len(got) {
+		t.Errorf("%s: wrong number of labels, expected %d but got %d: %v", hint, len(expected), len(got), got)
+		return
+	}
+	for key, conditions := range expected {
+		if len(conditions) != len(got[key]) {
+			t.Errorf("%s: wrong number of conditions of label %q, expected %d but got %d: %v", hint, key, len(conditions), len(got[key]), got[key])
+			continue
+		}
+		for i, c := range conditions {
+			if !proto.Equal(c, got[key][i]) {
+				t.Errorf("%s: wrong condition %d of label %q,\nexpected:\n	%v\nbut got:\n	%v\n", hint, i, key, c, got[key][i])
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		given    string
+		expected qtypeslabels.Selector
+	}{
+		"example": {
+			given: "env in (prod,staging),tier!=frontend,!legacy",
+			expected: qtypeslabels.Selector{
+				"env":    {&qtypes.String{Values: []string{"prod", "staging"}, Valid: true, Type: qtypes.QueryType_IN}},
+				"tier":   {&qtypes.String{Values: []string{"frontend"}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL}},
+				"legacy": {qtypes.NullString()},
+			},
+		},
+		"empty": {
+			given:    " ",
+			expected: qtypeslabels.Selector{},
+		},
+		"equality": {
+			given: "app=web, app.kubernetes.io/name == nginx,release=",
+			expected: qtypeslabels.Selector{
+				"app":                    {qtypes.EqualString("web")},
+				"app.kubernetes.io/name": {qtypes.EqualString("nginx")},
+				"release":                {qtypes.EqualString("")},
+			},
+		},
+		"exists": {
+			given: "canary , ! legacy",
+			expected: qtypeslabels.Selector{
+				"canary": {&qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}},
+				"legacy": {qtypes.NullString()},
+			},
+		},
+		"same-label": {
+			given: "env notin ( dev , test ),env",
+			expected: qtypeslabels.Selector{"env": {
+				&qtypes.String{Values: []string{"dev", "test"}, Valid: true, Negation: true, Type: qtypes.QueryType_IN},
+				&qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL},
+			}},
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypeslabels.Parse(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		assertSelector(t, hint, c.expected, got)
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	cases := map[string]struct {
+		given string
+		pos   int
+	}{
+		"missing-name":        {given: "app=web,", pos: 8},
+		"missing-name-of-not": {given: "!", pos: 1},
+		"invalid-name":        {given: "-app=web", pos: 0},
+		"invalid-prefix":      {given: "Example.com/app=web", pos: 0},
+		"too-long-name":       {given: "abcdefghijabcdefghijabcdefghijabcdefghijabcdefghijabcdefghijabcde=x", pos: 0},
+		"invalid-value":       {given: "app=web_", pos: 4},
+		"unknown-operator":    {given: "app>1", pos: 3},
+		"unknown-keyword":     {given: "app within (a)", pos: 4},
+		"missing-set":         {given: "app in a", pos: 7},
+		"empty-set":           {given: "app in ()", pos: 7},
+		"unclosed-set":        {given: "app in (a,b", pos: 7},
+		"unexpected-in-set":   {given: "app in (a b)", pos: 10},
+		"missing-separator":   {given: "app=web tier=db", pos: 8},
+		"not-with-value":      {given: "!app=web", pos: 4},
+	}
+
+	for hint, c := range cases {
+		_, err := qtypeslabels.Parse(c.given)
+		if err == nil {
+			t.Errorf("%s: expected error", hint)
+			continue
+		}
+		var pe *qtypeslabels.ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, qtypeslabels.ErrInvalidSelector) {
+			t.Errorf("%s: expected *ParseError, got: %s", hint, err.Error())
+			continue
+		}
+		if pe.Pos != c.pos {
+			t.Errorf("%s: wrong position, expected %d but got %d: %s", hint, c.pos, pe.Pos, err.Error())
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package qtypesmongo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var lengthExpr = regexp.MustCompile(`^\^\.\{(\d+),(\d*)\}\\z$`)
+
+// Decode parses given filter document. Fields that the schema does not list are rejected.
+//
+// Supported operators are $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin, $all, $elemMatch (with $eq, $in or $nin),
+// $regex (with $options i and s), $not and top level $and. A value without an operator stands for $eq.
+// Regular expressions have to be part of the portable subset, see qtypes.ValidatePattern.
+// Timestamps are RFC 3339 strings, optionally wrapped in {"$date": ...}, that accepts milliseconds since epoch as well.
+func (s Schema) Decode(data []byte) (Document, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, fmt.Errorf("qtypes: filter decoding error: %w", err)
+	}
+	doc := Document{}
+	if err := s.decode(doc, top); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (s Schema) decode(doc Document, top map[string]json.RawMessage) error {
+	for _, field := range slices.Sorted(maps.Keys(top)) {
+		raw := top[field]
+		if field == opAnd {
+			var docs []map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &docs); err != nil {
+				return fmt.Errorf("qtypes: filter decoding error for %s: %w", opAnd, err)
+			}
+			for _, d := range docs {
+				if err := s.decode(doc, d); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		kind, ok := s[field]
+		if !ok {
+			if strings.HasPrefix(field, "$") {
+				return fmt.Errorf("qtypes: filter decoding error: unsupported operator %s", field)
+			}
+			return fmt.Errorf("qtypes: filter decoding error: unknown field %q", field)
+		}
+		conds, err := decodeField(raw, kind != KindString)
+		if err != nil {
+			return fmt.Errorf("qtypes: filter decoding error for field %q: %w", field, err)
+		}
+		for _, c := range conds {
+			qc, err := kind.condition(c)
+			if err != nil {
+				return fmt.Errorf("qtypes: filter decoding error for field %q: %w", field, err)
+			}
+			doc[field] = append(doc[field], qc)
+		}
+	}
+	return nil
+}
+
+// decodeField decodes value of a field. Exclusive bounds of BETWEEN are allowed only if exclusive is true.
+func decodeField(raw json.RawMessage, exclusive bool) ([]cond, error) {
+	if isNull(raw) {
+		return []cond{{t: qtypes.QueryType_NULL}}, nil
+	}
+	if obj, ok := operatorObject(raw); ok {
+		return decodeOperators(obj, exclusive)
+	}
+	return []cond{{t: qtypes.QueryType_EQUAL, values: []json.RawMessage{raw}}}, nil
+}
+
+// operatorObject reports whether given value is an object of operators, e.g. {"$gt": 1}.
+func operatorObject(raw json.RawMessage) (map[string]json.RawMessage, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil, false
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil || len(obj) == 0 {
+		return nil, false
+	}
+	for k := range obj {
+		if !strings.HasPrefix(k, "$") || k == opDate {
+			return nil, false
+		}
+	}
+	return obj, true
+}
+
+func decodeOperators(obj map[string]json.RawMessage, exclusive bool) ([]cond, error) {
+	// Null guard of $not is implied by negation in qtypes, see not.
+	_, negated := obj[opNot]
+	guarded := negated && isNull(obj[opNe])
+
+	var res, lower, upper []cond
+	for _, op := range slices.Sorted(maps.Keys(obj)) {
+		v := obj[op]
+		switch op {
+		case opEq, opNe:
+			if op == opNe && guarded {
+				continue
+			}
+			c := cond{t: qtypes.QueryType_EQUAL, negation: op == opNe, values: []json.RawMessage{v}}
+			if isNull(v) {
+				c.t, c.values = qtypes.QueryType_NULL, nil
+			}
+			res = append(res, c)
+		case opGt:
+			lower = append(lower, cond{t: qtypes.QueryType_GREATER, values: []json.RawMessage{v}})
+		case opGte:
+			lower = append(lower, cond{t: qtypes.QueryType_GREATER_EQUAL, values: []json.RawMessage{v}})
+		case opLt:
+			upper = append(upper, cond{t: qtypes.QueryType_LESS, values: []json.RawMessage{v}})
+		case opLte:
+			upper = append(upper, cond{t: qtypes.QueryType_LESS_EQUAL, values: []json.RawMessage{v}})
+		case opIn, opNin, opAll:
+			var values []json.RawMessage
+			if err := json.Unmarshal(v, &values); err != nil {
+				return nil, fmt.Errorf("%s expects an array: %w", op, err)
+			}
+			t := qtypes.QueryType_IN
+			if op == opAll {
+				t = qtypes.QueryType_CONTAINS
+			}
+			if op == opNin {
+				res = append(res, decodeNin(values))
+				break
+			}
+			res = append(res, cond{t: t, values: values})
+		case opElemMatch:
+			c, err := decodeElemMatch(v)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, c)
+		case opRegex:
+			var pattern, options string
+			if err := json.Unmarshal(v, &pattern); err != nil {
+				return nil, fmt.Errorf("%s expects a string: %w", op, err)
+			}
+			if o, ok := obj[opOptions]; ok {
+				if err := json.Unmarshal(o, &options); err != nil {
+					return nil, fmt.Errorf("%s expects a string: %w", opOptions, err)
+				}
+			}
+			c, err := decodeRegex(pattern, options)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, c)
+		case opOptions:
+			if _, ok := obj[opRegex]; !ok {
+				return nil, fmt.Errorf("%s without %s", opOptions, opRegex)
+			}
+		case opNot:
+			inner, ok := operatorObject(v)
+			if !ok {
+				return nil, fmt.Errorf("%s expects an object of operators", opNot)
+			}
+			conds, err := decodeOperators(inner, exclusive)
+			if err != nil {
+				return nil, err
+			}
+			if len(conds) != 1 {
+				return nil, fmt.Errorf("%s of %d conditions cannot be represented", opNot, len(conds))
+			}
+			conds[0].negation = !conds[0].negation
+			if guarded && conds[0].t == qtypes.QueryType_NULL {
+				// Guard is not implied by a NULL condition.
+				res = append(res, cond{t: qtypes.QueryType_NULL, negation: true})
+			}
+			res = append(res, conds[0])
+		default:
+			return nil, fmt.Errorf("unsupported operator %s", op)
+		}
+	}
+
+	if len(lower) == 1 && len(upper) == 1 {
+		lo, hi := lower[0], upper[0]
+		if exclusive || (lo.t == qtypes.QueryType_GREATER_EQUAL && hi.t == qtypes.QueryType_LESS_EQUAL) {
+			return append(res, cond{
+				t:              qtypes.QueryType_BETWEEN,
+				values:         []json.RawMessage{lo.values[0], hi.values[0]},
+				lowerExclusive: lo.t == qtypes.QueryType_GREATER,
+				upperExclusive: hi.t == qtypes.QueryType_LESS,
+			}), nil
+		}
+	}
+	res = append(res, lower...)
+	return append(res, upper...), nil
+}
+
+// decodeNin decodes values of $nin as negated condition. Null guard is dropped, see nullGuarded,
+// and the rest is decoded as NULL, EQUAL or IN condition, depending on the number of values.
+func decodeNin(values []json.RawMessage) cond {
+	var (
+		rest    []json.RawMessage
+		guarded bool
+	)
+	for _, v := range values {
+		if isNull(v) {
+			guarded = true
+			continue
+		}
+		rest = append(rest, v)
+	}
+	switch {
+	case !guarded:
+		return cond{t: qtypes.QueryType_IN, negation: true, values: values}
+	case len(rest) == 0:
+		return cond{t: qtypes.QueryType_NULL, negation: true}
+	case len(rest) == 1:
+		return cond{t: qtypes.QueryType_EQUAL, negation: true, values: rest}
+	default:
+		return cond{t: qtypes.QueryType_IN, negation: true, values: rest}
+	}
+}
+
+func decodeElemMatch(raw json.RawMessage) (cond, error) {
+	obj, ok := operatorObject(raw)
+	if !ok || len(obj) != 1 {
+		return cond{}, fmt.Errorf("%s expects an object of a single operator", opElemMatch)
+	}
+	for op, v := range obj {
+		switch op {
+		case opEq:
+			return cond{t: qtypes.QueryType_HAS_ELEMENT, values: []json.RawMessage{v}}, nil
+		case opIn, opNin:
+			var values []json.RawMessage
+			if err := json.Unmarshal(v, &values); err != nil {
+				return cond{}, fmt.Errorf("%s expects an array: %w", op, err)
+			}
+			if op == opNin {
+				return cond{t: qtypes.QueryType_IS_CONTAINED_BY, negation: true, values: values}, nil
+			}
+			return cond{t: qtypes.QueryType_OVERLAP, values: values}, nil
+		}
+	}
+	return cond{}, fmt.Errorf("%s supports only %s, %s and %s operators", opElemMatch, opEq, opIn, opNin)
+}
+
+// decodeRegex decodes regular expression, as specific condition as possible.
+func decodeRegex(pattern, options string) (cond, error) {
+	var dotAll bool
+	c := cond{}
+	for _, o := range options {
+		switch o {
+		case 'i':
+			c.insensitive = true
+		case 's':
+			dotAll = true
+		default:
+			return cond{}, fmt.Errorf("unsupported regular expression option %q", o)
+		}
+	}
+
+	if m := lengthExpr.FindStringSubmatch(pattern); m != nil && dotAll {
+		switch {
+		case m[2] == "":
+			return cond{t: qtypes.QueryType_MIN_LENGTH, values: texts(m[1])}, nil
+		case m[1] == "0":
+			return cond{t: qtypes.QueryType_MAX_LENGTH, values: texts(m[2])}, nil
+		}
+	}
+
+	if body, ok := strings.CutPrefix(pattern, "^(?:"); ok {
+		if body, ok := strings.CutSuffix(body, ")"+endAnchor); ok {
+			var values []string
+			for _, alt := range splitAlternatives(body) {
+				v, ok := unquoteMeta(alt)
+				if !ok {
+					values = nil
+					break
+				}
+				values = append(values, v)
+			}
+			if len(values) > 0 {
+				c.t, c.values = qtypes.QueryType_IN, texts(values...)
+				return c, nil
+			}
+		}
+	}
+
+	body, start := strings.CutPrefix(pattern, "^")
+	if literal, end := strings.CutSuffix(body, endAnchor); end {
+		if v, ok := unquoteMeta(literal); ok {
+			c.t, c.values = qtypes.QueryType_HAS_SUFFIX, texts(v)
+			if start {
+				c.t = qtypes.QueryType_EQUAL
+			}
+			return c, nil
+		}
+	}
+	if v, ok := unquoteMeta(body); ok {
+		c.t, c.values = qtypes.QueryType_SUBSTRING, texts(v)
+		if start {
+			c.t = qtypes.QueryType_HAS_PREFIX
+		}
+		return c, nil
+	}
+
+	pattern, err := fromPCRE(pattern, dotAll)
+	if err != nil {
+		return cond{}, err
+	}
+	if err := qtypes.ValidatePattern(pattern); err != nil {
+		return cond{}, err
+	}
+	c.t, c.values = qtypes.QueryType_PATTERN, texts(pattern)
+	return c, nil
+}
+
+func texts(values ...string) []json.RawMessage {
+	res := make([]json.RawMessage, 0, len(values))
+	for _, v := range values {
+		b, _ := json.Marshal(v)
+		res = append(res, b)
+	}
+	return res
+}
+
+func isNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+// stringOnly lists query types that are expressed by regular expressions.
+var stringOnly = []qtypes.QueryType{
+	qtypes.QueryType_HAS_PREFIX,
+	qtypes.QueryType_HAS_SUFFIX,
+	qtypes.QueryType_SUBSTRING,
+	qtypes.QueryType_PATTERN,
+	qtypes.QueryType_MIN_LENGTH,
+	qtypes.QueryType_MAX_LENGTH,
+}
+
+func (k Kind) condition(c cond) (qtypes.Condition, error) {
+	if k != KindString && (c.insensitive || slices.Contains(stringOnly, c.t)) {
+		return nil, errors.New("regular expressions are supported only for strings")
+	}
+
+	switch k {
+	case KindInt64:
+		values, err := decodeValues(c.values, func(raw json.RawMessage) (int64, error) {
+			return strconv.ParseInt(string(bytes.TrimSpace(raw)), 10, 64)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.Int64{
+			Values:         values,
+			Valid:          true,
+			Negation:       c.negation,
+			Type:           c.t,
+			LowerExclusive: c.lowerExclusive,
+			UpperExclusive: c.upperExclusive,
+		}, nil
+	case KindUint64:
+		values, err := decodeValues(c.values, func(raw json.RawMessage) (uint64, error) {
+			return strconv.ParseUint(string(bytes.TrimSpace(raw)), 10, 64)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.Uint64{
+			Values:         values,
+			Valid:          true,
+			Negation:       c.negation,
+			Type:           c.t,
+			LowerExclusive: c.lowerExclusive,
+			UpperExclusive: c.upperExclusive,
+		}, nil
+	case KindFloat64:
+		values, err := decodeValues(c.values, func(raw json.RawMessage) (float64, error) {
+			var f float64
+			return f, json.Unmarshal(raw, &f)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.Float64{
+			Values:         values,
+			Valid:          true,
+			Negation:       c.negation,
+			Type:           c.t,
+			LowerExclusive: c.lowerExclusive,
+			UpperExclusive: c.upperExclusive,
+		}, nil
+	case KindTimestamp:
+		values, err := decodeValues(c.values, decodeTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.Timestamp{
+			Values:         values,
+			Valid:          true,
+			Negation:       c.negation,
+			Type:           c.t,
+			LowerExclusive: c.lowerExclusive,
+			UpperExclusive: c.upperExclusive,
+		}, nil
+	case KindString:
+		values, err := decodeValues(c.values, func(raw json.RawMessage) (string, error) {
+			var s string
+			return s, json.Unmarshal(raw, &s)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.String{
+			Values:      values,
+			Valid:       true,
+			Negation:    c.negation,
+			Type:        c.t,
+			Insensitive: c.insensitive,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown kind %d", k)
+	}
+}
+
+func decodeValues[T any](raw []json.RawMessage, fn func(json.RawMessage) (T, error)) ([]T, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	res := make([]T, 0, len(raw))
+	for i, r := range raw {
+		v, err := fn(r)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func decodeTimestamp(raw json.RawMessage) (*knowntimestamp.Timestamp, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '{' {
+		var date struct {
+			Date json.RawMessage `json:"$date"`
+		}
+		if err := json.Unmarshal(raw, &date); err != nil {
+			return nil, err
+		}
+		if date.Date == nil {
+			return nil, fmt.Errorf("timestamp object expects %s", opDate)
+		}
+		if ms, err := strconv.ParseInt(string(bytes.TrimSpace(date.Date)), 10, 64); err == nil {
+			return knowntimestamp.New(time.UnixMilli(ms)), nil
+		}
+		raw = date.Date
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, err
+	}
+	return knowntimestamp.New(t), nil
+}
@@ -0,0 +1,198 @@
+package qtypesmongo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesmongo"
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var schema = qtypesmongo.Schema{
+	"age":        qtypesmongo.KindInt64,
+	"id":         qtypesmongo.KindUint64,
+	"score":      qtypesmongo.KindFloat64,
+	"created_at": qtypesmongo.KindTimestamp,
+	"name":       qtypesmongo.KindString,
+	"tags":       qtypesmongo.KindString,
+}
+
+func assertDocument(t *testing.T, hint string, expected, got qtypesmongo.Document) {
+	t.Helper()
+
+	if len(expected) != len(got) {
+		t.Errorf("%s: wrong number of fields, expected %d but got %d: %v", hint, len(expected), len(got), got)
+		return
+	}
+	for field, conditions := range expected {
+		if len(conditions) != len(got[field]) {
+			t.Errorf("%s: wrong number of conditions of field %q, expected %d but got %d", hint, field, len(conditions), len(got[field]))
+			continue
+		}
+		for i, c := range conditions {
+			if !proto.Equal(c, got[field][i]) {
+				t.Errorf("%s: wrong condition %d of field %q,\nexpected:\n	%v\nbut got:\n	%v\n", hint, i, field, c, got[field][i])
+			}
+		}
+	}
+}
+
+func TestSchema_Decode(t *testing.T) {
+	created := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		given    string
+		expected qtypesmongo.Document
+	}{
+		"example": {
+			given: `{"age": {"$gte": 18}, "name": {"$regex": "^jo"}}`,
+			expected: qtypesmongo.Document{
+				"age":  {qtypes.GreaterEqualInt64(18)},
+				"name": {qtypes.HasPrefixString("jo")},
+			},
+		},
+		"empty": {
+			given:    `{}`,
+			expected: qtypesmongo.Document{},
+		},
+		"equal-shorthand": {
+			given:    `{"age": 18, "name": "john"}`,
+			expected: qtypesmongo.Document{"age": {qtypes.EqualInt64(18)}, "name": {qtypes.EqualString("john")}},
+		},
+		"null": {
+			given:    `{"age": null, "name": {"$ne": null}}`,
+			expected: qtypesmongo.Document{"age": {qtypes.NullInt64()}, "name": {&qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}}},
+		},
+		"not-equal": {
+			given:    `{"id": {"$ne": 18446744073709551615}}`,
+			expected: qtypesmongo.Document{"id": {&qtypes.Uint64{Values: []uint64{18446744073709551615}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL}}},
+		},
+		"null-guard": {
+			given: `{"age": {"$nin": [null, 1]}, "id": {"$nin": [null, 1, 2]}, "name": {"$ne": null, "$not": {"$regex": "^a"}}, "score": {"$nin": [null]}}`,
+			expected: qtypesmongo.Document{
+				"age":   {qtypes.NotEqualInt64(1)},
+				"id":    {&qtypes.Uint64{Values: []uint64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}},
+				"name":  {&qtypes.String{Values: []string{"a"}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_PREFIX}},
+				"score": {&qtypes.Float64{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}},
+			},
+		},
+		"null-guard-of-null": {
+			given:    `{"age": {"$ne": null, "$not": {"$ne": null}}}`,
+			expected: qtypesmongo.Document{"age": {&qtypes.Int64{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}, qtypes.NullInt64()}},
+		},
+		"between": {
+			given:    `{"age": {"$gt": 18, "$lte": 65}}`,
+			expected: qtypesmongo.Document{"age": {qtypes.RangeInt64(18, 65, true, false)}},
+		},
+		"not-between": {
+			given:    `{"score": {"$not": {"$gte": 0.5, "$lt": 1.5}}}`,
+			expected: qtypesmongo.Document{"score": {&qtypes.Float64{Values: []float64{0.5, 1.5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN, UpperExclusive: true}}},
+		},
+		"string-between": {
+			given:    `{"name": {"$gte": "a", "$lte": "f"}}`,
+			expected: qtypesmongo.Document{"name": {qtypes.BetweenString("a", "f")}},
+		},
+		"string-exclusive-range": {
+			given:    `{"name": {"$gte": "a", "$lt": "g"}}`,
+			expected: qtypesmongo.Document{"name": {qtypes.GreaterEqualString("a"), qtypes.LessString("g")}},
+		},
+		"in": {
+			given:    `{"age": {"$in": [1, 2]}, "name": {"$nin": ["a", "b"]}}`,
+			expected: qtypesmongo.Document{"age": {qtypes.InInt64(1, 2)}, "name": {&qtypes.String{Values: []string{"a", "b"}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}}},
+		},
+		"timestamp": {
+			given: `{"created_at": {"$gte": "2024-05-01T12:00:00Z", "$lt": {"$date": 1714564800000}}}`,
+			expected: qtypesmongo.Document{"created_at": {&qtypes.Timestamp{
+				Values:         []*knowntimestamp.Timestamp{knowntimestamp.New(created), knowntimestamp.New(created)},
+				Valid:          true,
+				Type:           qtypes.QueryType_BETWEEN,
+				UpperExclusive: true,
+			}}},
+		},
+		"timestamp-date": {
+			given:    `{"created_at": {"$date": "2024-05-01T12:00:00Z"}}`,
+			expected: qtypesmongo.Document{"created_at": {&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{knowntimestamp.New(created)}, Valid: true, Type: qtypes.QueryType_EQUAL}}},
+		},
+		"regex-equal-insensitive": {
+			given:    `{"name": {"$regex": "^john@example\\.com\\z", "$options": "i"}}`,
+			expected: qtypesmongo.Document{"name": {qtypes.EqualStringInsensitive("john@example.com")}},
+		},
+		"regex-in-insensitive": {
+			given:    `{"name": {"$regex": "^(?:john|jane\\|doe)\\z", "$options": "i"}}`,
+			expected: qtypesmongo.Document{"name": {qtypes.InStringInsensitive("john", "jane|doe")}},
+		},
+		"regex-suffix": {
+			given:    `{"name": {"$regex": "\\.com\\z"}}`,
+			expected: qtypesmongo.Document{"name": {qtypes.HasSuffixString(".com")}},
+		},
+		"regex-substring": {
+			given:    `{"name": {"$not": {"$regex": "test\\$"}}}`,
+			expected: qtypesmongo.Document{"name": {&qtypes.String{Values: []string{"test$"}, Valid: true, Negation: true, Type: qtypes.QueryType_SUBSTRING}}},
+		},
+		"regex-pattern": {
+			given:    `{"name": {"$regex": "^j.*n\\z", "$options": "is"}}`,
+			expected: qtypesmongo.Document{"name": {&qtypes.String{Values: []string{"^j.*n$"}, Valid: true, Type: qtypes.QueryType_PATTERN, Insensitive: true}}},
+		},
+		"regex-dot": {
+			given:    `{"name": {"$regex": "a.b"}, "tags": {"$regex": "^a.[.]\\.", "$options": "s"}}`,
+			expected: qtypesmongo.Document{"name": {&qtypes.String{Values: []string{"a[^\n]b"}, Valid: true, Type: qtypes.QueryType_PATTERN}}, "tags": {&qtypes.String{Values: []string{`^a.[.]\.`}, Valid: true, Type: qtypes.QueryType_PATTERN}}},
+		},
+		"regex-length": {
+			given: `{"$and": [{"name": {"$regex": "^.{2,}\\z", "$options": "s"}}, {"name": {"$regex": "^.{0,10}\\z", "$options": "s"}}]}`,
+			expected: qtypesmongo.Document{"name": {
+				&qtypes.String{Values: []string{"2"}, Valid: true, Type: qtypes.QueryType_MIN_LENGTH},
+				&qtypes.String{Values: []string{"10"}, Valid: true, Type: qtypes.QueryType_MAX_LENGTH},
+			}},
+		},
+		"elements": {
+			given: `{"tags": {"$all": ["a", "b"], "$elemMatch": {"$in": ["c"]}}, "age": {"$not": {"$elemMatch": {"$nin": [1, 2]}}}, "id": {"$elemMatch": {"$eq": 1}}}`,
+			expected: qtypesmongo.Document{
+				"tags": {
+					&qtypes.String{Values: []string{"a", "b"}, Valid: true, Type: qtypes.QueryType_CONTAINS},
+					&qtypes.String{Values: []string{"c"}, Valid: true, Type: qtypes.QueryType_OVERLAP},
+				},
+				"age": {&qtypes.Int64{Values: []int64{1, 2}, Valid: true, Type: qtypes.QueryType_IS_CONTAINED_BY}},
+				"id":  {&qtypes.Uint64{Values: []uint64{1}, Valid: true, Type: qtypes.QueryType_HAS_ELEMENT}},
+			},
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := schema.Decode([]byte(c.given))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		assertDocument(t, hint, c.expected, got)
+	}
+}
+
+func TestSchema_Decode_error(t *testing.T) {
+	cases := map[string]string{
+		"not-an-object":         `[]`,
+		"unknown-field":         `{"email": "a"}`,
+		"unsupported-operator":  `{"age": {"$size": 1}}`,
+		"top-level-or":          `{"$or": [{"age": 1}]}`,
+		"float-for-int":         `{"age": 1.5}`,
+		"string-for-int":        `{"age": "1"}`,
+		"regex-for-int":         `{"age": {"$regex": "^1"}}`,
+		"regex-option":          `{"name": {"$regex": "^a", "$options": "m"}}`,
+		"options-without-regex": `{"name": {"$options": "i"}}`,
+		"unsupported-pattern":   `{"name": {"$regex": "(?<=a)b"}}`,
+		"end-anchor":            `{"name": {"$regex": "^a.*b$", "$options": "s"}}`,
+		"not-of-two":            `{"name": {"$not": {"$eq": "a", "$regex": "b"}}}`,
+		"not-of-value":          `{"name": {"$not": "a"}}`,
+		"in-of-value":           `{"name": {"$in": "a"}}`,
+		"elem-match":            `{"name": {"$elemMatch": {"$gt": "a"}}}`,
+		"timestamp":             `{"created_at": "yesterday"}`,
+		"embedded-document":     `{"name": {"first": "john"}}`,
+	}
+
+	for hint, given := range cases {
+		if _, err := schema.Decode([]byte(given)); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
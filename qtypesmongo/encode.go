@@ -0,0 +1,318 @@
+package qtypesmongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// MarshalJSON implements json.Marshaler interface. Invalid conditions are skipped.
+// Conditions on the same field that cannot share an object of operators, e.g. two HAS_PREFIX conditions, are combined using $and.
+func (d Document) MarshalJSON() ([]byte, error) {
+	// Each layer is a document of operators keyed by field name, layers are combined using $and.
+	var layers []map[string]map[string]json.RawMessage
+	for _, field := range slices.Sorted(maps.Keys(d)) {
+		for i, c := range d[field] {
+			ops, err := encodeCondition(c)
+			if err != nil {
+				return nil, fmt.Errorf("qtypes: filter encoding error for condition %d of field %q: %w", i, field, err)
+			}
+			if ops == nil {
+				continue
+			}
+			layers = place(layers, field, ops)
+		}
+	}
+
+	docs := make([]map[string]json.RawMessage, 0, len(layers))
+	for _, layer := range layers {
+		doc := make(map[string]json.RawMessage, len(layer))
+		for field, ops := range layer {
+			b, err := json.Marshal(ops)
+			if err != nil {
+				return nil, err
+			}
+			doc[field] = b
+		}
+		docs = append(docs, doc)
+	}
+	switch len(docs) {
+	case 0:
+		return []byte("{}"), nil
+	case 1:
+		return json.Marshal(docs[0])
+	default:
+		return json.Marshal(map[string]interface{}{opAnd: docs})
+	}
+}
+
+// place merges operators into the first layer in which they do not collide with operators of the same field.
+func place(layers []map[string]map[string]json.RawMessage, field string, ops map[string]json.RawMessage) []map[string]map[string]json.RawMessage {
+	for _, layer := range layers {
+		existing, ok := layer[field]
+		if !ok {
+			layer[field] = ops
+			return layers
+		}
+		collides := false
+		for op := range ops {
+			if _, ok := existing[op]; ok {
+				collides = true
+				break
+			}
+		}
+		if !collides {
+			maps.Copy(existing, ops)
+			return layers
+		}
+	}
+	return append(layers, map[string]map[string]json.RawMessage{field: ops})
+}
+
+// encodeCondition returns nil if the condition is not valid.
+func encodeCondition(c qtypes.Condition) (map[string]json.RawMessage, error) {
+	if c == nil || !c.GetValid() {
+		return nil, nil
+	}
+
+	var (
+		cc   cond
+		strs []string
+	)
+	switch v := c.(type) {
+	case *qtypes.Int64:
+		cc = cond{lowerExclusive: v.LowerExclusive, upperExclusive: v.UpperExclusive}
+		for _, n := range v.Values {
+			cc.values = append(cc.values, strconv.AppendInt(nil, n, 10))
+		}
+	case *qtypes.Uint64:
+		cc = cond{lowerExclusive: v.LowerExclusive, upperExclusive: v.UpperExclusive}
+		for _, n := range v.Values {
+			cc.values = append(cc.values, strconv.AppendUint(nil, n, 10))
+		}
+	case *qtypes.Float64:
+		cc = cond{lowerExclusive: v.LowerExclusive, upperExclusive: v.UpperExclusive}
+		for _, f := range v.Values {
+			b, err := json.Marshal(f)
+			if err != nil {
+				return nil, err
+			}
+			cc.values = append(cc.values, b)
+		}
+	case *qtypes.Timestamp:
+		cc = cond{lowerExclusive: v.LowerExclusive, upperExclusive: v.UpperExclusive}
+		for i, t := range v.Values {
+			if t == nil {
+				return nil, fmt.Errorf("nil timestamp at position %d", i)
+			}
+			b, err := json.Marshal(map[string]string{opDate: t.AsTime().Format(time.RFC3339Nano)})
+			if err != nil {
+				return nil, err
+			}
+			cc.values = append(cc.values, b)
+		}
+	case *qtypes.String:
+		cc = cond{insensitive: v.Insensitive, values: texts(v.Values...)}
+		strs = v.Values
+		if strs == nil {
+			strs = []string{}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported condition type %T", c)
+	}
+	cc.t, cc.negation = c.GetType(), c.GetNegation()
+	return encodeOperators(cc, strs)
+}
+
+// encodeOperators returns object of operators that expresses given condition.
+// Texts are values of string conditions, they are nil for other types.
+func encodeOperators(c cond, texts []string) (map[string]json.RawMessage, error) {
+	count := func(n int) error {
+		if len(c.values) != n {
+			return fmt.Errorf("%s condition requires %d value(s) but got %d", c.t, n, len(c.values))
+		}
+		return nil
+	}
+	array := func() json.RawMessage {
+		b, _ := json.Marshal(c.values)
+		if c.values == nil {
+			b = []byte("[]")
+		}
+		return b
+	}
+
+	var ops map[string]json.RawMessage
+	switch c.t {
+	case qtypes.QueryType_NULL:
+		if c.negation {
+			return map[string]json.RawMessage{opNe: json.RawMessage("null")}, nil
+		}
+		return map[string]json.RawMessage{opEq: json.RawMessage("null")}, nil
+	case qtypes.QueryType_EQUAL:
+		if err := count(1); err != nil {
+			return nil, err
+		}
+		if c.insensitive && texts != nil {
+			ops = regex("^"+regexp.QuoteMeta(texts[0])+endAnchor, c.insensitive, false)
+			break
+		}
+		if c.negation {
+			return map[string]json.RawMessage{opNin: nullGuarded(c.values...)}, nil
+		}
+		return map[string]json.RawMessage{opEq: c.values[0]}, nil
+	case qtypes.QueryType_GREATER, qtypes.QueryType_GREATER_EQUAL, qtypes.QueryType_LESS, qtypes.QueryType_LESS_EQUAL:
+		if err := count(1); err != nil {
+			return nil, err
+		}
+		op := map[qtypes.QueryType]string{
+			qtypes.QueryType_GREATER:       opGt,
+			qtypes.QueryType_GREATER_EQUAL: opGte,
+			qtypes.QueryType_LESS:          opLt,
+			qtypes.QueryType_LESS_EQUAL:    opLte,
+		}[c.t]
+		ops = map[string]json.RawMessage{op: c.values[0]}
+	case qtypes.QueryType_IN:
+		if c.insensitive && texts != nil && len(texts) > 0 {
+			quoted := make([]string, 0, len(texts))
+			for _, t := range texts {
+				quoted = append(quoted, regexp.QuoteMeta(t))
+			}
+			ops = regex("^(?:"+strings.Join(quoted, "|")+")"+endAnchor, c.insensitive, false)
+			break
+		}
+		if c.negation {
+			return map[string]json.RawMessage{opNin: nullGuarded(c.values...)}, nil
+		}
+		return map[string]json.RawMessage{opIn: array()}, nil
+	case qtypes.QueryType_BETWEEN:
+		if err := count(2); err != nil {
+			return nil, err
+		}
+		lower, upper := opGte, opLte
+		if c.lowerExclusive {
+			lower = opGt
+		}
+		if c.upperExclusive {
+			upper = opLt
+		}
+		ops = map[string]json.RawMessage{lower: c.values[0], upper: c.values[1]}
+	case qtypes.QueryType_HAS_PREFIX, qtypes.QueryType_HAS_SUFFIX, qtypes.QueryType_SUBSTRING,
+		qtypes.QueryType_PATTERN, qtypes.QueryType_WILDCARD, qtypes.QueryType_MIN_LENGTH, qtypes.QueryType_MAX_LENGTH:
+		if texts == nil {
+			return nil, fmt.Errorf("%s condition is supported only for strings", c.t)
+		}
+		if err := count(1); err != nil {
+			return nil, err
+		}
+		v := texts[0]
+		switch c.t {
+		case qtypes.QueryType_HAS_PREFIX:
+			ops = regex("^"+regexp.QuoteMeta(v), c.insensitive, false)
+		case qtypes.QueryType_HAS_SUFFIX:
+			ops = regex(regexp.QuoteMeta(v)+endAnchor, c.insensitive, false)
+		case qtypes.QueryType_SUBSTRING:
+			ops = regex(regexp.QuoteMeta(v), c.insensitive, false)
+		case qtypes.QueryType_PATTERN:
+			ops = regex(toPCRE(v), c.insensitive, true)
+		case qtypes.QueryType_WILDCARD:
+			ops = regex("^"+wildcardRegex(v)+endAnchor, c.insensitive, true)
+		default:
+			n, err := strconv.ParseUint(v, 10, 63)
+			if err != nil {
+				return nil, fmt.Errorf("%s condition requires a number: %w", c.t, err)
+			}
+			if c.t == qtypes.QueryType_MIN_LENGTH {
+				ops = regex(fmt.Sprintf("^.{%d,}"+endAnchor, n), false, true)
+			} else {
+				ops = regex(fmt.Sprintf("^.{0,%d}"+endAnchor, n), false, true)
+			}
+		}
+	case qtypes.QueryType_HAS_ELEMENT:
+		if err := count(1); err != nil {
+			return nil, err
+		}
+		ops = elemMatch(opEq, c.values[0])
+	case qtypes.QueryType_HAS_ANY_ELEMENT, qtypes.QueryType_OVERLAP:
+		ops = elemMatch(opIn, array())
+	case qtypes.QueryType_CONTAINS, qtypes.QueryType_HAS_ALL_ELEMENTS:
+		ops = map[string]json.RawMessage{opAll: array()}
+	case qtypes.QueryType_IS_CONTAINED_BY:
+		// Every element is one of values, so no element is out of them.
+		ops = elemMatch(opNin, array())
+		if c.negation {
+			return ops, nil
+		}
+		return not(ops), nil
+	default:
+		return nil, fmt.Errorf("unsupported query type %s", c.t)
+	}
+
+	if c.negation {
+		return not(ops), nil
+	}
+	return ops, nil
+}
+
+func regex(pattern string, insensitive, dotAll bool) map[string]json.RawMessage {
+	b, _ := json.Marshal(pattern)
+	ops := map[string]json.RawMessage{opRegex: b}
+
+	var options string
+	if insensitive {
+		options += "i"
+	}
+	if dotAll {
+		options += "s"
+	}
+	if options != "" {
+		ops[opOptions] = json.RawMessage(strconv.Quote(options))
+	}
+	return ops
+}
+
+func elemMatch(op string, v json.RawMessage) map[string]json.RawMessage {
+	b, _ := json.Marshal(map[string]json.RawMessage{op: v})
+	return map[string]json.RawMessage{opElemMatch: b}
+}
+
+// not negates given operators. Unlike negation in qtypes, $not alone is satisfied by a missing or null field,
+// so it is guarded by $ne null.
+func not(ops map[string]json.RawMessage) map[string]json.RawMessage {
+	b, _ := json.Marshal(ops)
+	return map[string]json.RawMessage{opNe: json.RawMessage("null"), opNot: b}
+}
+
+// nullGuarded returns array of null and given values, for $nin to be satisfied by neither a missing nor null field.
+func nullGuarded(values ...json.RawMessage) json.RawMessage {
+	b, _ := json.Marshal(append([]json.RawMessage{json.RawMessage("null")}, values...))
+	return b
+}
+
+// wildcardRegex translates WILDCARD pattern to regular expression, see qtypes.MatchWildcard.
+func wildcardRegex(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteByte('.')
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				c = pattern[i]
+			}
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,179 @@
+package qtypesmongo_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesmongo"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDocument_MarshalJSON(t *testing.T) {
+	created := knowntimestamp.New(time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		given    qtypesmongo.Document
+		expected string
+	}{
+		"example": {
+			given: qtypesmongo.Document{
+				"age":  {qtypes.GreaterEqualInt64(18)},
+				"name": {qtypes.HasPrefixString("jo")},
+			},
+			expected: `{"age":{"$gte":18},"name":{"$regex":"^jo"}}`,
+		},
+		"empty": {
+			given:    qtypesmongo.Document{},
+			expected: `{}`,
+		},
+		"invalid": {
+			given:    qtypesmongo.Document{"age": {&qtypes.Int64{Values: []int64{1}}}, "name": {nil}},
+			expected: `{}`,
+		},
+		"null": {
+			given:    qtypesmongo.Document{"age": {qtypes.NullInt64()}},
+			expected: `{"age":{"$eq":null}}`,
+		},
+		"not-equal": {
+			given:    qtypesmongo.Document{"age": {qtypes.NotEqualInt64(1)}},
+			expected: `{"age":{"$nin":[null,1]}}`,
+		},
+		"between": {
+			given:    qtypesmongo.Document{"score": {qtypes.RangeFloat64(0.5, 1.5, true, false)}},
+			expected: `{"score":{"$gt":0.5,"$lte":1.5}}`,
+		},
+		"not-between": {
+			given:    qtypesmongo.Document{"age": {&qtypes.Int64{Values: []int64{1, 5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}}},
+			expected: `{"age":{"$ne":null,"$not":{"$gte":1,"$lte":5}}}`,
+		},
+		"timestamp": {
+			given:    qtypesmongo.Document{"created_at": {&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{created}, Valid: true, Type: qtypes.QueryType_EQUAL}}},
+			expected: `{"created_at":{"$eq":{"$date":"2024-05-01T12:00:00Z"}}}`,
+		},
+		"in": {
+			given:    qtypesmongo.Document{"id": {&qtypes.Uint64{Values: []uint64{1, 2}, Valid: true, Type: qtypes.QueryType_IN}}},
+			expected: `{"id":{"$in":[1,2]}}`,
+		},
+		"not-in": {
+			given:    qtypesmongo.Document{"name": {&qtypes.String{Values: []string{"a"}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}}},
+			expected: `{"name":{"$nin":[null,"a"]}}`,
+		},
+		"equal-insensitive": {
+			given:    qtypesmongo.Document{"name": {qtypes.EqualStringInsensitive("a.b")}},
+			expected: `{"name":{"$options":"i","$regex":"^a\\.b\\z"}}`,
+		},
+		"in-insensitive": {
+			given:    qtypesmongo.Document{"name": {qtypes.InStringInsensitive("a", "b|c")}},
+			expected: `{"name":{"$options":"i","$regex":"^(?:a|b\\|c)\\z"}}`,
+		},
+		"negated-suffix": {
+			given:    qtypesmongo.Document{"name": {&qtypes.String{Values: []string{".com"}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_SUFFIX}}},
+			expected: `{"name":{"$ne":null,"$not":{"$regex":"\\.com\\z"}}}`,
+		},
+		"pattern": {
+			given:    qtypesmongo.Document{"name": {&qtypes.String{Values: []string{`^a\$[$]|b$`}, Valid: true, Type: qtypes.QueryType_PATTERN}}},
+			expected: `{"name":{"$options":"s","$regex":"^a\\$[$]|b\\z"}}`,
+		},
+		"wildcard": {
+			given:    qtypesmongo.Document{"name": {qtypes.WildcardString(`jo*n?\*`)}},
+			expected: `{"name":{"$options":"s","$regex":"^jo.*n.\\*\\z"}}`,
+		},
+		"length": {
+			given: qtypesmongo.Document{"name": {
+				&qtypes.String{Values: []string{"2"}, Valid: true, Type: qtypes.QueryType_MIN_LENGTH},
+				&qtypes.String{Values: []string{"10"}, Valid: true, Type: qtypes.QueryType_MAX_LENGTH},
+			}},
+			expected: `{"$and":[{"name":{"$options":"s","$regex":"^.{2,}\\z"}},{"name":{"$options":"s","$regex":"^.{0,10}\\z"}}]}`,
+		},
+		"same-field": {
+			given:    qtypesmongo.Document{"age": {qtypes.GreaterInt64(1), qtypes.LessInt64(5), qtypes.NotEqualInt64(3)}},
+			expected: `{"age":{"$gt":1,"$lt":5,"$nin":[null,3]}}`,
+		},
+		"elements": {
+			given: qtypesmongo.Document{
+				"age":  {&qtypes.Int64{Values: []int64{1, 2}, Valid: true, Type: qtypes.QueryType_IS_CONTAINED_BY}},
+				"id":   {&qtypes.Uint64{Values: []uint64{1}, Valid: true, Type: qtypes.QueryType_HAS_ELEMENT}},
+				"tags": {&qtypes.String{Values: []string{"a", "b"}, Valid: true, Type: qtypes.QueryType_HAS_ALL_ELEMENTS}},
+			},
+			expected: `{"age":{"$ne":null,"$not":{"$elemMatch":{"$nin":[1,2]}}},"id":{"$elemMatch":{"$eq":1}},"tags":{"$all":["a","b"]}}`,
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := json.Marshal(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if string(got) != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%s\nbut got:\n	%s\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestDocument_MarshalJSON_error(t *testing.T) {
+	cases := map[string]qtypesmongo.Document{
+		"between-single-value":  {"age": {&qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_BETWEEN}}},
+		"prefix-of-int":         {"age": {&qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_HAS_PREFIX}}},
+		"min-length-not-number": {"name": {&qtypes.String{Values: []string{"a"}, Valid: true, Type: qtypes.QueryType_MIN_LENGTH}}},
+		"nil-timestamp":         {"created_at": {&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{nil}, Valid: true, Type: qtypes.QueryType_EQUAL}}},
+	}
+
+	for hint, given := range cases {
+		if _, err := json.Marshal(given); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
+
+func TestDocument_roundTrip(t *testing.T) {
+	from := knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC))
+	to := knowntimestamp.New(time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC))
+
+	cases := map[string]qtypesmongo.Document{
+		"comparisons": {
+			"age":        {qtypes.RangeInt64(1, 5, true, false)},
+			"id":         {&qtypes.Uint64{Values: []uint64{18446744073709551615}, Valid: true, Type: qtypes.QueryType_EQUAL}},
+			"score":      {qtypes.RangeFloat64(0.1, 0.2, true, true)},
+			"created_at": {qtypes.RangeTimestamp(from, to, false, true)},
+		},
+		"strings": {
+			"name": {
+				&qtypes.String{Values: []string{"^j.*n$"}, Valid: true, Negation: true, Type: qtypes.QueryType_PATTERN, Insensitive: true},
+				qtypes.HasPrefixString("jo"),
+				qtypes.HasSuffixString(".com"),
+				qtypes.SubString("a+b"),
+			},
+		},
+		"insensitive": {
+			"name": {qtypes.EqualStringInsensitive("John"), qtypes.InStringInsensitive("a", "b")},
+		},
+		"negations": {
+			"age":   {qtypes.NotEqualInt64(3), &qtypes.Int64{Values: []int64{1, 5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}},
+			"id":    {&qtypes.Uint64{Values: []uint64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}},
+			"score": {&qtypes.Float64{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}},
+			"tags":  {&qtypes.String{Values: []string{"a", "b"}, Valid: true, Type: qtypes.QueryType_IS_CONTAINED_BY}},
+		},
+		"elements": {
+			"age":  {&qtypes.Int64{Values: []int64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IS_CONTAINED_BY}},
+			"tags": {&qtypes.String{Values: []string{"a"}, Valid: true, Type: qtypes.QueryType_OVERLAP}},
+			"id":   {&qtypes.Uint64{Values: []uint64{7}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_ELEMENT}},
+		},
+	}
+
+	for hint, given := range cases {
+		b, err := json.Marshal(given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		got, err := schema.Decode(b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		assertDocument(t, hint, given, got)
+	}
+}
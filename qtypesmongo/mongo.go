@@ -0,0 +1,203 @@
+// Package qtypesmongo translates conditions to and from MongoDB style JSON filter documents,
+// e.g. {"age": {"$gte": 18}, "name": {"$regex": "^jo"}}.
+package qtypesmongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Kind is a type of conditions that a field holds.
+type Kind int
+
+const (
+	// KindInt64 is a field of qtypes.Int64 conditions.
+	KindInt64 Kind = iota + 1
+	// KindUint64 is a field of qtypes.Uint64 conditions.
+	KindUint64
+	// KindFloat64 is a field of qtypes.Float64 conditions.
+	KindFloat64
+	// KindTimestamp is a field of qtypes.Timestamp conditions.
+	KindTimestamp
+	// KindString is a field of qtypes.String conditions.
+	KindString
+)
+
+// Schema lists fields that a document can filter by, JSON values alone do not tell e.g. integers and floats apart.
+type Schema map[string]Kind
+
+// Document is a filter document, conditions are keyed by field name.
+// Conditions on the same field have to be satisfied all together, like operators of a MongoDB filter.
+// Decoded conditions are of type that the schema declares for the field, e.g. *qtypes.Int64 for KindInt64.
+//
+// Translation of the following query types is not reversible, as MongoDB expresses them in the same way:
+//   - CONTAINS and HAS_ALL_ELEMENTS ($all), the former is decoded,
+//   - OVERLAP and HAS_ANY_ELEMENT ($elemMatch with $in), the former is decoded,
+//   - WILDCARD and PATTERN ($regex), the latter is decoded,
+//   - negated EQUAL and negated IN of a single value ($nin), the former is decoded,
+//   - lower and upper bound on the same field, e.g. GREATER and LESS_EQUAL, that are decoded as BETWEEN
+//     (for strings only if both bounds are inclusive).
+//
+// Regular expressions that match literal text are decoded as more specific conditions, e.g. "^jo" as HAS_PREFIX.
+// Encoded regular expressions anchor the end of the text with \z, as $ matches before a trailing new line as well,
+// so decoded regular expressions cannot use $. In decoded regular expressions . matches a new line
+// only if "s" option is set, like in MongoDB.
+//
+// Negation in qtypes is never satisfied by a missing or null field, while $ne, $nin and $not are.
+// Negated conditions are therefore encoded with a null guard, e.g. {"$nin": [null, "x"]}
+// or {"$ne": null, "$not": {"$regex": "^x"}}, that is dropped when decoded.
+// Decoded negations without the guard are narrowed to qtypes semantics, that excludes missing and null fields.
+type Document map[string][]qtypes.Condition
+
+const (
+	opEq        = "$eq"
+	opNe        = "$ne"
+	opGt        = "$gt"
+	opGte       = "$gte"
+	opLt        = "$lt"
+	opLte       = "$lte"
+	opIn        = "$in"
+	opNin       = "$nin"
+	opAll       = "$all"
+	opElemMatch = "$elemMatch"
+	opRegex     = "$regex"
+	opOptions   = "$options"
+	opNot       = "$not"
+	opAnd       = "$and"
+	opDate      = "$date"
+)
+
+// cond is a condition of any kind, with values encoded as JSON.
+type cond struct {
+	t              qtypes.QueryType
+	negation       bool
+	insensitive    bool
+	lowerExclusive bool
+	upperExclusive bool
+	values         []json.RawMessage
+}
+
+// regexSpecial lists characters that regexp.QuoteMeta escapes.
+const regexSpecial = `\.+*?()|[]{}^$`
+
+// endAnchor matches only at the end of the text, unlike $, that matches before a trailing new line as well.
+const endAnchor = `\z`
+
+// toPCRE returns regular expression, that matches what given PATTERN value does when "s" option is set.
+// The value has to be validated already.
+func toPCRE(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '\\':
+			b.WriteString(pattern[i : i+2])
+			i++
+		case '[':
+			end := closing(pattern, i+1)
+			b.WriteString(pattern[i:min(end+1, len(pattern))])
+			i = end
+		case '$':
+			b.WriteString(endAnchor)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// fromPCRE returns PATTERN value, that matches what given regular expression does,
+// when "s" option is set if dotAll is true. Unlike in PATTERN, . does not match a new line without the option.
+// The $ anchor is rejected, as PATTERN cannot express that it matches before a trailing new line as well.
+func fromPCRE(expr string, dotAll bool) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; c {
+		case '\\':
+			switch {
+			case i+1 == len(expr):
+				b.WriteByte(c)
+			case expr[i+1] == 'z':
+				b.WriteByte('$')
+			default:
+				b.WriteString(expr[i : i+2])
+			}
+			i++
+		case '[':
+			end := closing(expr, i+1)
+			b.WriteString(expr[i:min(end+1, len(expr))])
+			i = end
+		case '.':
+			if dotAll {
+				b.WriteByte(c)
+			} else {
+				b.WriteString("[^\n]")
+			}
+		case '$':
+			return "", fmt.Errorf("regular expression anchor $ at position %d matches before a trailing new line, use %s instead", i, endAnchor)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// closing returns position of the end of bracket expression that content starts at i.
+func closing(expr string, i int) int {
+	if i < len(expr) && expr[i] == '^' {
+		i++
+	}
+	if i < len(expr) && expr[i] == ']' {
+		i++
+	}
+	for ; i < len(expr) && expr[i] != ']'; i++ {
+		if strings.HasPrefix(expr[i:], "[:") {
+			if j := strings.Index(expr[i+2:], ":]"); j >= 0 {
+				i += j + 3
+			}
+		}
+	}
+	return i
+}
+
+// unquoteMeta returns text that given regular expression matches literally.
+// It reports false if the expression contains any unescaped special character.
+func unquoteMeta(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(s) || strings.IndexByte(regexSpecial, s[i+1]) < 0 {
+				return "", false
+			}
+			i++
+			b.WriteByte(s[i])
+		case strings.IndexByte(regexSpecial, c) >= 0:
+			return "", false
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), true
+}
+
+// splitAlternatives splits given expression on unescaped "|".
+func splitAlternatives(s string) []string {
+	var (
+		res   []string
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '|':
+			res = append(res, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(res, s[start:])
+}
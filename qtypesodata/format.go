@@ -0,0 +1,161 @@
+package qtypesodata
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Format returns $filter representation of given filter, that Schema.Parse understands.
+// Invalid conditions are skipped. Conditions that cannot be expressed, e.g. PATTERN or case insensitive ones,
+// cause an error.
+func Format(f Filter) (string, error) {
+	var parts []string
+	for _, property := range slices.Sorted(maps.Keys(f)) {
+		for i, c := range f[property] {
+			s, err := format(property, c)
+			if err != nil {
+				return "", fmt.Errorf("qtypes: odata filter formatting error for condition %d of property %q: %w", i, property, err)
+			}
+			if s != "" {
+				parts = append(parts, s)
+			}
+		}
+	}
+	return strings.Join(parts, " and "), nil
+}
+
+// names maps query types to comparison operators and functions, for conditions that are not negated.
+var names = map[qtypes.QueryType]string{
+	qtypes.QueryType_EQUAL:         "eq",
+	qtypes.QueryType_GREATER:       "gt",
+	qtypes.QueryType_GREATER_EQUAL: "ge",
+	qtypes.QueryType_LESS:          "lt",
+	qtypes.QueryType_LESS_EQUAL:    "le",
+	qtypes.QueryType_IN:            "in",
+	qtypes.QueryType_HAS_PREFIX:    "startswith",
+	qtypes.QueryType_HAS_SUFFIX:    "endswith",
+	qtypes.QueryType_SUBSTRING:     "contains",
+}
+
+func format(property string, c qtypes.Condition) (string, error) {
+	if c == nil || !c.GetValid() {
+		return "", nil
+	}
+
+	var (
+		values []string
+		le, ue bool
+		text   bool
+	)
+	switch v := c.(type) {
+	case *qtypes.Int64:
+		for _, n := range v.Values {
+			values = append(values, strconv.FormatInt(n, 10))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Uint64:
+		for _, n := range v.Values {
+			values = append(values, strconv.FormatUint(n, 10))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Float64:
+		for _, n := range v.Values {
+			values = append(values, strconv.FormatFloat(n, 'f', -1, 64))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Timestamp:
+		for i, t := range v.Values {
+			if t == nil {
+				return "", fmt.Errorf("nil timestamp at position %d", i)
+			}
+			values = append(values, t.AsTime().UTC().Format(time.RFC3339Nano))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.String:
+		if v.Insensitive {
+			return "", fmt.Errorf("case insensitive %s cannot be expressed", v.Type)
+		}
+		for _, s := range v.Values {
+			values = append(values, "'"+strings.ReplaceAll(s, "'", "''")+"'")
+		}
+		text = true
+	default:
+		return "", fmt.Errorf("unsupported condition type %T", c)
+	}
+
+	t, negation := c.GetType(), c.GetNegation()
+	not := func(s string) string {
+		if negation {
+			return "not (" + s + ")"
+		}
+		return s
+	}
+	count := func(n int) error {
+		if len(values) != n {
+			return fmt.Errorf("%s requires %d value(s) but got %d", t, n, len(values))
+		}
+		return nil
+	}
+
+	switch t {
+	case qtypes.QueryType_NULL:
+		if negation {
+			return property + " ne null", nil
+		}
+		return property + " eq null", nil
+	case qtypes.QueryType_EQUAL:
+		if err := count(1); err != nil {
+			return "", err
+		}
+		if negation {
+			return property + " ne " + values[0], nil
+		}
+		return property + " eq " + values[0], nil
+	case qtypes.QueryType_GREATER, qtypes.QueryType_GREATER_EQUAL, qtypes.QueryType_LESS, qtypes.QueryType_LESS_EQUAL:
+		if err := count(1); err != nil {
+			return "", err
+		}
+		return not(property + " " + names[t] + " " + values[0]), nil
+	case qtypes.QueryType_IN:
+		if len(values) == 0 {
+			return "", fmt.Errorf("%s requires at least one value", t)
+		}
+		return not(property + " in (" + strings.Join(values, ",") + ")"), nil
+	case qtypes.QueryType_BETWEEN:
+		if err := count(2); err != nil {
+			return "", err
+		}
+		lower, upper := names[qtypes.QueryType_GREATER_EQUAL], names[qtypes.QueryType_LESS_EQUAL]
+		if le {
+			lower = names[qtypes.QueryType_GREATER]
+		}
+		if ue {
+			upper = names[qtypes.QueryType_LESS]
+		}
+		s := "(" + property + " " + lower + " " + values[0] + " and " + property + " " + upper + " " + values[1] + ")"
+		if negation {
+			return "not " + s, nil
+		}
+		return s, nil
+	case qtypes.QueryType_HAS_PREFIX, qtypes.QueryType_HAS_SUFFIX, qtypes.QueryType_SUBSTRING:
+		if !text {
+			return "", fmt.Errorf("%s is supported only for strings", t)
+		}
+		if err := count(1); err != nil {
+			return "", err
+		}
+		s := names[t] + "(" + property + "," + values[0] + ")"
+		if negation {
+			return "not " + s, nil
+		}
+		return s, nil
+	default:
+		return "", fmt.Errorf("%s cannot be expressed", t)
+	}
+}
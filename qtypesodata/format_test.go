@@ -0,0 +1,130 @@
+package qtypesodata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesodata"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFormat(t *testing.T) {
+	from := knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC))
+	to := knowntimestamp.New(time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		given    qtypesodata.Filter
+		expected string
+	}{
+		"example": {
+			given:    qtypesodata.Filter{"Age": {qtypes.GreaterEqualInt64(18)}, "Name": {qtypes.HasPrefixString("Jo")}},
+			expected: "Age ge 18 and startswith(Name,'Jo')",
+		},
+		"empty": {
+			given:    qtypesodata.Filter{},
+			expected: "",
+		},
+		"invalid": {
+			given:    qtypesodata.Filter{"Age": {&qtypes.Int64{Values: []int64{1}}, nil, qtypes.NotEqualInt64(2)}},
+			expected: "Age ne 2",
+		},
+		"null": {
+			given:    qtypesodata.Filter{"Age": {qtypes.NullInt64()}, "Name": {&qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}}},
+			expected: "Age eq null and Name ne null",
+		},
+		"negated-comparison": {
+			given:    qtypesodata.Filter{"Score": {&qtypes.Float64{Values: []float64{0.5}, Valid: true, Negation: true, Type: qtypes.QueryType_LESS}}},
+			expected: "not (Score lt 0.5)",
+		},
+		"in": {
+			given:    qtypesodata.Filter{"Name": {&qtypes.String{Values: []string{"a", "it's"}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}}},
+			expected: "not (Name in ('a','it''s'))",
+		},
+		"between": {
+			given:    qtypesodata.Filter{"CreatedAt": {qtypes.RangeTimestamp(from, to, false, true)}},
+			expected: "(CreatedAt ge 2024-05-01T00:00:00Z and CreatedAt lt 2024-05-02T00:00:00Z)",
+		},
+		"not-between": {
+			given:    qtypesodata.Filter{"ID": {&qtypes.Uint64{Values: []uint64{1, 5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}}},
+			expected: "not (ID ge 1 and ID le 5)",
+		},
+		"functions": {
+			given: qtypesodata.Filter{"Name": {
+				&qtypes.String{Values: []string{".com"}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_SUFFIX},
+				qtypes.SubString("oh"),
+			}},
+			expected: "not endswith(Name,'.com') and contains(Name,'oh')",
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypesodata.Format(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestFormat_error(t *testing.T) {
+	cases := map[string]qtypesodata.Filter{
+		"insensitive":          {"Name": {qtypes.EqualStringInsensitive("john")}},
+		"pattern":              {"Name": {&qtypes.String{Values: []string{"^j"}, Valid: true, Type: qtypes.QueryType_PATTERN}}},
+		"wildcard":             {"Name": {qtypes.WildcardString("j*")}},
+		"prefix-of-int":        {"Age": {&qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_HAS_PREFIX}}},
+		"contains":             {"Age": {&qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_CONTAINS}}},
+		"between-single-value": {"Age": {&qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_BETWEEN}}},
+		"empty-in":             {"Age": {&qtypes.Int64{Valid: true, Type: qtypes.QueryType_IN}}},
+		"nil-timestamp":        {"CreatedAt": {&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{nil}, Valid: true, Type: qtypes.QueryType_EQUAL}}},
+	}
+
+	for hint, given := range cases {
+		if _, err := qtypesodata.Format(given); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
+
+func TestFormat_roundTrip(t *testing.T) {
+	from := knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 500, time.UTC))
+	to := knowntimestamp.New(time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC))
+
+	cases := map[string]qtypesodata.Filter{
+		"numbers": {
+			"Age":   {qtypes.RangeInt64(1, 5, true, false), qtypes.NotEqualInt64(3), qtypes.InInt64(7)},
+			"ID":    {&qtypes.Uint64{Values: []uint64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}},
+			"Score": {&qtypes.Float64{Values: []float64{0.5, 1.5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}},
+		},
+		"timestamps": {
+			"CreatedAt": {qtypes.RangeTimestamp(from, to, true, true), &qtypes.Timestamp{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}},
+		},
+		"strings": {
+			"Name": {
+				qtypes.EqualString(`it's "quoted"`),
+				qtypes.HasPrefixString("Jo"),
+				&qtypes.String{Values: []string{"x"}, Valid: true, Negation: true, Type: qtypes.QueryType_SUBSTRING},
+				qtypes.BetweenString("a", "f"),
+				qtypes.LessString("z"),
+			},
+			"Address/City": {qtypes.NullString()},
+		},
+	}
+
+	for hint, given := range cases {
+		s, err := qtypesodata.Format(given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		got, err := schema.Parse(s)
+		if err != nil {
+			t.Errorf("%s: unexpected error for %q: %s", hint, s, err.Error())
+			continue
+		}
+		assertFilter(t, hint, given, got)
+	}
+}
@@ -0,0 +1,101 @@
+// Package qtypesodata parses OData $filter expressions, e.g. Age ge 18 and startswith(Name,'Jo'),
+// into conditions keyed by property name, and serializes them back.
+//
+// Supported are comparison operators eq, ne, gt, ge, lt, le and in, functions startswith, endswith and contains,
+// null comparisons, logical operators and, or and not, and parentheses.
+// Comparison operators map to query types like qtypeshttp prefixes do, e.g. ge to GREATER_EQUAL,
+// functions to HAS_PREFIX, HAS_SUFFIX and SUBSTRING, and eq null to NULL.
+//
+// Conditions on the same property have to be satisfied all together, so parts of an expression that cannot be
+// expressed as such are reported as unsupported, e.g. or between comparisons of different properties,
+// or functions other than the ones above. Or between eq comparisons of the same property results in IN.
+package qtypesodata
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Kind is a type of conditions that a property holds.
+type Kind int
+
+const (
+	// KindInt64 is a property of qtypes.Int64 conditions.
+	KindInt64 Kind = iota + 1
+	// KindUint64 is a property of qtypes.Uint64 conditions.
+	KindUint64
+	// KindFloat64 is a property of qtypes.Float64 conditions.
+	KindFloat64
+	// KindTimestamp is a property of qtypes.Timestamp conditions, its literals are DateTimeOffset values.
+	KindTimestamp
+	// KindString is a property of qtypes.String conditions.
+	KindString
+)
+
+// Schema lists properties that an expression can use. Names of nested properties are paths, e.g. "Address/City".
+type Schema map[string]Kind
+
+// Filter is a parsed expression, conditions are keyed by property name.
+// Decoded conditions are of type that the schema declares for the property, e.g. *qtypes.Int64 for KindInt64.
+type Filter map[string][]qtypes.Condition
+
+var (
+	// ErrInvalidFilter is wrapped by errors returned when an expression is not a valid $filter expression.
+	ErrInvalidFilter = errors.New("qtypes: invalid odata filter")
+	// ErrUnsupportedFilter is wrapped by errors returned when a valid expression cannot be expressed by conditions.
+	ErrUnsupportedFilter = errors.New("qtypes: unsupported odata filter")
+)
+
+// ParseError is returned when an expression is not valid. It wraps ErrInvalidFilter.
+type ParseError struct {
+	// Pos is a byte offset in the expression at which the problem is.
+	Pos    int
+	Reason string
+}
+
+// Error implements error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("qtypes: odata filter parsing error at position %d: %s", e.Pos, e.Reason)
+}
+
+// Unwrap returns ErrInvalidFilter.
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidFilter
+}
+
+// UnsupportedError is returned when a valid expression cannot be expressed by conditions. It wraps ErrUnsupportedFilter.
+type UnsupportedError struct {
+	// Pos is a byte offset in the expression at which the unsupported part starts.
+	Pos    int
+	Reason string
+}
+
+// Error implements error interface.
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("qtypes: odata filter is not supported at position %d: %s", e.Pos, e.Reason)
+}
+
+// Unwrap returns ErrUnsupportedFilter.
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupportedFilter
+}
+
+// comparisons maps comparison operators to query types.
+var comparisons = map[string]qtypes.QueryType{
+	"eq": qtypes.QueryType_EQUAL,
+	"ne": qtypes.QueryType_EQUAL,
+	"gt": qtypes.QueryType_GREATER,
+	"ge": qtypes.QueryType_GREATER_EQUAL,
+	"lt": qtypes.QueryType_LESS,
+	"le": qtypes.QueryType_LESS_EQUAL,
+	"in": qtypes.QueryType_IN,
+}
+
+// functions maps string functions to query types.
+var functions = map[string]qtypes.QueryType{
+	"startswith": qtypes.QueryType_HAS_PREFIX,
+	"endswith":   qtypes.QueryType_HAS_SUFFIX,
+	"contains":   qtypes.QueryType_SUBSTRING,
+}
@@ -0,0 +1,493 @@
+package qtypesodata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Parse parses given $filter expression. Properties that the schema does not list are rejected.
+// Errors are either of type *ParseError or *UnsupportedError, that point at the problem.
+// An empty expression results in an empty Filter.
+//
+// Operator not has to be followed by a parenthesized expression or a function, e.g. not (Age gt 5),
+// as it binds stronger than comparison operators.
+func (s Schema) Parse(filter string) (Filter, error) {
+	p := &parser{schema: s, src: filter}
+	res := Filter{}
+
+	p.space()
+	if p.pos == len(p.src) {
+		return res, nil
+	}
+	rs, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	p.space()
+	if p.pos < len(p.src) {
+		return nil, p.errorf(p.pos, "unexpected %q", p.src[p.pos])
+	}
+
+	for _, r := range rs {
+		res[r.property] = append(res[r.property], r.condition())
+	}
+	return res, nil
+}
+
+type parser struct {
+	schema Schema
+	src    string
+	pos    int
+}
+
+// restriction is a condition on a property, with values as written in the expression.
+type restriction struct {
+	property       string
+	kind           Kind
+	t              qtypes.QueryType
+	negation       bool
+	lowerExclusive bool
+	upperExclusive bool
+	values         []string
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	return &ParseError{Pos: pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) unsupported(pos int, format string, args ...interface{}) error {
+	return &UnsupportedError{Pos: pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// space skips white space and reports whether there was any.
+func (p *parser) space() bool {
+	start := p.pos
+	for p.pos < len(p.src) && isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// at reports whether given keyword, followed by white space, a parenthesis or the end, is at the current position.
+func (p *parser) at(keyword string) bool {
+	if !strings.HasPrefix(p.src[p.pos:], keyword) {
+		return false
+	}
+	end := p.pos + len(keyword)
+	return end == len(p.src) || isSpace(p.src[end]) || p.src[end] == '('
+}
+
+// keyword consumes given keyword preceded by white space, and returns its position or -1.
+func (p *parser) keyword(keyword string) int {
+	start := p.pos
+	if p.space() && p.at(keyword) {
+		pos := p.pos
+		p.pos += len(keyword)
+		p.space()
+		return pos
+	}
+	p.pos = start
+	return -1
+}
+
+func (p *parser) or() ([]restriction, error) {
+	rs, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	orPos := -1
+	single := len(rs) == 1
+	for {
+		pos := p.keyword("or")
+		if pos < 0 {
+			break
+		}
+		if orPos < 0 {
+			orPos = pos
+		}
+		more, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		single = single && len(more) == 1
+		rs = append(rs, more...)
+	}
+	if orPos < 0 {
+		return rs, nil
+	}
+	r, ok := disjunction(rs)
+	if !single || !ok {
+		return nil, p.unsupported(orPos, "or is supported only between eq comparisons of the same property")
+	}
+	return []restriction{r}, nil
+}
+
+// disjunction combines eq comparisons of the same property into a single IN restriction.
+func disjunction(rs []restriction) (restriction, bool) {
+	res := restriction{property: rs[0].property, kind: rs[0].kind, t: qtypes.QueryType_IN}
+	for _, r := range rs {
+		if r.property != res.property || r.negation || (r.t != qtypes.QueryType_EQUAL && r.t != qtypes.QueryType_IN) {
+			return restriction{}, false
+		}
+		res.values = append(res.values, r.values...)
+	}
+	return res, true
+}
+
+func (p *parser) and() ([]restriction, error) {
+	rs, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") >= 0 {
+		more, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, more...)
+	}
+	return rs, nil
+}
+
+func (p *parser) not() ([]restriction, error) {
+	start := p.pos
+	if !p.at("not") {
+		return p.primary()
+	}
+	p.pos += len("not")
+	p.space()
+	if p.pos == len(p.src) || (p.src[p.pos] != '(' && !p.function()) {
+		return nil, p.errorf(p.pos, "expected parenthesized expression or function after not")
+	}
+
+	rs, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) != 1 {
+		return nil, p.unsupported(start, "not is supported only for a single condition")
+	}
+	rs[0].negation = !rs[0].negation
+	return rs, nil
+}
+
+// function reports whether a function call is at the current position.
+func (p *parser) function() bool {
+	end := p.pos + len(p.identifier())
+	return end > p.pos && end < len(p.src) && p.src[end] == '('
+}
+
+// identifier returns identifier at the current position, without consuming it.
+func (p *parser) identifier() string {
+	end := p.pos
+	for end < len(p.src) {
+		c := p.src[end]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_' || c == '/' || c == '.') {
+			break
+		}
+		end++
+	}
+	return p.src[p.pos:end]
+}
+
+func (p *parser) primary() ([]restriction, error) {
+	if p.pos == len(p.src) || p.src[p.pos] != '(' {
+		r, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		return []restriction{r}, nil
+	}
+
+	start := p.pos
+	p.pos++
+	p.space()
+	rs, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	p.space()
+	if p.pos == len(p.src) || p.src[p.pos] != ')' {
+		return nil, p.errorf(start, "unclosed parenthesis")
+	}
+	p.pos++
+	if r, ok := conjunction(rs); ok {
+		return []restriction{r}, nil
+	}
+	return rs, nil
+}
+
+// conjunction combines lower and upper bound of the same property into a single BETWEEN restriction.
+func conjunction(rs []restriction) (restriction, bool) {
+	if len(rs) != 2 || rs[0].property != rs[1].property || rs[0].negation || rs[1].negation {
+		return restriction{}, false
+	}
+	lo, hi := rs[0], rs[1]
+	if lo.t == qtypes.QueryType_LESS || lo.t == qtypes.QueryType_LESS_EQUAL {
+		lo, hi = hi, lo
+	}
+	if (lo.t != qtypes.QueryType_GREATER && lo.t != qtypes.QueryType_GREATER_EQUAL) ||
+		(hi.t != qtypes.QueryType_LESS && hi.t != qtypes.QueryType_LESS_EQUAL) {
+		return restriction{}, false
+	}
+	res := restriction{
+		property:       lo.property,
+		kind:           lo.kind,
+		t:              qtypes.QueryType_BETWEEN,
+		lowerExclusive: lo.t == qtypes.QueryType_GREATER,
+		upperExclusive: hi.t == qtypes.QueryType_LESS,
+		values:         []string{lo.values[0], hi.values[0]},
+	}
+	// String conditions have no exclusive bounds.
+	if res.kind == KindString && (res.lowerExclusive || res.upperExclusive) {
+		return restriction{}, false
+	}
+	return res, true
+}
+
+func (p *parser) property(pos int, name string) (Kind, error) {
+	if name == "" {
+		return 0, p.errorf(pos, "expected property")
+	}
+	kind, ok := p.schema[name]
+	if !ok {
+		return 0, p.errorf(pos, "unknown property %q", name)
+	}
+	return kind, nil
+}
+
+func (p *parser) comparison() (restriction, error) {
+	start := p.pos
+	name := p.identifier()
+	if p.function() {
+		return p.call(name)
+	}
+	kind, err := p.property(start, name)
+	if err != nil {
+		return restriction{}, err
+	}
+	p.pos += len(name)
+
+	if !p.space() {
+		return restriction{}, p.errorf(p.pos, "expected comparison operator")
+	}
+	opPos := p.pos
+	op := p.identifier()
+	t, ok := comparisons[op]
+	if !ok {
+		return restriction{}, p.errorf(opPos, "expected comparison operator")
+	}
+	p.pos += len(op)
+	p.space()
+
+	r := restriction{property: name, kind: kind, t: t, negation: op == "ne"}
+	if t == qtypes.QueryType_IN {
+		values, err := p.list(kind)
+		if err != nil {
+			return restriction{}, err
+		}
+		r.values = values
+		return r, nil
+	}
+
+	litPos := p.pos
+	lit, quoted, err := p.literal()
+	if err != nil {
+		return restriction{}, err
+	}
+	if lit == "null" && !quoted {
+		if t != qtypes.QueryType_EQUAL {
+			return restriction{}, p.errorf(litPos, "null can be compared only using eq and ne")
+		}
+		r.t = qtypes.QueryType_NULL
+		return r, nil
+	}
+	if err := validate(kind, lit, quoted); err != nil {
+		return restriction{}, p.errorf(litPos, "invalid literal %q: %s", lit, err.Error())
+	}
+	r.values = []string{lit}
+	return r, nil
+}
+
+// call parses a function call, e.g. startswith(Name,'Jo').
+func (p *parser) call(name string) (restriction, error) {
+	start := p.pos
+	t, ok := functions[name]
+	if !ok {
+		return restriction{}, p.unsupported(start, "function %s is not supported", name)
+	}
+	p.pos += len(name) + 1
+	p.space()
+
+	propPos := p.pos
+	property := p.identifier()
+	if p.function() {
+		return restriction{}, p.unsupported(propPos, "function %s is not supported as an argument", property)
+	}
+	kind, err := p.property(propPos, property)
+	if err != nil {
+		return restriction{}, err
+	}
+	if kind != KindString {
+		return restriction{}, p.errorf(propPos, "function %s requires a string property", name)
+	}
+	p.pos += len(property)
+	p.space()
+	if p.pos == len(p.src) || p.src[p.pos] != ',' {
+		return restriction{}, p.errorf(p.pos, "expected ,")
+	}
+	p.pos++
+	p.space()
+
+	litPos := p.pos
+	lit, quoted, err := p.literal()
+	if err != nil {
+		return restriction{}, err
+	}
+	if err := validate(kind, lit, quoted); err != nil {
+		return restriction{}, p.errorf(litPos, "invalid literal %q: %s", lit, err.Error())
+	}
+	p.space()
+	if p.pos == len(p.src) || p.src[p.pos] != ')' {
+		return restriction{}, p.errorf(start, "unclosed parenthesis")
+	}
+	p.pos++
+	return restriction{property: property, kind: kind, t: t, values: []string{lit}}, nil
+}
+
+// list parses a parenthesized list of literals, e.g. ('a','b').
+func (p *parser) list(kind Kind) ([]string, error) {
+	start := p.pos
+	if p.pos == len(p.src) || p.src[p.pos] != '(' {
+		return nil, p.errorf(start, "expected list of literals")
+	}
+	p.pos++
+	var values []string
+	for {
+		p.space()
+		litPos := p.pos
+		lit, quoted, err := p.literal()
+		if err != nil {
+			return nil, err
+		}
+		if err := validate(kind, lit, quoted); err != nil {
+			return nil, p.errorf(litPos, "invalid literal %q: %s", lit, err.Error())
+		}
+		values = append(values, lit)
+		p.space()
+		if p.pos == len(p.src) {
+			return nil, p.errorf(start, "unclosed parenthesis")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return values, nil
+		default:
+			return nil, p.errorf(p.pos, "unexpected %q in list of literals", p.src[p.pos])
+		}
+	}
+}
+
+// literal returns the literal at the current position, and reports whether it was a quoted string.
+// Within a string, two single quotes stand for one.
+func (p *parser) literal() (string, bool, error) {
+	start := p.pos
+	if p.pos == len(p.src) || p.src[p.pos] != '\'' {
+		for p.pos < len(p.src) && !isSpace(p.src[p.pos]) && strings.IndexByte("(),'", p.src[p.pos]) < 0 {
+			p.pos++
+		}
+		if p.pos == start {
+			return "", false, p.errorf(start, "expected literal")
+		}
+		return p.src[start:p.pos], false, nil
+	}
+
+	p.pos++
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(p.src[p.pos:], '\'')
+		if i < 0 {
+			return "", false, p.errorf(start, "unterminated string")
+		}
+		b.WriteString(p.src[p.pos : p.pos+i])
+		p.pos += i + 1
+		if p.pos < len(p.src) && p.src[p.pos] == '\'' {
+			b.WriteByte('\'')
+			p.pos++
+			continue
+		}
+		return b.String(), true, nil
+	}
+}
+
+func validate(k Kind, v string, quoted bool) error {
+	if quoted != (k == KindString) {
+		if quoted {
+			return fmt.Errorf("expected unquoted literal")
+		}
+		return fmt.Errorf("expected string literal")
+	}
+
+	var err error
+	switch k {
+	case KindInt64:
+		_, err = strconv.ParseInt(v, 10, 64)
+	case KindUint64:
+		_, err = strconv.ParseUint(v, 10, 64)
+	case KindFloat64:
+		_, err = strconv.ParseFloat(v, 64)
+	case KindTimestamp:
+		_, err = time.Parse(time.RFC3339Nano, v)
+	case KindString:
+	default:
+		err = fmt.Errorf("unknown kind %d", k)
+	}
+	return err
+}
+
+// condition converts restriction to a condition, values are already validated.
+func (r restriction) condition() qtypes.Condition {
+	switch r.kind {
+	case KindInt64:
+		values := make([]int64, 0, len(r.values))
+		for _, v := range r.values {
+			n, _ := strconv.ParseInt(v, 10, 64)
+			values = append(values, n)
+		}
+		return &qtypes.Int64{Values: values, Valid: true, Negation: r.negation, Type: r.t, LowerExclusive: r.lowerExclusive, UpperExclusive: r.upperExclusive}
+	case KindUint64:
+		values := make([]uint64, 0, len(r.values))
+		for _, v := range r.values {
+			n, _ := strconv.ParseUint(v, 10, 64)
+			values = append(values, n)
+		}
+		return &qtypes.Uint64{Values: values, Valid: true, Negation: r.negation, Type: r.t, LowerExclusive: r.lowerExclusive, UpperExclusive: r.upperExclusive}
+	case KindFloat64:
+		values := make([]float64, 0, len(r.values))
+		for _, v := range r.values {
+			f, _ := strconv.ParseFloat(v, 64)
+			values = append(values, f)
+		}
+		return &qtypes.Float64{Values: values, Valid: true, Negation: r.negation, Type: r.t, LowerExclusive: r.lowerExclusive, UpperExclusive: r.upperExclusive}
+	case KindTimestamp:
+		values := make([]*knowntimestamp.Timestamp, 0, len(r.values))
+		for _, v := range r.values {
+			t, _ := time.Parse(time.RFC3339Nano, v)
+			values = append(values, knowntimestamp.New(t))
+		}
+		return &qtypes.Timestamp{Values: values, Valid: true, Negation: r.negation, Type: r.t, LowerExclusive: r.lowerExclusive, UpperExclusive: r.upperExclusive}
+	default:
+		return &qtypes.String{Values: r.values, Valid: true, Negation: r.negation, Type: r.t}
+	}
+}
@@ -0,0 +1,172 @@
+package qtypesodata_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesodata"
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var schema = qtypesodata.Schema{
+	"Age":          qtypesodata.KindInt64,
+	"ID":           qtypesodata.KindUint64,
+	"Score":        qtypesodata.KindFloat64,
+	"CreatedAt":    qtypesodata.KindTimestamp,
+	"Name":         qtypesodata.KindString,
+	"Address/City": qtypesodata.KindString,
+}
+
+func assertFilter(t *testing.T, hint string, expected, got qtypesodata.Filter) {
+	t.Helper()
+
+	if len(expected) != len(got) {
+		t.Errorf("%s: wrong number of properties, expected %d but got %d: %v", hint, len(expected), len(got), got)
+		return
+	}
+	for property, conditions := range expected {
+		if len(conditions) != len(got[property]) {
+			t.Errorf("%s: wrong number of conditions of property %q, expected %d but got %d: %v", hint, property, len(conditions), len(got[property]), got[property])
+			continue
+		}
+		for i, c := range conditions {
+			if !proto.Equal(c, got[property][i]) {
+				t.Errorf("%s: wrong condition %d of property %q,\nexpected:\n	%v\nbut got:\n	%v\n", hint, i, property, c, got[property][i])
+			}
+		}
+	}
+}
+
+func TestSchema_Parse(t *testing.T) {
+	created := knowntimestamp.New(time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		given    string
+		expected qtypesodata.Filter
+	}{
+		"example": {
+			given:    "Age ge 18 and startswith(Name,'Jo')",
+			expected: qtypesodata.Filter{"Age": {qtypes.GreaterEqualInt64(18)}, "Name": {qtypes.HasPrefixString("Jo")}},
+		},
+		"empty": {
+			given:    "",
+			expected: qtypesodata.Filter{},
+		},
+		"comparisons": {
+			given: "Age gt 1 and Age lt 10 and ID ne 18446744073709551615 and Score le -0.5",
+			expected: qtypesodata.Filter{
+				"Age":   {qtypes.GreaterInt64(1), qtypes.LessInt64(10)},
+				"ID":    {&qtypes.Uint64{Values: []uint64{18446744073709551615}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL}},
+				"Score": {&qtypes.Float64{Values: []float64{-0.5}, Valid: true, Type: qtypes.QueryType_LESS_EQUAL}},
+			},
+		},
+		"null": {
+			given:    "Name eq null and Age ne null",
+			expected: qtypesodata.Filter{"Name": {qtypes.NullString()}, "Age": {&qtypes.Int64{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}}},
+		},
+		"in": {
+			given:    "Name in ('a', 'it''s')",
+			expected: qtypesodata.Filter{"Name": {&qtypes.String{Values: []string{"a", "it's"}, Valid: true, Type: qtypes.QueryType_IN}}},
+		},
+		"or": {
+			given:    "Age eq 1 or Age eq 2 or Age in (3)",
+			expected: qtypesodata.Filter{"Age": {qtypes.InInt64(1, 2, 3)}},
+		},
+		"and-binds-stronger": {
+			given:    "(Age eq 1 or Age eq 2) and Name eq 'x'",
+			expected: qtypesodata.Filter{"Age": {qtypes.InInt64(1, 2)}, "Name": {qtypes.EqualString("x")}},
+		},
+		"not": {
+			given: "not (Age in (1,2)) and not endswith(Name,'.com') and not(Score gt 1)",
+			expected: qtypesodata.Filter{
+				"Age":   {&qtypes.Int64{Values: []int64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}},
+				"Name":  {&qtypes.String{Values: []string{".com"}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_SUFFIX}},
+				"Score": {&qtypes.Float64{Values: []float64{1}, Valid: true, Negation: true, Type: qtypes.QueryType_GREATER}},
+			},
+		},
+		"between": {
+			given: "(Age gt 1 and Age le 5) and not (Score ge 0.5 and Score le 1.5)",
+			expected: qtypesodata.Filter{
+				"Age":   {qtypes.RangeInt64(1, 5, true, false)},
+				"Score": {&qtypes.Float64{Values: []float64{0.5, 1.5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}},
+			},
+		},
+		"timestamp": {
+			given:    "CreatedAt lt 2024-05-01T12:00:00Z",
+			expected: qtypesodata.Filter{"CreatedAt": {&qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{created}, Valid: true, Type: qtypes.QueryType_LESS}}},
+		},
+		"path": {
+			given:    "contains( Address/City , 'ork' )",
+			expected: qtypesodata.Filter{"Address/City": {qtypes.SubString("ork")}},
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := schema.Parse(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		assertFilter(t, hint, c.expected, got)
+	}
+}
+
+func TestSchema_Parse_error(t *testing.T) {
+	cases := map[string]struct {
+		given       string
+		pos         int
+		unsupported bool
+	}{
+		"unknown-property":     {given: "Age eq 1 and Email eq 'a'", pos: 13},
+		"missing-operator":     {given: "Age", pos: 3},
+		"unknown-operator":     {given: "Age has 1", pos: 4},
+		"missing-literal":      {given: "Age eq ", pos: 7},
+		"invalid-number":       {given: "Age eq 1.5", pos: 7},
+		"quoted-number":        {given: "Age eq '1'", pos: 7},
+		"unquoted-string":      {given: "Name eq John", pos: 8},
+		"unterminated-string":  {given: "Name eq 'John", pos: 8},
+		"null-comparison":      {given: "Age gt null", pos: 7},
+		"unclosed-parenthesis": {given: "(Age eq 1", pos: 0},
+		"unclosed-list":        {given: "Age in (1,2", pos: 7},
+		"trailing":             {given: "Age eq 1)", pos: 8},
+		"not-of-comparison":    {given: "not Age eq 1", pos: 4},
+		"function-of-number":   {given: "startswith(Age,'1')", pos: 11},
+		"missing-argument":     {given: "startswith(Name)", pos: 15},
+		"unknown-function":     {given: "length(Name) eq 3", pos: 0, unsupported: true},
+		"nested-function":      {given: "startswith(tolower(Name),'jo')", pos: 11, unsupported: true},
+		"lambda":               {given: "Tags/any(t: t eq 'a')", pos: 0, unsupported: true},
+		"or-of-properties":     {given: "Age eq 1 or Score eq 1", pos: 9, unsupported: true},
+		"or-of-ranges":         {given: "Age lt 1 or Age gt 5", pos: 9, unsupported: true},
+		"not-of-many":          {given: "not (Age eq 1 and Score eq 1)", pos: 0, unsupported: true},
+	}
+
+	for hint, c := range cases {
+		_, err := schema.Parse(c.given)
+		if err == nil {
+			t.Errorf("%s: expected error", hint)
+			continue
+		}
+		var pos int
+		if c.unsupported {
+			var ue *qtypesodata.UnsupportedError
+			if !errors.As(err, &ue) || !errors.Is(err, qtypesodata.ErrUnsupportedFilter) {
+				t.Errorf("%s: expected *UnsupportedError, got: %s", hint, err.Error())
+				continue
+			}
+			pos = ue.Pos
+		} else {
+			var pe *qtypesodata.ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, qtypesodata.ErrInvalidFilter) {
+				t.Errorf("%s: expected *ParseError, got: %s", hint, err.Error())
+				continue
+			}
+			pos = pe.Pos
+		}
+		if pos != c.pos {
+			t.Errorf("%s: wrong position, expected %d but got %d: %s", hint, c.pos, pos, err.Error())
+		}
+	}
+}
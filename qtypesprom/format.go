@@ -0,0 +1,70 @@
+package qtypesprom
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Format returns label matchers representation of given matchers in braces, that Parse understands.
+// Invalid conditions are skipped. Conditions other than EQUAL and PATTERN, case insensitive EQUAL,
+// and patterns that are not part of the portable subset cause an error.
+func Format(m Matchers) (string, error) {
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(m)) {
+		for i, c := range m[name] {
+			r, err := format(name, c)
+			if err != nil {
+				return "", fmt.Errorf("qtypes: label matchers formatting error for condition %d of label %q: %w", i, name, err)
+			}
+			if r != "" {
+				parts = append(parts, r)
+			}
+		}
+	}
+	return "{" + strings.Join(parts, ",") + "}", nil
+}
+
+func format(name string, c *qtypes.String) (string, error) {
+	if !c.GetValid() {
+		return "", nil
+	}
+	if name == "" {
+		return "", fmt.Errorf("label name cannot be empty")
+	}
+	if !labelNameExpr.MatchString(name) {
+		name = strconv.Quote(name)
+	}
+	if len(c.Values) != 1 {
+		return "", fmt.Errorf("%s requires 1 value but got %d", c.Type, len(c.Values))
+	}
+
+	switch c.Type {
+	case qtypes.QueryType_EQUAL:
+		if c.Insensitive {
+			return "", fmt.Errorf("case insensitive %s cannot be expressed", c.Type)
+		}
+		if c.Negation {
+			return name + "!=" + strconv.Quote(c.Values[0]), nil
+		}
+		return name + "=" + strconv.Quote(c.Values[0]), nil
+	case qtypes.QueryType_PATTERN:
+		if err := qtypes.ValidatePattern(c.Values[0]); err != nil {
+			return "", err
+		}
+		expr := anchor(c.Values[0])
+		if c.Insensitive {
+			expr = insensitiveFlag + expr
+		}
+		if c.Negation {
+			return name + "!~" + strconv.Quote(expr), nil
+		}
+		return name + "=~" + strconv.Quote(expr), nil
+	default:
+		return "", fmt.Errorf("%s cannot be expressed", c.Type)
+	}
+}
@@ -0,0 +1,122 @@
+package qtypesprom_test
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesprom"
+)
+
+func TestFormat(t *testing.T) {
+	cases := map[string]struct {
+		given    qtypesprom.Matchers
+		expected string
+	}{
+		"example": {
+			given: qtypesprom.Matchers{
+				"__name__": {qtypes.EqualString("http_requests_total")},
+				"job":      {qtypes.EqualString("api")},
+				"path":     {pattern("^/v1/", false, false)},
+				"code":     {pattern("^5..$", true, false)},
+			},
+			expected: `{__name__="http_requests_total",code!~"5..",job="api",path=~"/v1/.*"}`,
+		},
+		"empty": {
+			given:    qtypesprom.Matchers{},
+			expected: "{}",
+		},
+		"invalid": {
+			given:    qtypesprom.Matchers{"job": {{Values: []string{"api"}}, nil, qtypes.EqualString("web")}},
+			expected: `{job="web"}`,
+		},
+		"quoted": {
+			given:    qtypesprom.Matchers{"service.name": {qtypes.EqualString("a\"b\n")}},
+			expected: `{"service.name"="a\"b\n"}`,
+		},
+		"insensitive": {
+			given:    qtypesprom.Matchers{"env": {pattern("prod", true, true)}},
+			expected: `{env!~"(?i).*prod.*"}`,
+		},
+		"alternation": {
+			given:    qtypesprom.Matchers{"a": {pattern("^a|b$", false, false)}},
+			expected: `{a=~".*(^a|b$).*"}`,
+		},
+		"match-all": {
+			given:    qtypesprom.Matchers{"a": {pattern("", false, false)}, "b": {pattern("^", false, false)}, "c": {pattern("^$", false, false)}},
+			expected: `{a=~".*",b=~".*",c=~""}`,
+		},
+		"escaped-dollar": {
+			given:    qtypesprom.Matchers{"a": {pattern(`^\$`, false, false)}, "b": {pattern(`\\$`, false, false)}},
+			expected: `{a=~"\\$.*",b=~".*\\\\"}`,
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypesprom.Format(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %s but got %s", hint, c.expected, got)
+		}
+		parsed, err := qtypesprom.Parse(got)
+		if err != nil {
+			t.Errorf("%s: unexpected error for %s: %s", hint, got, err.Error())
+			continue
+		}
+		again, err := qtypesprom.Format(parsed)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if again != got {
+			t.Errorf("%s: wrong round trip output, expected %s but got %s", hint, got, again)
+		}
+	}
+}
+
+func TestFormat_error(t *testing.T) {
+	cases := map[string]qtypesprom.Matchers{
+		"empty-name":          {"": {qtypes.EqualString("a")}},
+		"insensitive-equal":   {"job": {qtypes.EqualStringInsensitive("api")}},
+		"in":                  {"job": {{Values: []string{"a", "b"}, Valid: true, Type: qtypes.QueryType_IN}}},
+		"prefix":              {"job": {qtypes.HasPrefixString("a")}},
+		"null":                {"job": {qtypes.NullString()}},
+		"unsupported-pattern": {"job": {pattern("a(?:b)", false, false)}},
+	}
+
+	for hint, given := range cases {
+		if _, err := qtypesprom.Format(given); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
+
+func TestFormat_anchoring(t *testing.T) {
+	patterns := []string{"", "^", "$", "^$", "a", "^a", "a$", "^a$", "a|b", "^a|b$", `\$`, `a\\$`, "(a|b)$", "[$]", "a*"}
+	values := []string{"", "a", "b", "ab", "ba", "bab", "c", "$", `a\`, "[", "aa\nb", "\na"}
+
+	for _, p := range patterns {
+		got, err := qtypesprom.Format(qtypesprom.Matchers{"a": {pattern(p, false, false)}})
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", p, err.Error())
+			continue
+		}
+		expr, err := strconv.Unquote(strings.TrimSuffix(strings.TrimPrefix(got, "{a=~"), "}"))
+		if err != nil {
+			t.Errorf("%q: unexpected error for %s: %s", p, got, err.Error())
+			continue
+		}
+		full := regexp.MustCompile(`^(?s:(?:` + expr + `))$`)
+		re, _ := qtypes.CompilePattern(p, false)
+		for _, v := range values {
+			if expected := re.MatchString(v); full.MatchString(v) != expected {
+				t.Errorf("%q: wrong output for value %q of expression %q, expected %t", p, v, expr, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package qtypesprom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Parse parses given label matchers, e.g. http_requests_total{job="api",code=~"5.."}.
+// Braces are optional, e.g. job="api",code=~"5.." is understood as well. A trailing comma is allowed.
+// Values can be quoted with ", ' or `, like in PromQL. Label names that are not valid identifiers have to be quoted.
+// Regular expressions have to be part of the portable subset, see qtypes.ValidatePattern,
+// with an optional leading (?i) flag. Errors are of type *ParseError, that points at the problem.
+func Parse(matchers string) (Matchers, error) {
+	p := &parser{src: matchers}
+	res := Matchers{}
+
+	p.space()
+	if p.pos == len(p.src) {
+		return res, nil
+	}
+
+	start := p.pos
+	if name := p.metric(); name != "" {
+		p.space()
+		if p.pos == len(p.src) || p.src[p.pos] == '{' {
+			res[MetricNameLabel] = append(res[MetricNameLabel], qtypes.EqualString(name))
+		} else {
+			p.pos = start
+		}
+	}
+
+	braced := p.pos < len(p.src) && p.src[p.pos] == '{'
+	if braced {
+		p.pos++
+		p.space()
+	}
+	for p.pos < len(p.src) && (!braced || p.src[p.pos] != '}') {
+		name, c, err := p.matcher()
+		if err != nil {
+			return nil, err
+		}
+		res[name] = append(res[name], c)
+
+		p.space()
+		if p.pos == len(p.src) || p.src[p.pos] != ',' {
+			break
+		}
+		p.pos++
+		p.space()
+	}
+	if braced {
+		if p.pos == len(p.src) || p.src[p.pos] != '}' {
+			return nil, p.errorf(p.pos, "expected , or }")
+		}
+		p.pos++
+		p.space()
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorf(p.pos, "unexpected %q", p.src[p.pos])
+	}
+	return res, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	return &ParseError{Pos: pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) space() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// identifier consumes a label name, or a metric name if colons are allowed.
+func (p *parser) identifier(colon bool) string {
+	start := p.pos
+	for ; p.pos < len(p.src); p.pos++ {
+		c := p.src[p.pos]
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || colon && c == ':' || p.pos > start && c >= '0' && c <= '9' {
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *parser) metric() string {
+	return p.identifier(true)
+}
+
+func (p *parser) name() (string, error) {
+	if p.pos < len(p.src) && strings.IndexByte("\"'`", p.src[p.pos]) >= 0 {
+		start := p.pos
+		name, err := p.string()
+		if err != nil {
+			return "", err
+		}
+		if name == "" {
+			return "", p.errorf(start, "label name cannot be empty")
+		}
+		return name, nil
+	}
+	name := p.identifier(false)
+	if name == "" {
+		return "", p.errorf(p.pos, "expected label name")
+	}
+	return name, nil
+}
+
+func (p *parser) matcher() (string, *qtypes.String, error) {
+	name, err := p.name()
+	if err != nil {
+		return "", nil, err
+	}
+	p.space()
+
+	var op string
+	for _, o := range []string{"=~", "!~", "!=", "="} {
+		if strings.HasPrefix(p.src[p.pos:], o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return "", nil, p.errorf(p.pos, "expected one of =, !=, =~ or !~")
+	}
+	p.pos += len(op)
+	p.space()
+
+	start := p.pos
+	value, err := p.string()
+	if err != nil {
+		return "", nil, err
+	}
+	if op == "=" || op == "!=" {
+		return name, &qtypes.String{Values: []string{value}, Valid: true, Negation: op == "!=", Type: qtypes.QueryType_EQUAL}, nil
+	}
+
+	expr, insensitive := strings.CutPrefix(value, insensitiveFlag)
+	if err := qtypes.ValidatePattern(expr); err != nil {
+		return "", nil, p.errorf(start, "%s", err.Error())
+	}
+	return name, &qtypes.String{
+		Values:      []string{unanchor(expr)},
+		Valid:       true,
+		Negation:    op == "!~",
+		Type:        qtypes.QueryType_PATTERN,
+		Insensitive: insensitive,
+	}, nil
+}
+
+// string consumes a quoted string, e.g. "a\nb", 'a"b' or `a\b`.
+func (p *parser) string() (string, error) {
+	start := p.pos
+	if p.pos == len(p.src) || strings.IndexByte("\"'`", p.src[p.pos]) < 0 {
+		return "", p.errorf(start, "expected quoted string")
+	}
+	quote := p.src[p.pos]
+	for p.pos++; p.pos < len(p.src) && p.src[p.pos] != quote; p.pos++ {
+		if quote != '`' && p.src[p.pos] == '\\' {
+			p.pos++
+		}
+	}
+	if p.pos >= len(p.src) {
+		return "", p.errorf(start, "unterminated string")
+	}
+	p.pos++
+
+	raw := p.src[start:p.pos]
+	if quote == '\'' {
+		raw = doubleQuoted(raw[1 : len(raw)-1])
+	}
+	s, err := strconv.Unquote(raw)
+	if err != nil {
+		return "", p.errorf(start, "invalid string %s", p.src[start:p.pos])
+	}
+	return s, nil
+}
+
+// doubleQuoted turns content of a single quoted string into a double quoted string.
+func doubleQuoted(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case s[i] == '\\' && i+1 < len(s):
+			b.WriteString(s[i : i+2])
+			i++
+		case s[i] == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
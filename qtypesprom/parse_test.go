@@ -0,0 +1,177 @@
+package qtypesprom_test
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesprom"
+	"google.golang.org/protobuf/proto"
+)
+
+func assertMatchers(t *testing.T, hint string, expected, got qtypesprom.Matchers) {
+	t.Helper()
+
+	if len(expected) != len(got) {
+		t.Errorf("%s: wrong number of labels, expected %d but got %d: %v", hint, len(expected), len(got), got)
+		return
+	}
+	for name, conditions := range expected {
+		if len(conditions) != len(got[name]) {
+			t.Errorf("%s: wrong number of conditions of label %q, expected %d but got %d", hint, name, len(conditions), len(got[name]))
+			continue
+		}
+		for i, c := range conditions {
+			if !proto.Equal(c, got[name][i]) {
+				t.Errorf("%s: wrong condition %d of label %q,\nexpected:\n	%v\nbut got:\n	%v\n", hint, i, name, c, got[name][i])
+			}
+		}
+	}
+}
+
+func pattern(expr string, negation, insensitive bool) *qtypes.String {
+	return &qtypes.String{Values: []string{expr}, Valid: true, Negation: negation, Type: qtypes.QueryType_PATTERN, Insensitive: insensitive}
+}
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		given    string
+		expected qtypesprom.Matchers
+	}{
+		"example": {
+			given: `http_requests_total{job="api", path=~"/v1/.*", code!~"5..", method!="GET"}`,
+			expected: qtypesprom.Matchers{
+				"__name__": {qtypes.EqualString("http_requests_total")},
+				"job":      {qtypes.EqualString("api")},
+				"path":     {pattern("^/v1/", false, false)},
+				"code":     {pattern("^5..$", true, false)},
+				"method":   {&qtypes.String{Values: []string{"GET"}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL}},
+			},
+		},
+		"empty": {
+			given:    " ",
+			expected: qtypesprom.Matchers{},
+		},
+		"empty-braces": {
+			given:    "{ }",
+			expected: qtypesprom.Matchers{},
+		},
+		"without-braces": {
+			given:    `name=~"api.*"`,
+			expected: qtypesprom.Matchers{"name": {pattern("^api", false, false)}},
+		},
+		"metric-only": {
+			given:    "job:requests:rate5m",
+			expected: qtypesprom.Matchers{"__name__": {qtypes.EqualString("job:requests:rate5m")}},
+		},
+		"trailing-comma": {
+			given:    `{job="api",}`,
+			expected: qtypesprom.Matchers{"job": {qtypes.EqualString("api")}},
+		},
+		"same-label": {
+			given: `job!="a",job!="b"`,
+			expected: qtypesprom.Matchers{"job": {
+				&qtypes.String{Values: []string{"a"}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL},
+				&qtypes.String{Values: []string{"b"}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL},
+			}},
+		},
+		"quotes": {
+			given: `{a="x\"y\n", b='it\'s "ok"', c=` + "`C:\\dir`" + `, "service.name"=""}`,
+			expected: qtypesprom.Matchers{
+				"a":            {qtypes.EqualString("x\"y\n")},
+				"b":            {qtypes.EqualString(`it's "ok"`)},
+				"c":            {qtypes.EqualString(`C:\dir`)},
+				"service.name": {qtypes.EqualString("")},
+			},
+		},
+		"insensitive": {
+			given:    `{env=~"(?i)prod|staging"}`,
+			expected: qtypesprom.Matchers{"env": {pattern("^(prod|staging)$", false, true)}},
+		},
+		"match-all": {
+			given:    `{a=~".*", b=~".+", c=~""}`,
+			expected: qtypesprom.Matchers{"a": {pattern("", false, false)}, "b": {pattern("^.+$", false, false)}, "c": {pattern("^$", false, false)}},
+		},
+		"substring": {
+			given:    `{a=~".*err.*", b=~".*\\.*"}`,
+			expected: qtypesprom.Matchers{"a": {pattern("err", false, false)}, "b": {pattern(`\.*$`, false, false)}},
+		},
+		"grouped-alternation": {
+			given:    `{a=~"(a|b)c", b=~"[|]x"}`,
+			expected: qtypesprom.Matchers{"a": {pattern("^(a|b)c$", false, false)}, "b": {pattern("^[|]x$", false, false)}},
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypesprom.Parse(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		assertMatchers(t, hint, c.expected, got)
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	cases := map[string]struct {
+		given string
+		pos   int
+	}{
+		"missing-operator":   {given: `{job}`, pos: 4},
+		"double-equal":       {given: `{job=="api"}`, pos: 5},
+		"unquoted-value":     {given: `{job=api}`, pos: 5},
+		"unterminated":       {given: `{job="api}`, pos: 5},
+		"unclosed-brace":     {given: `{job="api"`, pos: 10},
+		"missing-comma":      {given: `{job="api" env="prod"}`, pos: 11},
+		"trailing-garbage":   {given: `{job="api"} or`, pos: 12},
+		"empty-name":         {given: `{""="api"}`, pos: 1},
+		"digit-name":         {given: `{1a="api"}`, pos: 1},
+		"invalid-escape":     {given: `{job="\q"}`, pos: 5},
+		"unsupported-regex":  {given: `{job=~"a(?:b)"}`, pos: 6},
+		"unsupported-flag":   {given: `{job=~"(?s)a"}`, pos: 6},
+		"unbalanced-regex":   {given: `{job=~"a)|(b"}`, pos: 6},
+		"metric-and-matcher": {given: `up job="api"`, pos: 3},
+	}
+
+	for hint, c := range cases {
+		_, err := qtypesprom.Parse(c.given)
+		if err == nil {
+			t.Errorf("%s: expected error", hint)
+			continue
+		}
+		var perr *qtypesprom.ParseError
+		if !errors.As(err, &perr) || !errors.Is(err, qtypesprom.ErrInvalidMatchers) {
+			t.Errorf("%s: wrong error type: %T", hint, err)
+			continue
+		}
+		if perr.Pos != c.pos {
+			t.Errorf("%s: wrong position, expected %d but got %d: %s", hint, c.pos, perr.Pos, err.Error())
+		}
+	}
+}
+
+func TestParse_anchoring(t *testing.T) {
+	exprs := []string{"", ".*", ".+", "a", "a.*", ".*a", ".*a.*", `\.*`, `a\\.*`, "a|b", "^a|b$", "(a|b)c", "[|]", "a*", ".*^a", "a$.*", ".*.*"}
+	values := []string{"", "a", "b", "ab", "ba", "bab", "ac", "bc", "c", ".", "..", `a\`, `a\.`, "|", "aa\nb", "\na"}
+
+	for _, expr := range exprs {
+		got, err := qtypesprom.Parse(`{a=~` + strconv.Quote(expr) + `}`)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", expr, err.Error())
+			continue
+		}
+		re, err := qtypes.CompilePattern(got["a"][0].Values[0], false)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", expr, err.Error())
+			continue
+		}
+		full := regexp.MustCompile(`^(?s:(?:` + expr + `))$`)
+		for _, v := range values {
+			if expected := full.MatchString(v); re.MatchString(v) != expected {
+				t.Errorf("%q: wrong output for value %q of pattern %q, expected %t", expr, v, re.String(), expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package qtypesprom converts Prometheus label matchers, e.g. {job="api",path=~"/v1/.*",code!~"5.."},
+// to and from string conditions keyed by label name.
+//
+// Matchers map to conditions as follows:
+//   - name="value" to EQUAL, name!="value" to negated EQUAL,
+//   - name=~"regex" to PATTERN, name!~"regex" to negated PATTERN,
+//   - a leading (?i) flag of a regular expression to case insensitive PATTERN.
+//
+// Prometheus regular expressions have to match the whole label value, while PATTERN matches any part of it.
+// Expressions are anchored, or unanchored in the opposite direction, accordingly.
+// For example, path=~"/v1/.*" becomes PATTERN ^/v1/ and PATTERN ^a|b$ becomes ".*(^a|b$).*".
+package qtypesprom
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Matchers is a set of label matchers, conditions are keyed by label name and have to be satisfied all together.
+// Metric name is a condition of label __name__.
+type Matchers map[string][]*qtypes.String
+
+// MetricNameLabel is the label that holds metric name.
+const MetricNameLabel = "__name__"
+
+// ErrInvalidMatchers is wrapped by errors returned when label matchers cannot be parsed.
+var ErrInvalidMatchers = errors.New("qtypes: invalid label matchers")
+
+// ParseError is returned when label matchers cannot be parsed. It wraps ErrInvalidMatchers.
+type ParseError struct {
+	// Pos is a byte offset in the input at which the problem is.
+	Pos    int
+	Reason string
+}
+
+// Error implements error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("qtypes: label matchers parsing error at position %d: %s", e.Pos, e.Reason)
+}
+
+// Unwrap returns ErrInvalidMatchers.
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidMatchers
+}
+
+// insensitiveFlag is the only flag that is translated, into Insensitive field of String.
+const insensitiveFlag = "(?i)"
+
+var labelNameExpr = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// unanchor returns PATTERN value equivalent to given fully anchored regular expression.
+// The expression has to be validated already.
+func unanchor(expr string) string {
+	if alternation(expr) {
+		return "^(" + expr + ")$"
+	}
+	start, end := "^", "$"
+	if rest, ok := strings.CutPrefix(expr, ".*"); ok {
+		expr, start = rest, ""
+		if expr == "" {
+			return ""
+		}
+	}
+	if strings.HasSuffix(expr, ".*") && !escaped(expr, len(expr)-2) {
+		expr, end = expr[:len(expr)-2], ""
+	}
+	return start + expr + end
+}
+
+// anchor returns fully anchored regular expression equivalent to given PATTERN value.
+// The value has to be validated already.
+func anchor(pattern string) string {
+	if alternation(pattern) {
+		return ".*(" + pattern + ").*"
+	}
+	start, end := ".*", ".*"
+	if rest, ok := strings.CutPrefix(pattern, "^"); ok && !repeated(rest) {
+		pattern, start = rest, ""
+	}
+	if strings.HasSuffix(pattern, "$") && !escaped(pattern, len(pattern)-1) {
+		pattern, end = pattern[:len(pattern)-1], ""
+	}
+	if pattern == "" && start == end {
+		return start
+	}
+	return start + pattern + end
+}
+
+// alternation reports whether given expression has an alternation outside of any group.
+func alternation(expr string) bool {
+	var depth int
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			i++
+		case '[':
+			i = closing(expr, i+1)
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// closing returns position of the end of bracket expression that content starts at i.
+func closing(expr string, i int) int {
+	if i < len(expr) && expr[i] == '^' {
+		i++
+	}
+	if i < len(expr) && expr[i] == ']' {
+		i++
+	}
+	for ; i < len(expr) && expr[i] != ']'; i++ {
+		if strings.HasPrefix(expr[i:], "[:") {
+			if j := strings.Index(expr[i+2:], ":]"); j >= 0 {
+				i += j + 3
+			}
+		}
+	}
+	return i
+}
+
+// escaped reports whether character at position i is preceded by an odd number of backslashes.
+func escaped(s string, i int) bool {
+	var n int
+	for i--; i >= 0 && s[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// repeated reports whether given expression starts with a repetition operator.
+func repeated(expr string) bool {
+	return expr != "" && strings.IndexByte("*+?{", expr[0]) >= 0
+}
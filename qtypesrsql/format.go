@@ -0,0 +1,169 @@
+package qtypesrsql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+)
+
+// Format returns RSQL representation of given node, that Schema.Parse understands.
+// Comparisons of invalid conditions are skipped, so are logical nodes without any valid operand.
+//
+// BETWEEN with exclusive bounds is written as a pair of comparisons, e.g. (a=gt=1;a=lt=5).
+func Format(n Node) (string, error) {
+	s, _, err := format(n)
+	return s, err
+}
+
+// format returns representation of given node, and logical operator at its top level, if any.
+func format(n Node) (string, Operator, error) {
+	switch n := n.(type) {
+	case *Comparison:
+		return formatComparison(n)
+	case *Logical:
+		sep := ";"
+		if n.Operator == Or {
+			sep = ","
+		} else if n.Operator != And {
+			return "", 0, fmt.Errorf("qtypes: rsql formatting error: unknown logical operator %s", n.Operator)
+		}
+
+		parts := make([]string, 0, len(n.Operands))
+		var op Operator
+		for _, o := range n.Operands {
+			s, inner, err := format(o)
+			if err != nil {
+				return "", 0, err
+			}
+			if s == "" {
+				continue
+			}
+			if inner == Or && n.Operator == And {
+				s = "(" + s + ")"
+			}
+			parts = append(parts, s)
+			op = inner
+		}
+		if len(parts) > 1 {
+			op = n.Operator
+		}
+		return strings.Join(parts, sep), op, nil
+	case nil:
+		return "", 0, nil
+	default:
+		return "", 0, fmt.Errorf("qtypes: rsql formatting error: unsupported node %T", n)
+	}
+}
+
+func formatComparison(c *Comparison) (string, Operator, error) {
+	if c.Condition == nil || !c.Condition.GetValid() {
+		return "", 0, nil
+	}
+
+	var (
+		values      []string
+		le, ue      bool
+		insensitive bool
+		text        bool
+	)
+	switch v := c.Condition.(type) {
+	case *qtypes.Int64:
+		for _, n := range v.Values {
+			values = append(values, strconv.FormatInt(n, 10))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Uint64:
+		for _, n := range v.Values {
+			values = append(values, strconv.FormatUint(n, 10))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Float64:
+		for _, f := range v.Values {
+			values = append(values, strconv.FormatFloat(f, 'f', -1, 64))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.Timestamp:
+		for i, t := range v.Values {
+			if t == nil {
+				return "", 0, fmt.Errorf("qtypes: rsql formatting error for selector %q: nil timestamp at position %d", c.Selector, i)
+			}
+			values = append(values, t.AsTime().UTC().Format(time.RFC3339Nano))
+		}
+		le, ue = v.LowerExclusive, v.UpperExclusive
+	case *qtypes.String:
+		values, insensitive, text = v.Values, v.Insensitive, true
+	default:
+		return "", 0, fmt.Errorf("qtypes: rsql formatting error for selector %q: unsupported condition type %T", c.Selector, c.Condition)
+	}
+	op := operator{t: c.Condition.GetType(), negation: c.Condition.GetNegation(), insensitive: insensitive}
+
+	if op.t == qtypes.QueryType_BETWEEN && (le || ue) {
+		if len(values) != 2 {
+			return "", 0, fmt.Errorf("qtypes: rsql formatting error for selector %q: between expects 2 values but got %d", c.Selector, len(values))
+		}
+		lower := map[bool]string{false: "=ge=", true: "=gt="}[le]
+		upper := map[bool]string{false: "=le=", true: "=lt="}[ue]
+		if op.negation {
+			lower = map[bool]string{false: "=lt=", true: "=le="}[le]
+			upper = map[bool]string{false: "=gt=", true: "=ge="}[ue]
+			return c.Selector + lower + quote(values[0]) + "," + c.Selector + upper + quote(values[1]), Or, nil
+		}
+		return c.Selector + lower + quote(values[0]) + ";" + c.Selector + upper + quote(values[1]), And, nil
+	}
+
+	if op.t == qtypes.QueryType_NULL {
+		return c.Selector + names[operator{t: op.t}] + strconv.FormatBool(!op.negation), 0, nil
+	}
+
+	// WILDCARD has its own operators, as ? is not a wildcard in star patterns.
+	if s, ok := c.Condition.(*qtypes.String); ok && !s.Insensitive && s.Type != qtypes.QueryType_WILDCARD {
+		if pattern, ok := qtypes.StarPattern(s); ok {
+			name := map[bool]string{false: "==", true: "!="}[op.negation]
+			return c.Selector + name + quote(pattern), 0, nil
+		}
+	}
+
+	name, ok := names[op]
+	if !ok {
+		return "", 0, fmt.Errorf("qtypes: rsql formatting error for selector %q: unsupported combination of type %s, negation %t and insensitivity %t", c.Selector, op.t, op.negation, op.insensitive)
+	}
+	args := make([]string, 0, len(values))
+	for _, v := range values {
+		if text && op.t != qtypes.QueryType_WILDCARD {
+			v = escape(v, "\\")
+		}
+		args = append(args, quote(v))
+	}
+	switch op.t {
+	case qtypes.QueryType_IN, qtypes.QueryType_BETWEEN, qtypes.QueryType_CONTAINS, qtypes.QueryType_IS_CONTAINED_BY,
+		qtypes.QueryType_OVERLAP, qtypes.QueryType_HAS_ANY_ELEMENT, qtypes.QueryType_HAS_ALL_ELEMENTS:
+		return c.Selector + name + "(" + strings.Join(args, ",") + ")", 0, nil
+	}
+	if len(args) != 1 {
+		return "", 0, fmt.Errorf("qtypes: rsql formatting error for selector %q: %s expects a single value but got %d", c.Selector, op.t, len(args))
+	}
+	return c.Selector + name + args[0], 0, nil
+}
+
+// escape prefixes given characters with a backslash.
+func escape(s, chars string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(chars, s[i]) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// quote wraps given escaped argument in double quotes, if it is empty or contains reserved characters.
+func quote(s string) string {
+	if s != "" && !strings.ContainsAny(s, reserved) {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
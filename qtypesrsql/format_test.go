@@ -0,0 +1,140 @@
+package qtypesrsql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesrsql"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFormat(t *testing.T) {
+	from := knowntimestamp.New(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC))
+	to := knowntimestamp.New(time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		given    qtypesrsql.Node
+		expected string
+	}{
+		"example": {
+			given:    and(cmp("age", qtypes.GreaterEqualInt64(18)), cmp("name", qtypes.HasPrefixString("jo"))),
+			expected: "age=ge=18;name==jo*",
+		},
+		"nil": {
+			given:    nil,
+			expected: "",
+		},
+		"invalid": {
+			given:    and(cmp("age", &qtypes.Int64{Values: []int64{1}}), cmp("age", qtypes.EqualInt64(2))),
+			expected: "age==2",
+		},
+		"precedence": {
+			given:    and(or(cmp("age", qtypes.LessInt64(18)), cmp("age", qtypes.GreaterInt64(65))), cmp("name", qtypes.EqualString("john"))),
+			expected: "(age=lt=18,age=gt=65);name==john",
+		},
+		"nested-and": {
+			given:    or(and(cmp("age", qtypes.EqualInt64(1)), cmp("age", qtypes.EqualInt64(2))), cmp("age", qtypes.EqualInt64(3))),
+			expected: "age==1;age==2,age==3",
+		},
+		"null": {
+			given:    cmp("name", &qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL}),
+			expected: "name=null=false",
+		},
+		"between": {
+			given:    cmp("age", qtypes.BetweenInt64(1, 5)),
+			expected: "age=bw=(1,5)",
+		},
+		"between-half-open": {
+			given:    cmp("created_at", qtypes.RangeTimestamp(from, to, false, true)),
+			expected: "created_at=ge=2024-05-01T00:00:00Z;created_at=lt=2024-05-02T00:00:00Z",
+		},
+		"not-between-open": {
+			given:    and(cmp("age", &qtypes.Int64{Values: []int64{1, 5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN, LowerExclusive: true, UpperExclusive: true}), cmp("id", &qtypes.Uint64{Values: []uint64{1}, Valid: true, Type: qtypes.QueryType_EQUAL})),
+			expected: "(age=le=1,age=ge=5);id==1",
+		},
+		"quoted": {
+			given:    cmp("name", &qtypes.String{Values: []string{"john doe", `say "hi"`, ""}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}),
+			expected: `name=out=("john doe","say \"hi\"","")`,
+		},
+		"escaped-star": {
+			given:    cmp("name", qtypes.EqualString(`a*\`)),
+			expected: `name==a\*\\`,
+		},
+		"substring": {
+			given:    cmp("name", qtypes.SubString("oh")),
+			expected: "name==*oh*",
+		},
+		"insensitive": {
+			given:    cmp("name", &qtypes.String{Values: []string{"oh"}, Valid: true, Negation: true, Type: qtypes.QueryType_SUBSTRING, Insensitive: true}),
+			expected: "name=nsubi=oh",
+		},
+		"wildcard": {
+			given:    cmp("name", qtypes.WildcardString(`jo*n\?`)),
+			expected: `name=glob=jo*n\?`,
+		},
+		"negated-comparison": {
+			given:    cmp("score", &qtypes.Float64{Values: []float64{1.5}, Valid: true, Negation: true, Type: qtypes.QueryType_GREATER}),
+			expected: "score=ngt=1.5",
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := qtypesrsql.Format(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("%s: wrong output, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestFormat_error(t *testing.T) {
+	cases := map[string]qtypesrsql.Node{
+		"negated-contains":     cmp("age", &qtypes.Int64{Values: []int64{1}, Valid: true, Negation: true, Type: qtypes.QueryType_CONTAINS}),
+		"between-single-value": cmp("age", &qtypes.Int64{Values: []int64{1}, Valid: true, Type: qtypes.QueryType_BETWEEN, LowerExclusive: true}),
+		"nil-timestamp":        cmp("created_at", &qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{nil}, Valid: true, Type: qtypes.QueryType_EQUAL}),
+		"unknown-operator":     &qtypesrsql.Logical{Operands: []qtypesrsql.Node{cmp("age", qtypes.EqualInt64(1))}},
+	}
+
+	for hint, given := range cases {
+		if _, err := qtypesrsql.Format(given); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
+
+func TestFormat_roundTrip(t *testing.T) {
+	given := []string{
+		"age=ge=18;name==jo*",
+		"(age=lt=18,age=gt=65);name!=john",
+		"id=out=(1,2)",
+		"score=nbw=(0.5,1.5)",
+		"created_at=le=2024-05-01T12:00:00.5Z",
+		"name=null=true,name=null=false",
+		`name=in=("john doe","a,b",'o\'neil')`,
+		`name==*.com;name!=*oh*;name==a\*b`,
+		`name=glob=j*n\?;name=nglobi=*x`,
+		`name=rgx="^j.*n$";name=minl=2;name=maxl=10`,
+		"name=eqi=John;name=hpi=jo;name=nhs=.tmp",
+		"tags=cts=(a,b);tags=icb=(a);tags=ovl=(b);tags=he=c;tags=hae=(d);tags=hle=(e)",
+	}
+
+	for _, g := range given {
+		n, err := schema.Parse(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", g, err.Error())
+		}
+		s, err := qtypesrsql.Format(n)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", g, err.Error())
+		}
+		got, err := schema.Parse(s)
+		if err != nil {
+			t.Fatalf("%s: unexpected error for formatted %q: %s", g, s, err.Error())
+		}
+		assertNode(t, g, n, got)
+	}
+}
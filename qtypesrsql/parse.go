@@ -0,0 +1,401 @@
+package qtypesrsql
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Parse parses given expression. Selectors that the schema does not list are rejected.
+// Errors are of type *ParseError, that points at the problem.
+//
+// Operator ; (and) binds stronger than , (or), parentheses can be used for grouping.
+// Sequences of the same logical operator are flattened into a single *Logical node,
+// an expression that consists of a single comparison results in *Comparison node.
+func (s Schema) Parse(expr string) (Node, error) {
+	p := &parser{schema: s, src: expr}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	p.space()
+	if p.pos < len(p.src) {
+		return nil, p.errorf(p.pos, "unexpected %q", p.src[p.pos])
+	}
+	return n, nil
+}
+
+type parser struct {
+	schema Schema
+	src    string
+	pos    int
+}
+
+// argument is a value of comparison, with escapes of *, ? and \ preserved.
+type argument struct {
+	text string
+	pos  int
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	return &ParseError{Pos: pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+// space skips white space and reports whether there was any.
+func (p *parser) space() bool {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// separator consumes logical operator written either as given character, or as given keyword surrounded by white space.
+func (p *parser) separator(char byte, keyword string) bool {
+	start := p.pos
+	sp := p.space()
+	if p.pos < len(p.src) && p.src[p.pos] == char {
+		p.pos++
+		return true
+	}
+	if sp && strings.HasPrefix(p.src[p.pos:], keyword) {
+		end := p.pos + len(keyword)
+		if end < len(p.src) && strings.IndexByte(" \t\r\n", p.src[end]) >= 0 {
+			p.pos = end
+			return true
+		}
+	}
+	p.pos = start
+	return false
+}
+
+func (p *parser) or() (Node, error) {
+	return p.logical(Or, ',', "or", p.and)
+}
+
+func (p *parser) and() (Node, error) {
+	return p.logical(And, ';', "and", p.constraint)
+}
+
+func (p *parser) logical(op Operator, char byte, keyword string, operand func() (Node, error)) (Node, error) {
+	var operands []Node
+	for {
+		n, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if l, ok := n.(*Logical); ok && l.Operator == op {
+			operands = append(operands, l.Operands...)
+		} else {
+			operands = append(operands, n)
+		}
+		if !p.separator(char, keyword) {
+			break
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &Logical{Operator: op, Operands: operands}, nil
+}
+
+func (p *parser) constraint() (Node, error) {
+	p.space()
+	if p.pos < len(p.src) && p.src[p.pos] == '(' {
+		start := p.pos
+		p.pos++
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		p.space()
+		if p.pos == len(p.src) || p.src[p.pos] != ')' {
+			return nil, p.errorf(start, "unclosed parenthesis")
+		}
+		p.pos++
+		return n, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (Node, error) {
+	start := p.pos
+	selector := p.unreserved()
+	if selector == "" {
+		return nil, p.errorf(start, "expected selector")
+	}
+	kind, ok := p.schema[selector]
+	if !ok {
+		return nil, p.errorf(start, "unknown selector %q", selector)
+	}
+
+	p.space()
+	opPos := p.pos
+	name := p.operator()
+	if name == "" {
+		return nil, p.errorf(opPos, "expected comparison operator")
+	}
+	op, ok := operators[name]
+	if !ok {
+		return nil, p.errorf(opPos, "unknown comparison operator %q", name)
+	}
+
+	p.space()
+	args, err := p.arguments()
+	if err != nil {
+		return nil, err
+	}
+	c, err := p.condition(kind, name, op, opPos, args)
+	if err != nil {
+		return nil, err
+	}
+	return &Comparison{Selector: selector, Condition: c}, nil
+}
+
+func (p *parser) unreserved() string {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte(reserved, p.src[p.pos]) < 0 {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *parser) operator() string {
+	rest := p.src[p.pos:]
+	n := 0
+	switch {
+	case strings.HasPrefix(rest, "=="), strings.HasPrefix(rest, "!="), strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, ">="):
+		n = 2
+	case strings.HasPrefix(rest, "<"), strings.HasPrefix(rest, ">"):
+		n = 1
+	case strings.HasPrefix(rest, "="):
+		n = 1
+		for n < len(rest) && ('a' <= rest[n] && rest[n] <= 'z' || 'A' <= rest[n] && rest[n] <= 'Z') {
+			n++
+		}
+		if n == 1 || n == len(rest) || rest[n] != '=' {
+			return ""
+		}
+		n++
+	}
+	p.pos += n
+	return rest[:n]
+}
+
+func (p *parser) arguments() ([]argument, error) {
+	if p.pos == len(p.src) || p.src[p.pos] != '(' {
+		a, err := p.argument()
+		if err != nil {
+			return nil, err
+		}
+		return []argument{a}, nil
+	}
+
+	start := p.pos
+	p.pos++
+	var args []argument
+	for {
+		p.space()
+		a, err := p.argument()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+		p.space()
+		if p.pos == len(p.src) {
+			return nil, p.errorf(start, "unclosed parenthesis")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return args, nil
+		default:
+			return nil, p.errorf(p.pos, "unexpected %q in list of arguments", p.src[p.pos])
+		}
+	}
+}
+
+func (p *parser) argument() (argument, error) {
+	start := p.pos
+	var quote byte
+	if p.pos < len(p.src) && (p.src[p.pos] == '"' || p.src[p.pos] == '\'') {
+		quote = p.src[p.pos]
+		p.pos++
+	}
+
+	var b strings.Builder
+	for {
+		if p.pos == len(p.src) {
+			if quote != 0 {
+				return argument{}, p.errorf(start, "unterminated quoted argument")
+			}
+			break
+		}
+		c := p.src[p.pos]
+		if quote != 0 && c == quote {
+			p.pos++
+			break
+		}
+		if quote == 0 && strings.IndexByte(reserved, c) >= 0 {
+			break
+		}
+		if c == '\\' {
+			if p.pos+1 == len(p.src) {
+				return argument{}, p.errorf(p.pos, "unterminated escape sequence")
+			}
+			p.pos++
+			c = p.src[p.pos]
+			if c == '*' || c == '?' || c == '\\' {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+	if quote == 0 && p.pos == start {
+		return argument{}, p.errorf(start, "expected argument")
+	}
+	return argument{text: b.String(), pos: start}, nil
+}
+
+// stringOnly lists query types that are supported only by strings.
+var stringOnly = []qtypes.QueryType{
+	qtypes.QueryType_HAS_PREFIX,
+	qtypes.QueryType_HAS_SUFFIX,
+	qtypes.QueryType_SUBSTRING,
+	qtypes.QueryType_PATTERN,
+	qtypes.QueryType_WILDCARD,
+	qtypes.QueryType_MIN_LENGTH,
+	qtypes.QueryType_MAX_LENGTH,
+}
+
+func (p *parser) condition(kind Kind, name string, op operator, opPos int, args []argument) (qtypes.Condition, error) {
+	if op.t == qtypes.QueryType_NULL {
+		if len(args) != 1 || (args[0].text != "true" && args[0].text != "false") {
+			return nil, p.errorf(opPos, "operator %s expects true or false", name)
+		}
+		op.negation = args[0].text == "false"
+		args = nil
+	}
+	// Arguments of == and != on strings are star patterns, see qtypes.StarString.
+	starred := kind == KindString && (name == "==" || name == "!=") && len(args) == 1
+	if starred {
+		s := qtypes.StarString(args[0].text)
+		op.t, args[0].text = s.Type, s.Values[0]
+	}
+	if kind != KindString && (op.insensitive || slices.Contains(stringOnly, op.t)) {
+		return nil, p.errorf(opPos, "operator %s is supported only for strings", name)
+	}
+
+	switch op.t {
+	case qtypes.QueryType_NULL:
+	case qtypes.QueryType_BETWEEN:
+		if len(args) != 2 {
+			return nil, p.errorf(opPos, "operator %s expects 2 arguments but got %d", name, len(args))
+		}
+	case qtypes.QueryType_IN, qtypes.QueryType_CONTAINS, qtypes.QueryType_IS_CONTAINED_BY, qtypes.QueryType_OVERLAP,
+		qtypes.QueryType_HAS_ANY_ELEMENT, qtypes.QueryType_HAS_ALL_ELEMENTS:
+	default:
+		if len(args) != 1 {
+			return nil, p.errorf(opPos, "operator %s expects a single argument but got %d", name, len(args))
+		}
+	}
+
+	switch kind {
+	case KindInt64:
+		values, err := parseValues(p, args, func(s string) (int64, error) {
+			return strconv.ParseInt(s, 10, 64)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.Int64{Values: values, Valid: true, Negation: op.negation, Type: op.t}, nil
+	case KindUint64:
+		values, err := parseValues(p, args, func(s string) (uint64, error) {
+			return strconv.ParseUint(s, 10, 64)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.Uint64{Values: values, Valid: true, Negation: op.negation, Type: op.t}, nil
+	case KindFloat64:
+		values, err := parseValues(p, args, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.Float64{Values: values, Valid: true, Negation: op.negation, Type: op.t}, nil
+	case KindTimestamp:
+		values, err := parseValues(p, args, func(s string) (*knowntimestamp.Timestamp, error) {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, err
+			}
+			return knowntimestamp.New(t), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.Timestamp{Values: values, Valid: true, Negation: op.negation, Type: op.t}, nil
+	case KindString:
+		if !starred && op.t != qtypes.QueryType_WILDCARD {
+			for i := range args {
+				args[i].text = unescape(args[i].text)
+			}
+		}
+		values, err := parseValues(p, args, func(s string) (string, error) {
+			switch op.t {
+			case qtypes.QueryType_PATTERN:
+				return s, qtypes.ValidatePattern(s)
+			case qtypes.QueryType_MIN_LENGTH, qtypes.QueryType_MAX_LENGTH:
+				_, err := strconv.ParseUint(s, 10, 63)
+				return s, err
+			}
+			return s, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &qtypes.String{Values: values, Valid: true, Negation: op.negation, Type: op.t, Insensitive: op.insensitive}, nil
+	default:
+		return nil, p.errorf(opPos, "unknown kind %d", kind)
+	}
+}
+
+func parseValues[T any](p *parser, args []argument, fn func(string) (T, error)) ([]T, error) {
+	if args == nil {
+		return nil, nil
+	}
+	res := make([]T, 0, len(args))
+	for _, a := range args {
+		v, err := fn(a.text)
+		if err != nil {
+			return nil, p.errorf(a.pos, "invalid argument %q: %s", a.text, err.Error())
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+// unescape removes escapes of *, ? and \, that arguments preserve.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,206 @@
+package qtypesrsql_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypesrsql"
+	"google.golang.org/protobuf/proto"
+	knowntimestamp "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var schema = qtypesrsql.Schema{
+	"age":        qtypesrsql.KindInt64,
+	"id":         qtypesrsql.KindUint64,
+	"score":      qtypesrsql.KindFloat64,
+	"created_at": qtypesrsql.KindTimestamp,
+	"name":       qtypesrsql.KindString,
+	"tags":       qtypesrsql.KindString,
+}
+
+func cmp(selector string, c qtypes.Condition) *qtypesrsql.Comparison {
+	return &qtypesrsql.Comparison{Selector: selector, Condition: c}
+}
+
+func and(operands ...qtypesrsql.Node) *qtypesrsql.Logical {
+	return &qtypesrsql.Logical{Operator: qtypesrsql.And, Operands: operands}
+}
+
+func or(operands ...qtypesrsql.Node) *qtypesrsql.Logical {
+	return &qtypesrsql.Logical{Operator: qtypesrsql.Or, Operands: operands}
+}
+
+func assertNode(t *testing.T, hint string, expected, got qtypesrsql.Node) {
+	t.Helper()
+
+	switch e := expected.(type) {
+	case *qtypesrsql.Comparison:
+		g, ok := got.(*qtypesrsql.Comparison)
+		if !ok {
+			t.Errorf("%s: wrong node, expected comparison but got %T", hint, got)
+			return
+		}
+		if e.Selector != g.Selector || !proto.Equal(e.Condition, g.Condition) {
+			t.Errorf("%s: wrong comparison,\nexpected:\n	%s %v\nbut got:\n	%s %v\n", hint, e.Selector, e.Condition, g.Selector, g.Condition)
+		}
+	case *qtypesrsql.Logical:
+		g, ok := got.(*qtypesrsql.Logical)
+		if !ok {
+			t.Errorf("%s: wrong node, expected logical but got %T", hint, got)
+			return
+		}
+		if e.Operator != g.Operator || len(e.Operands) != len(g.Operands) {
+			t.Errorf("%s: wrong logical node, expected %s of %d operands but got %s of %d", hint, e.Operator, len(e.Operands), g.Operator, len(g.Operands))
+			return
+		}
+		for i := range e.Operands {
+			assertNode(t, hint, e.Operands[i], g.Operands[i])
+		}
+	}
+}
+
+func TestSchema_Parse(t *testing.T) {
+	created := knowntimestamp.New(time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		given    string
+		expected qtypesrsql.Node
+	}{
+		"example": {
+			given:    "age=ge=18;name==jo*",
+			expected: and(cmp("age", qtypes.GreaterEqualInt64(18)), cmp("name", qtypes.HasPrefixString("jo"))),
+		},
+		"precedence": {
+			given: "age<18,age>65;name!=john",
+			expected: or(
+				cmp("age", qtypes.LessInt64(18)),
+				and(cmp("age", qtypes.GreaterInt64(65)), cmp("name", &qtypes.String{Values: []string{"john"}, Valid: true, Negation: true, Type: qtypes.QueryType_EQUAL})),
+			),
+		},
+		"parentheses": {
+			given: "(age=lt=18 or age=gt=65) and name==john",
+			expected: and(
+				or(cmp("age", qtypes.LessInt64(18)), cmp("age", qtypes.GreaterInt64(65))),
+				cmp("name", qtypes.EqualString("john")),
+			),
+		},
+		"flattening": {
+			given:    "age==1;(age==2;age==3)",
+			expected: and(cmp("age", qtypes.EqualInt64(1)), cmp("age", qtypes.EqualInt64(2)), cmp("age", qtypes.EqualInt64(3))),
+		},
+		"in": {
+			given:    "id=out=( 1 , 2 )",
+			expected: cmp("id", &qtypes.Uint64{Values: []uint64{1, 2}, Valid: true, Negation: true, Type: qtypes.QueryType_IN}),
+		},
+		"quoted": {
+			given:    `name=in=("john doe",'o\'neil',"a,b")`,
+			expected: cmp("name", &qtypes.String{Values: []string{"john doe", "o'neil", "a,b"}, Valid: true, Type: qtypes.QueryType_IN}),
+		},
+		"empty-quoted": {
+			given:    `name==""`,
+			expected: cmp("name", qtypes.EqualString("")),
+		},
+		"null": {
+			given:    "age=null=true;name=null=false",
+			expected: and(cmp("age", qtypes.NullInt64()), cmp("name", &qtypes.String{Valid: true, Negation: true, Type: qtypes.QueryType_NULL})),
+		},
+		"between": {
+			given:    "score=nbw=(0.5,1.5)",
+			expected: cmp("score", &qtypes.Float64{Values: []float64{0.5, 1.5}, Valid: true, Negation: true, Type: qtypes.QueryType_BETWEEN}),
+		},
+		"timestamp": {
+			given:    "created_at=le=2024-05-01T12:00:00Z",
+			expected: cmp("created_at", &qtypes.Timestamp{Values: []*knowntimestamp.Timestamp{created}, Valid: true, Type: qtypes.QueryType_LESS_EQUAL}),
+		},
+		"suffix": {
+			given:    "name!=*.com",
+			expected: cmp("name", &qtypes.String{Values: []string{".com"}, Valid: true, Negation: true, Type: qtypes.QueryType_HAS_SUFFIX}),
+		},
+		"substring": {
+			given:    "name==*oh*",
+			expected: cmp("name", qtypes.SubString("oh")),
+		},
+		"wildcard": {
+			given:    `name==j*n?\*`,
+			expected: cmp("name", qtypes.WildcardString(`j*n\?\*`)),
+		},
+		"escaped-star": {
+			given:    `name=="a\*"`,
+			expected: cmp("name", qtypes.EqualString("a*")),
+		},
+		"star-in-other-operators": {
+			given:    `name=hpi=a*`,
+			expected: cmp("name", &qtypes.String{Values: []string{"a*"}, Valid: true, Type: qtypes.QueryType_HAS_PREFIX, Insensitive: true}),
+		},
+		"extended": {
+			given: `name=rgx="^j.*n$";name=glob=jo?n;name=minl=2;tags=cts=(a,b)`,
+			expected: and(
+				cmp("name", &qtypes.String{Values: []string{"^j.*n$"}, Valid: true, Type: qtypes.QueryType_PATTERN}),
+				cmp("name", qtypes.WildcardString("jo?n")),
+				cmp("name", &qtypes.String{Values: []string{"2"}, Valid: true, Type: qtypes.QueryType_MIN_LENGTH}),
+				cmp("tags", &qtypes.String{Values: []string{"a", "b"}, Valid: true, Type: qtypes.QueryType_CONTAINS}),
+			),
+		},
+		"insensitive": {
+			given:    "name=ini=(a,B)",
+			expected: cmp("name", qtypes.InStringInsensitive("a", "B")),
+		},
+	}
+
+	for hint, c := range cases {
+		got, err := schema.Parse(c.given)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		assertNode(t, hint, c.expected, got)
+	}
+}
+
+func TestSchema_Parse_error(t *testing.T) {
+	cases := map[string]struct {
+		given string
+		pos   int
+	}{
+		"empty":                 {given: "", pos: 0},
+		"unknown-selector":      {given: "age==1;email==a", pos: 7},
+		"missing-operator":      {given: "age", pos: 3},
+		"unknown-operator":      {given: "age=foo=1", pos: 3},
+		"missing-argument":      {given: "age==", pos: 5},
+		"invalid-value":         {given: "age=in=(1,x)", pos: 10},
+		"unterminated-quote":    {given: `name=="abc`, pos: 6},
+		"unclosed-parenthesis":  {given: "(age==1;age==2", pos: 0},
+		"unclosed-arguments":    {given: "age=in=(1,2", pos: 7},
+		"trailing":              {given: "age==1)", pos: 6},
+		"dangling-separator":    {given: "age==1;", pos: 7},
+		"string-only-operator":  {given: "age=hp=1", pos: 3},
+		"insensitive-of-int":    {given: "age=eqi=1", pos: 3},
+		"too-many-arguments":    {given: "age==(1,2)", pos: 3},
+		"between-single-value":  {given: "age=bw=1", pos: 3},
+		"null-argument":         {given: "age=null=yes", pos: 3},
+		"unsupported-pattern":   {given: `name=rgx="(?i)a"`, pos: 9},
+		"min-length-not-number": {given: "name=minl=a", pos: 10},
+		"timestamp":             {given: "created_at==yesterday", pos: 12},
+	}
+
+	for hint, c := range cases {
+		_, err := schema.Parse(c.given)
+		if err == nil {
+			t.Errorf("%s: expected error", hint)
+			continue
+		}
+		if !errors.Is(err, qtypesrsql.ErrInvalidExpression) {
+			t.Errorf("%s: expected error to wrap ErrInvalidExpression, got: %s", hint, err.Error())
+		}
+		var pe *qtypesrsql.ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: expected *ParseError, got %T", hint, err)
+			continue
+		}
+		if pe.Pos != c.pos {
+			t.Errorf("%s: wrong position, expected %d but got %d: %s", hint, c.pos, pe.Pos, err.Error())
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Package qtypesrsql parses RSQL/FIQL expressions, e.g. age=ge=18;name==jo*, into conditions grouped by AND and OR,
+// and formats them back.
+//
+// Besides standard comparison operators ==, !=, =lt= (<), =le= (<=), =gt= (>), =ge= (>=), =in= and =out=,
+// every query type is available as an operator named after the qtypeshttp prefix, e.g. =hp= for HAS_PREFIX or =nbw= for negated BETWEEN.
+// NULL is expressed as =null=true, its negation as =null=false.
+//
+// Arguments are either unquoted, or quoted with single or double quotes.
+// A backslash escapes the next character, e.g. \" or \,. Asterisk in arguments of == and != operators on strings
+// matches any sequence of characters: jo* is HAS_PREFIX, *jo HAS_SUFFIX, *jo* SUBSTRING and j*o WILDCARD,
+// escaped one \* is a literal asterisk. Values of WILDCARD operators use qtypes.MatchWildcard syntax.
+package qtypesrsql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/piotrkowalczuk/qtypes"
+	"github.com/piotrkowalczuk/qtypes/qtypeshttp"
+)
+
+// Kind is a type of conditions that a selector holds.
+type Kind int
+
+const (
+	// KindInt64 is a selector of qtypes.Int64 conditions.
+	KindInt64 Kind = iota + 1
+	// KindUint64 is a selector of qtypes.Uint64 conditions.
+	KindUint64
+	// KindFloat64 is a selector of qtypes.Float64 conditions.
+	KindFloat64
+	// KindTimestamp is a selector of qtypes.Timestamp conditions, its values are RFC 3339 timestamps.
+	KindTimestamp
+	// KindString is a selector of qtypes.String conditions.
+	KindString
+)
+
+// Schema lists selectors that an expression can use, and types of their conditions.
+type Schema map[string]Kind
+
+// Node is a node of an expression, either *Comparison or *Logical.
+type Node interface {
+	node()
+}
+
+// Comparison is a condition on a selector.
+type Comparison struct {
+	Selector  string
+	Condition qtypes.Condition
+}
+
+// Logical combines its operands using AND or OR.
+type Logical struct {
+	Operator Operator
+	Operands []Node
+}
+
+func (*Comparison) node() {}
+func (*Logical) node()    {}
+
+// Operator is a logical operator.
+type Operator int
+
+const (
+	// And is satisfied if all operands are satisfied, it is written as ; or and.
+	And Operator = iota + 1
+	// Or is satisfied if any operand is satisfied, it is written as , or or.
+	Or
+)
+
+// String implements fmt.Stringer interface.
+func (o Operator) String() string {
+	switch o {
+	case And:
+		return "AND"
+	case Or:
+		return "OR"
+	default:
+		return fmt.Sprintf("Operator(%d)", int(o))
+	}
+}
+
+// ErrInvalidExpression is wrapped by errors returned when an expression cannot be parsed.
+var ErrInvalidExpression = errors.New("qtypes: invalid rsql expression")
+
+// ParseError is returned when an expression cannot be parsed. It wraps ErrInvalidExpression.
+type ParseError struct {
+	// Pos is a byte offset in the expression at which the problem is.
+	Pos    int
+	Reason string
+}
+
+// Error implements error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("qtypes: rsql parsing error at position %d: %s", e.Pos, e.Reason)
+}
+
+// Unwrap returns ErrInvalidExpression.
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidExpression
+}
+
+type operator struct {
+	t           qtypes.QueryType
+	negation    bool
+	insensitive bool
+}
+
+func extended(prefix string) string {
+	return "=" + prefix + "="
+}
+
+// comparisons lists operators in order of preference, the first operator of a kind is used for formatting.
+var comparisons = []struct {
+	name string
+	op   operator
+}{
+	{name: "==", op: operator{t: qtypes.QueryType_EQUAL}},
+	{name: "!=", op: operator{t: qtypes.QueryType_EQUAL, negation: true}},
+	{name: "=lt=", op: operator{t: qtypes.QueryType_LESS}},
+	{name: "<", op: operator{t: qtypes.QueryType_LESS}},
+	{name: "=le=", op: operator{t: qtypes.QueryType_LESS_EQUAL}},
+	{name: "<=", op: operator{t: qtypes.QueryType_LESS_EQUAL}},
+	{name: "=gt=", op: operator{t: qtypes.QueryType_GREATER}},
+	{name: ">", op: operator{t: qtypes.QueryType_GREATER}},
+	{name: "=ge=", op: operator{t: qtypes.QueryType_GREATER_EQUAL}},
+	{name: ">=", op: operator{t: qtypes.QueryType_GREATER_EQUAL}},
+	{name: "=in=", op: operator{t: qtypes.QueryType_IN}},
+	{name: "=out=", op: operator{t: qtypes.QueryType_IN, negation: true}},
+	{name: extended(qtypeshttp.Null), op: operator{t: qtypes.QueryType_NULL}},
+	{name: extended(qtypeshttp.EqualInsensitive), op: operator{t: qtypes.QueryType_EQUAL, insensitive: true}},
+	{name: extended(qtypeshttp.NotEqualInsensitive), op: operator{t: qtypes.QueryType_EQUAL, negation: true, insensitive: true}},
+	{name: extended(qtypeshttp.NotGreaterThan), op: operator{t: qtypes.QueryType_GREATER, negation: true}},
+	{name: extended(qtypeshttp.NotGreaterThanOrEqual), op: operator{t: qtypes.QueryType_GREATER_EQUAL, negation: true}},
+	{name: extended(qtypeshttp.NotLessThan), op: operator{t: qtypes.QueryType_LESS, negation: true}},
+	{name: extended(qtypeshttp.NotLessThanOrEqual), op: operator{t: qtypes.QueryType_LESS_EQUAL, negation: true}},
+	{name: extended(qtypeshttp.Between), op: operator{t: qtypes.QueryType_BETWEEN}},
+	{name: extended(qtypeshttp.NotBetween), op: operator{t: qtypes.QueryType_BETWEEN, negation: true}},
+	{name: extended(qtypeshttp.HasPrefix), op: operator{t: qtypes.QueryType_HAS_PREFIX}},
+	{name: extended(qtypeshttp.NotHasPrefix), op: operator{t: qtypes.QueryType_HAS_PREFIX, negation: true}},
+	{name: extended(qtypeshttp.HasPrefixInsensitive), op: operator{t: qtypes.QueryType_HAS_PREFIX, insensitive: true}},
+	{name: extended(qtypeshttp.NotHasPrefixInsensitive), op: operator{t: qtypes.QueryType_HAS_PREFIX, negation: true, insensitive: true}},
+	{name: extended(qtypeshttp.HasSuffix), op: operator{t: qtypes.QueryType_HAS_SUFFIX}},
+	{name: extended(qtypeshttp.NotHasSuffix), op: operator{t: qtypes.QueryType_HAS_SUFFIX, negation: true}},
+	{name: extended(qtypeshttp.HasSuffixInsensitive), op: operator{t: qtypes.QueryType_HAS_SUFFIX, insensitive: true}},
+	{name: extended(qtypeshttp.NotHasSuffixInsensitive), op: operator{t: qtypes.QueryType_HAS_SUFFIX, negation: true, insensitive: true}},
+	{name: extended(qtypeshttp.Substring), op: operator{t: qtypes.QueryType_SUBSTRING}},
+	{name: extended(qtypeshttp.NotSubstring), op: operator{t: qtypes.QueryType_SUBSTRING, negation: true}},
+	{name: extended(qtypeshttp.SubstringInsensitive), op: operator{t: qtypes.QueryType_SUBSTRING, insensitive: true}},
+	{name: extended(qtypeshttp.NotSubstringInsensitive), op: operator{t: qtypes.QueryType_SUBSTRING, negation: true, insensitive: true}},
+	{name: extended(qtypeshttp.InInsensitive), op: operator{t: qtypes.QueryType_IN, insensitive: true}},
+	{name: extended(qtypeshttp.NotInInsensitive), op: operator{t: qtypes.QueryType_IN, negation: true, insensitive: true}},
+	{name: extended(qtypeshttp.Pattern), op: operator{t: qtypes.QueryType_PATTERN}},
+	{name: extended(qtypeshttp.NotPattern), op: operator{t: qtypes.QueryType_PATTERN, negation: true}},
+	{name: extended(qtypeshttp.Wildcard), op: operator{t: qtypes.QueryType_WILDCARD}},
+	{name: extended(qtypeshttp.NotWildcard), op: operator{t: qtypes.QueryType_WILDCARD, negation: true}},
+	{name: extended(qtypeshttp.WildcardInsensitive), op: operator{t: qtypes.QueryType_WILDCARD, insensitive: true}},
+	{name: extended(qtypeshttp.NotWildcardInsensitive), op: operator{t: qtypes.QueryType_WILDCARD, negation: true, insensitive: true}},
+	{name: extended(qtypeshttp.MinLength), op: operator{t: qtypes.QueryType_MIN_LENGTH}},
+	{name: extended(qtypeshttp.MaxLength), op: operator{t: qtypes.QueryType_MAX_LENGTH}},
+	{name: extended(qtypeshttp.Contains), op: operator{t: qtypes.QueryType_CONTAINS}},
+	{name: extended(qtypeshttp.IsContainedBy), op: operator{t: qtypes.QueryType_IS_CONTAINED_BY}},
+	{name: extended(qtypeshttp.Overlap), op: operator{t: qtypes.QueryType_OVERLAP}},
+	{name: extended(qtypeshttp.HasElement), op: operator{t: qtypes.QueryType_HAS_ELEMENT}},
+	{name: extended(qtypeshttp.HasAnyElement), op: operator{t: qtypes.QueryType_HAS_ANY_ELEMENT}},
+	{name: extended(qtypeshttp.HasAllElements), op: operator{t: qtypes.QueryType_HAS_ALL_ELEMENTS}},
+}
+
+var (
+	operators = func() map[string]operator {
+		res := make(map[string]operator, len(comparisons))
+		for _, c := range comparisons {
+			res[c.name] = c.op
+		}
+		return res
+	}()
+	names = func() map[operator]string {
+		res := make(map[operator]string, len(comparisons))
+		for _, c := range comparisons {
+			if _, ok := res[c.op]; !ok {
+				res[c.op] = c.name
+			}
+		}
+		return res
+	}()
+)
+
+// reserved lists characters that cannot be part of an unquoted selector or argument.
+const reserved = "\"'();,=!~<> \t\r\n"
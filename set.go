@@ -0,0 +1,326 @@
+package qtypes
+
+import (
+	"slices"
+)
+
+// bound is one side of an interval, infinite bound leaves the side unbounded.
+type bound[T any] struct {
+	value     T
+	exclusive bool
+	infinite  bool
+}
+
+type interval[T any] struct {
+	lo, hi bound[T]
+}
+
+// valueSet is a set of values that satisfy a condition.
+// Not null values are represented as sorted, disjoint intervals.
+type valueSet[T any] struct {
+	null      bool
+	intervals []interval[T]
+}
+
+func (s valueSet[T]) empty() bool {
+	return !s.null && len(s.intervals) == 0
+}
+
+func (d domain[T]) compareLower(a, b bound[T]) int {
+	switch {
+	case a.infinite && b.infinite:
+		return 0
+	case a.infinite:
+		return -1
+	case b.infinite:
+		return 1
+	}
+	if c := d.compare(a.value, b.value); c != 0 {
+		return c
+	}
+	switch {
+	case a.exclusive == b.exclusive:
+		return 0
+	case a.exclusive:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func (d domain[T]) compareUpper(a, b bound[T]) int {
+	switch {
+	case a.infinite && b.infinite:
+		return 0
+	case a.infinite:
+		return 1
+	case b.infinite:
+		return -1
+	}
+	if c := d.compare(a.value, b.value); c != 0 {
+		return c
+	}
+	switch {
+	case a.exclusive == b.exclusive:
+		return 0
+	case a.exclusive:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// interval allocates an interval, exclusive bounds of discrete domains are turned into inclusive ones.
+// It reports false if the interval is empty.
+func (d domain[T]) interval(lo, hi bound[T]) (interval[T], bool) {
+	if d.discrete() {
+		if !lo.infinite && lo.exclusive {
+			v, ok := d.next(lo.value)
+			if !ok {
+				return interval[T]{}, false
+			}
+			lo = bound[T]{value: v}
+		}
+		if !hi.infinite && hi.exclusive {
+			v, ok := d.prev(hi.value)
+			if !ok {
+				return interval[T]{}, false
+			}
+			hi = bound[T]{value: v}
+		}
+	}
+	if lo.infinite || hi.infinite {
+		return interval[T]{lo: lo, hi: hi}, true
+	}
+	c := d.compare(lo.value, hi.value)
+	if c > 0 || (c == 0 && (lo.exclusive || hi.exclusive)) {
+		return interval[T]{}, false
+	}
+	return interval[T]{lo: lo, hi: hi}, true
+}
+
+func (d domain[T]) point(i interval[T]) bool {
+	return !i.lo.infinite && !i.hi.infinite && d.compare(i.lo.value, i.hi.value) == 0
+}
+
+// union returns sorted and disjoint intervals that cover the same values as given ones.
+func (d domain[T]) union(intervals []interval[T]) []interval[T] {
+	sorted := slices.Clone(intervals)
+	slices.SortFunc(sorted, func(a, b interval[T]) int {
+		return d.compareLower(a.lo, b.lo)
+	})
+
+	var res []interval[T]
+	for _, i := range sorted {
+		if len(res) == 0 {
+			res = append(res, i)
+			continue
+		}
+		last := &res[len(res)-1]
+		if !d.overlap(*last, i) {
+			res = append(res, i)
+			continue
+		}
+		if d.compareUpper(i.hi, last.hi) > 0 {
+			last.hi = i.hi
+		}
+	}
+	return res
+}
+
+// overlap reports whether b, that does not start before a, overlaps or touches a.
+func (d domain[T]) overlap(a, b interval[T]) bool {
+	if a.hi.infinite || b.lo.infinite {
+		return true
+	}
+	c := d.compare(a.hi.value, b.lo.value)
+	return c > 0 || (c == 0 && !(a.hi.exclusive && b.lo.exclusive))
+}
+
+// join merges sorted and disjoint intervals that are adjacent in discrete domain, e.g. [1, 2] and [3, 4] into [1, 4].
+func (d domain[T]) join(intervals []interval[T]) []interval[T] {
+	if !d.discrete() {
+		return intervals
+	}
+
+	var res []interval[T]
+	for _, i := range intervals {
+		if len(res) > 0 {
+			last := &res[len(res)-1]
+			if next, ok := d.next(last.hi.value); ok && !last.hi.infinite && !i.lo.infinite && d.compare(next, i.lo.value) == 0 {
+				last.hi = i.hi
+				continue
+			}
+		}
+		res = append(res, i)
+	}
+	return res
+}
+
+func (d domain[T]) intersect(a, b valueSet[T]) valueSet[T] {
+	res := valueSet[T]{null: a.null && b.null}
+	for i, j := 0, 0; i < len(a.intervals) && j < len(b.intervals); {
+		x, y := a.intervals[i], b.intervals[j]
+		lo, hi := x.lo, x.hi
+		if d.compareLower(y.lo, lo) > 0 {
+			lo = y.lo
+		}
+		if d.compareUpper(y.hi, hi) < 0 {
+			hi = y.hi
+		}
+		if in, ok := d.interval(lo, hi); ok {
+			res.intervals = append(res.intervals, in)
+		}
+		if d.compareUpper(x.hi, y.hi) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res
+}
+
+// complement returns set of not null values that are not part of given set.
+func (d domain[T]) complement(s valueSet[T]) valueSet[T] {
+	var res valueSet[T]
+
+	lo := bound[T]{infinite: true}
+	for _, i := range s.intervals {
+		if !i.lo.infinite {
+			if in, ok := d.interval(lo, bound[T]{value: i.lo.value, exclusive: !i.lo.exclusive}); ok {
+				res.intervals = append(res.intervals, in)
+			}
+		}
+		if i.hi.infinite {
+			return res
+		}
+		lo = bound[T]{value: i.hi.value, exclusive: !i.hi.exclusive}
+	}
+	if in, ok := d.interval(lo, bound[T]{infinite: true}); ok {
+		res.intervals = append(res.intervals, in)
+	}
+	return res
+}
+
+// valueSet returns set of values that satisfy given condition.
+// It reports false if the condition is invalid or its type is not supported.
+func (d domain[T]) valueSet(c condition[T]) (valueSet[T], bool) {
+	if !c.valid {
+		return valueSet[T]{}, false
+	}
+
+	var res valueSet[T]
+	switch c.t {
+	case QueryType_NULL:
+		if !c.negation {
+			return valueSet[T]{null: true}, true
+		}
+		return valueSet[T]{intervals: []interval[T]{{lo: bound[T]{infinite: true}, hi: bound[T]{infinite: true}}}}, true
+	case QueryType_EQUAL, QueryType_IN:
+		if c.t == QueryType_EQUAL && len(c.values) != 1 {
+			return valueSet[T]{}, false
+		}
+		for _, v := range c.values {
+			res.intervals = append(res.intervals, interval[T]{lo: bound[T]{value: v}, hi: bound[T]{value: v}})
+		}
+		res.intervals = d.union(res.intervals)
+	case QueryType_GREATER, QueryType_GREATER_EQUAL, QueryType_LESS, QueryType_LESS_EQUAL:
+		if len(c.values) != 1 {
+			return valueSet[T]{}, false
+		}
+		v := bound[T]{value: c.values[0], exclusive: c.t == QueryType_GREATER || c.t == QueryType_LESS}
+		lo, hi := v, bound[T]{infinite: true}
+		if c.t == QueryType_LESS || c.t == QueryType_LESS_EQUAL {
+			lo, hi = bound[T]{infinite: true}, v
+		}
+		if in, ok := d.interval(lo, hi); ok {
+			res.intervals = append(res.intervals, in)
+		}
+	case QueryType_BETWEEN:
+		if len(c.values) != 2 {
+			return valueSet[T]{}, false
+		}
+		lo := bound[T]{value: c.values[0], exclusive: c.lowerExclusive}
+		hi := bound[T]{value: c.values[1], exclusive: c.upperExclusive}
+		if d.compare(lo.value, hi.value) > 0 {
+			lo, hi = bound[T]{value: hi.value, exclusive: hi.exclusive}, bound[T]{value: lo.value, exclusive: lo.exclusive}
+		}
+		if in, ok := d.interval(lo, hi); ok {
+			res.intervals = append(res.intervals, in)
+		}
+	default:
+		return valueSet[T]{}, false
+	}
+	if c.negation {
+		return d.complement(res), true
+	}
+	return res, true
+}
+
+// condition returns single condition that is satisfied by exactly given set of values.
+func (d domain[T]) condition(s valueSet[T]) (condition[T], error) {
+	switch {
+	case s.empty():
+		return condition[T]{}, ErrUnsatisfiable
+	case s.null && len(s.intervals) == 0:
+		return condition[T]{valid: true, t: QueryType_NULL}, nil
+	case s.null:
+		return condition[T]{}, ErrNotRepresentable
+	}
+
+	if values, ok := d.points(s.intervals); ok {
+		return pointsCondition(values, false), nil
+	}
+	if len(s.intervals) == 1 {
+		return d.intervalCondition(s.intervals[0], false), nil
+	}
+
+	c := d.complement(s)
+	if values, ok := d.points(c.intervals); ok {
+		return pointsCondition(values, true), nil
+	}
+	if len(c.intervals) == 1 && !c.intervals[0].lo.infinite && !c.intervals[0].hi.infinite {
+		return d.intervalCondition(c.intervals[0], true), nil
+	}
+	return condition[T]{}, ErrNotRepresentable
+}
+
+func (d domain[T]) points(intervals []interval[T]) ([]T, bool) {
+	if len(intervals) == 0 {
+		return nil, false
+	}
+	values := make([]T, 0, len(intervals))
+	for _, i := range intervals {
+		if !d.point(i) {
+			return nil, false
+		}
+		values = append(values, i.lo.value)
+	}
+	return values, true
+}
+
+func pointsCondition[T any](values []T, negation bool) condition[T] {
+	if len(values) == 1 {
+		return condition[T]{values: values, valid: true, negation: negation, t: QueryType_EQUAL}
+	}
+	return condition[T]{values: values, valid: true, negation: negation, t: QueryType_IN}
+}
+
+func (d domain[T]) intervalCondition(i interval[T], negation bool) condition[T] {
+	switch {
+	case i.lo.infinite && i.hi.infinite:
+		return condition[T]{valid: true, negation: !negation, t: QueryType_NULL}
+	case i.lo.infinite:
+		return condition[T]{values: []T{i.hi.value}, valid: true, negation: negation, t: unboundedType(true, false, i.hi.exclusive)}
+	case i.hi.infinite:
+		return condition[T]{values: []T{i.lo.value}, valid: true, negation: negation, t: unboundedType(false, i.lo.exclusive, false)}
+	}
+	return condition[T]{
+		values:         []T{i.lo.value, i.hi.value},
+		valid:          true,
+		negation:       negation,
+		t:              QueryType_BETWEEN,
+		lowerExclusive: i.lo.exclusive,
+		upperExclusive: i.hi.exclusive,
+	}
+}
@@ -0,0 +1,193 @@
+package qtypes
+
+import (
+	"strings"
+)
+
+// MatchWildcard reports whether s matches given WILDCARD pattern.
+// Within the pattern, "*" matches any sequence of characters, including an empty one, and "?" matches exactly one character.
+// Backslash escapes the following character, e.g. "\*" matches an asterisk.
+// A trailing backslash matches itself.
+// If insensitive, both are folded using DefaultFolding first, so "?" matches a single character of the folded value.
+func MatchWildcard(pattern, s string, insensitive bool) bool {
+	if insensitive {
+		pattern, s = foldCase(pattern), foldCase(s)
+	}
+	p, v := []rune(pattern), []rune(s)
+
+	// Backtracking is limited to the last seen star,
+	// so that the number of steps is bounded by the product of lengths, whatever the pattern.
+	var pi, vi int
+	star, next := -1, 0
+	for vi < len(v) {
+		if pi < len(p) {
+			switch c := p[pi]; {
+			case c == '*':
+				star, next = pi, vi
+				pi++
+				continue
+			case c == '?':
+				pi++
+				vi++
+				continue
+			case c == '\\' && pi+1 < len(p):
+				if p[pi+1] == v[vi] {
+					pi += 2
+					vi++
+					continue
+				}
+			case c == v[vi]:
+				pi++
+				vi++
+				continue
+			}
+		}
+		if star < 0 {
+			return false
+		}
+		next++
+		pi, vi = star+1, next
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
+
+// WildcardToLike translates given WILDCARD pattern to a SQL LIKE pattern.
+// Literal "%", "_" and backslash are escaped with a backslash, so the pattern has to be used with ESCAPE '\',
+// which is the default in PostgreSQL and MySQL.
+// Case insensitive conditions can be expressed using ILIKE in PostgreSQL, or by lowering both sides.
+func WildcardToLike(pattern string) string {
+	var b strings.Builder
+	b.Grow(len(pattern))
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteByte('%')
+		case '?':
+			b.WriteByte('_')
+		case '%', '_':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				c = pattern[i]
+			}
+			if c == '%' || c == '_' || c == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// StarString allocates valid String object that matches the same values as given pattern,
+// in which "*" matches any sequence of characters and backslash escapes the following character.
+// Query languages like RSQL or AIP-160 use such patterns. The most specific query type is chosen,
+// e.g. HAS_PREFIX of "jo" for "jo*", SUBSTRING for "*jo*" or EQUAL for a pattern without wildcards.
+// Other patterns result in WILDCARD.
+func StarString(pattern string) *String {
+	var (
+		segments []string
+		b        strings.Builder
+	)
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			segments = append(segments, b.String())
+			b.Reset()
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			b.WriteByte(pattern[i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	segments = append(segments, b.String())
+
+	s := &String{Valid: true}
+	switch {
+	case len(segments) == 1:
+		s.Type, s.Values = QueryType_EQUAL, segments
+	case len(segments) == 2 && segments[0] != "" && segments[1] == "":
+		s.Type, s.Values = QueryType_HAS_PREFIX, segments[:1]
+	case len(segments) == 2 && segments[0] == "" && segments[1] != "":
+		s.Type, s.Values = QueryType_HAS_SUFFIX, segments[1:]
+	case len(segments) == 3 && segments[0] == "" && segments[1] != "" && segments[2] == "":
+		s.Type, s.Values = QueryType_SUBSTRING, segments[1:2]
+	default:
+		for i, seg := range segments {
+			segments[i] = escapeWildcard(seg)
+		}
+		s.Type, s.Values = QueryType_WILDCARD, []string{strings.Join(segments, "*")}
+	}
+	return s
+}
+
+// StarPattern returns pattern that StarString translates to a condition of the same type and value.
+// It reports false if the type is not supported, or if it is a WILDCARD pattern that uses "?".
+// Negation and insensitivity are not part of the pattern.
+func StarPattern(s *String) (string, bool) {
+	if s == nil || len(s.Values) != 1 {
+		return "", false
+	}
+	v := s.Values[0]
+	switch s.Type {
+	case QueryType_EQUAL:
+		return escapeStar(v), true
+	case QueryType_HAS_PREFIX:
+		return escapeStar(v) + "*", true
+	case QueryType_HAS_SUFFIX:
+		return "*" + escapeStar(v), true
+	case QueryType_SUBSTRING:
+		return "*" + escapeStar(v) + "*", true
+	case QueryType_WILDCARD:
+		var b strings.Builder
+		for i := 0; i < len(v); i++ {
+			switch c := v[i]; c {
+			case '?':
+				return "", false
+			case '\\':
+				if i+1 < len(v) {
+					i++
+				}
+				b.WriteString(escapeStar(v[i : i+1]))
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return b.String(), true
+	default:
+		return "", false
+	}
+}
+
+// escapeWildcard escapes characters that are special in WILDCARD patterns.
+func escapeWildcard(s string) string {
+	return escape(s, `*?\`)
+}
+
+// escapeStar escapes characters that are special in StarString patterns.
+func escapeStar(s string) string {
+	return escape(s, `*\`)
+}
+
+func escape(s, special string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(special, s[i]) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+package qtypes
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMatchWildcard(t *testing.T) {
+	cases := map[string]struct {
+		pattern     string
+		given       string
+		insensitive bool
+		expected    bool
+	}{
+		"exact":                {pattern: "john", given: "john", expected: true},
+		"exact-mismatch":       {pattern: "john", given: "jon", expected: false},
+		"star":                 {pattern: "jo*n?", given: "johnny", expected: true},
+		"star-empty":           {pattern: "jo*n?", given: "jon!", expected: true},
+		"question-mark":        {pattern: "jo?n", given: "jon", expected: false},
+		"question-mark-rune":   {pattern: "za?", given: "zaż", expected: true},
+		"only-star":            {pattern: "*", given: "", expected: true},
+		"empty":                {pattern: "", given: "a", expected: false},
+		"backtracking":         {pattern: "*ab*ba", given: "aabbaba", expected: true},
+		"escaped-star":         {pattern: `a\*`, given: "a*", expected: true},
+		"escaped-star-literal": {pattern: `a\*`, given: "ab", expected: false},
+		"escaped-backslash":    {pattern: `a\\*`, given: `a\b`, expected: true},
+		"trailing-backslash":   {pattern: `a\`, given: `a\`, expected: true},
+		"sensitive":            {pattern: "JO*", given: "john", expected: false},
+		"insensitive":          {pattern: "JO*", given: "john", insensitive: true, expected: true},
+		"many-stars":           {pattern: strings.Repeat("*a", 50) + "b", given: strings.Repeat("a", 1000), expected: false},
+	}
+
+	for hint, c := range cases {
+		if got := MatchWildcard(c.pattern, c.given, c.insensitive); got != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestWildcardToLike(t *testing.T) {
+	cases := map[string]struct {
+		given    string
+		expected string
+	}{
+		"plain":              {given: "john", expected: "john"},
+		"wildcards":          {given: "jo*n?", expected: "jo%n_"},
+		"literal-percent":    {given: "100%*", expected: `100\%%`},
+		"literal-underscore": {given: "snake_case", expected: `snake\_case`},
+		"escaped-wildcards":  {given: `\*\?`, expected: "*?"},
+		"escaped-percent":    {given: `\%`, expected: `\%`},
+		"escaped-backslash":  {given: `a\\b`, expected: `a\\b`},
+		"trailing-backslash": {given: `a\`, expected: `a\\`},
+	}
+
+	for hint, c := range cases {
+		if got := WildcardToLike(c.given); got != c.expected {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+		}
+	}
+}
+
+func TestIntersectString_wildcard(t *testing.T) {
+	got, err := IntersectString(inString("john", "jane", "bob"), &String{Values: []string{"J*"}, Valid: true, Type: QueryType_WILDCARD, Insensitive: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := inString("john", "jane"); !EquivalentString(got, expected) {
+		t.Errorf("wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", expected, got)
+	}
+}
+
+func TestStarString(t *testing.T) {
+	cases := map[string]struct {
+		given    string
+		expected *String
+	}{
+		"equal":           {given: "john", expected: EqualString("john")},
+		"empty":           {given: "", expected: EqualString("")},
+		"prefix":          {given: "jo*", expected: HasPrefixString("jo")},
+		"suffix":          {given: "*.com", expected: HasSuffixString(".com")},
+		"substring":       {given: "*oh*", expected: SubString("oh")},
+		"escaped-star":    {given: `a\*`, expected: EqualString("a*")},
+		"escaped-prefix":  {given: `a\**`, expected: HasPrefixString("a*")},
+		"question-mark":   {given: "a?", expected: EqualString("a?")},
+		"wildcard":        {given: `j*n?\\*`, expected: WildcardString(`j*n\?\\*`)},
+		"only-star":       {given: "*", expected: WildcardString("*")},
+		"double-star":     {given: "**", expected: WildcardString("**")},
+		"infix":           {given: "*a*b*", expected: WildcardString("*a*b*")},
+		"trailing-escape": {given: `a\`, expected: EqualString(`a\`)},
+	}
+
+	for hint, c := range cases {
+		got := StarString(c.given)
+		if !proto.Equal(got, c.expected) {
+			t.Errorf("%s: wrong output,\nexpected:\n	%v\nbut got:\n	%v\n", hint, c.expected, got)
+			continue
+		}
+		pattern, ok := StarPattern(got)
+		if !ok {
+			t.Errorf("%s: expected pattern", hint)
+			continue
+		}
+		if again := StarString(pattern); !proto.Equal(again, c.expected) {
+			t.Errorf("%s: wrong round trip output for pattern %q,\nexpected:\n	%v\nbut got:\n	%v\n", hint, pattern, c.expected, again)
+		}
+	}
+}
+
+func TestStarPattern(t *testing.T) {
+	cases := map[string]struct {
+		given    *String
+		expected string
+		ok       bool
+	}{
+		"equal":             {given: EqualString(`a*\`), expected: `a\*\\`, ok: true},
+		"substring":         {given: SubString("oh"), expected: "*oh*", ok: true},
+		"wildcard":          {given: WildcardString(`jo*\?`), expected: "jo*?", ok: true},
+		"wildcard-question": {given: WildcardString("jo?"), ok: false},
+		"pattern":           {given: &String{Values: []string{"^a"}, Valid: true, Type: QueryType_PATTERN}, ok: false},
+		"in":                {given: inString("a", "b"), ok: false},
+		"nil":               {given: nil, ok: false},
+	}
+
+	for hint, c := range cases {
+		got, ok := StarPattern(c.given)
+		if ok != c.ok || got != c.expected {
+			t.Errorf("%s: wrong output, expected %q (%t) but got %q (%t)", hint, c.expected, c.ok, got, ok)
+		}
+	}
+}